@@ -19,7 +19,7 @@ import (
 func newGateway(ctx context.Context, conn *grpc.ClientConn, opts []gwruntime.ServeMuxOption) (http.Handler, error) {
 	mux := gwruntime.NewServeMux(opts...)
 
-	for _, f := range []func(context.Context, *gwruntime.ServeMux, *grpc.ClientConn) error{
+	for _, f := range []func(context.Context, *gwruntime.ServeMux, *grpc.ClientConn, ...gwruntime.RegisterOption) error{
 		examplepb.RegisterEchoServiceHandler,
 		standalone.RegisterUnannotatedEchoServiceHandler,
 		examplepb.RegisterStreamServiceHandler,
@@ -1328,14 +1328,14 @@ func testABEDownload(t *testing.T, port int) {
 		t.Fatalf("testABEDownload() Content-Type failed: got %s, want %s", value, wantHeader)
 	}
 
-	body, err := readAll(resp.Body)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		t.Fatalf("readAll(resp.Body) failed with %v; want success", err)
+		t.Fatalf("io.ReadAll(resp.Body) failed with %v; want success", err)
 	}
 
-	want := []string{"Hello 1", "Hello 2"}
-	if !reflect.DeepEqual(body, want) {
-		t.Errorf("testABEDownload() failed: got %v, want %v", body, want)
+	// The chunks of an HttpBody stream are written back to back.
+	if got, want := string(body), "Hello 1Hello 2"; got != want {
+		t.Errorf("testABEDownload() failed: got %q, want %q", got, want)
 	}
 }
 
@@ -1870,17 +1870,17 @@ func TestResponseBodyStreamHttpBody(t *testing.T) {
 	}
 
 	defer resp.Body.Close()
-	body, err := readAll(resp.Body)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		t.Fatalf("readAll(resp.Body) failed with %v; want success", err)
+		t.Fatalf("io.ReadAll(resp.Body) failed with %v; want success", err)
 	}
 
 	if got, want := resp.StatusCode, http.StatusOK; got != want {
 		t.Errorf("resp.StatusCode = %d; want %d", got, want)
 	}
 
-	if diff := cmp.Diff(body, []string{"Hello 1", "Hello 2"}); diff != "" {
-		t.Error(diff)
+	if got, want := string(body), "Hello 1Hello 2"; got != want {
+		t.Errorf("body = %q; want %q", got, want)
 	}
 }
 
@@ -1901,7 +1901,7 @@ func TestResponseBodyStreamHttpBodyError(t *testing.T) {
 		t.Errorf("resp.StatusCode = %d; want %d", got, want)
 	}
 
-	if diff := cmp.Diff(body, []string{"Hello 1", "Hello 2", `{"error":{"code":3,"message":"error","details":[]}}`}); diff != "" {
+	if diff := cmp.Diff(body, []string{`Hello 1Hello 2{"error":{"code":3,"message":"error","details":[]}}`}); diff != "" {
 		t.Error(diff)
 	}
 }
@@ -396,7 +396,7 @@ func request_ABitOfEverythingService_CreateBody_0(ctx context.Context, marshaler
 		protoReq ABitOfEverything
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -411,7 +411,7 @@ func local_request_ABitOfEverythingService_CreateBody_0(ctx context.Context, mar
 		protoReq ABitOfEverything
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.CreateBody(ctx, &protoReq)
@@ -426,7 +426,7 @@ func request_ABitOfEverythingService_CreateBook_0(ctx context.Context, marshaler
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq.Book); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq.Book); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -456,7 +456,7 @@ func local_request_ABitOfEverythingService_CreateBook_0(ctx context.Context, mar
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq.Book); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq.Book); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	val, ok := pathParams["parent"]
@@ -489,7 +489,7 @@ func request_ABitOfEverythingService_UpdateBook_0(ctx context.Context, marshaler
 	if berr != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
 	}
-	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Book); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(newReader()), &protoReq.Book); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -530,7 +530,7 @@ func local_request_ABitOfEverythingService_UpdateBook_0(ctx context.Context, mar
 	if berr != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
 	}
-	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Book); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(newReader()), &protoReq.Book); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if protoReq.UpdateMask == nil || len(protoReq.UpdateMask.GetPaths()) == 0 {
@@ -709,7 +709,7 @@ func request_ABitOfEverythingService_Update_0(ctx context.Context, marshaler run
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -733,7 +733,7 @@ func local_request_ABitOfEverythingService_Update_0(ctx context.Context, marshal
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	val, ok := pathParams["uuid"]
@@ -756,7 +756,7 @@ func request_ABitOfEverythingService_UpdateV2_0(ctx context.Context, marshaler r
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq.Abe); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq.Abe); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -786,7 +786,7 @@ func local_request_ABitOfEverythingService_UpdateV2_0(ctx context.Context, marsh
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq.Abe); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq.Abe); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	val, ok := pathParams["abe.uuid"]
@@ -819,7 +819,7 @@ func request_ABitOfEverythingService_UpdateV2_1(ctx context.Context, marshaler r
 	if berr != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
 	}
-	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Abe); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(newReader()), &protoReq.Abe); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -860,7 +860,7 @@ func local_request_ABitOfEverythingService_UpdateV2_1(ctx context.Context, marsh
 	if berr != nil {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", berr)
 	}
-	if err := marshaler.NewDecoder(newReader()).Decode(&protoReq.Abe); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(newReader()), &protoReq.Abe); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if protoReq.UpdateMask == nil || len(protoReq.UpdateMask.GetPaths()) == 0 {
@@ -894,7 +894,7 @@ func request_ABitOfEverythingService_UpdateV2_2(ctx context.Context, marshaler r
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -918,7 +918,7 @@ func local_request_ABitOfEverythingService_UpdateV2_2(ctx context.Context, marsh
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	val, ok := pathParams["abe.uuid"]
@@ -1360,7 +1360,7 @@ func request_ABitOfEverythingService_Echo_1(ctx context.Context, marshaler runti
 		protoReq sub.StringMessage
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq.Value); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq.Value); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -1375,7 +1375,7 @@ func local_request_ABitOfEverythingService_Echo_1(ctx context.Context, marshaler
 		protoReq sub.StringMessage
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq.Value); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq.Value); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.Echo(ctx, &protoReq)
@@ -1423,7 +1423,7 @@ func request_ABitOfEverythingService_DeepPathEcho_0(ctx context.Context, marshal
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -1447,7 +1447,7 @@ func local_request_ABitOfEverythingService_DeepPathEcho_0(ctx context.Context, m
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	val, ok := pathParams["single_nested.name"]
@@ -1510,7 +1510,7 @@ func request_ABitOfEverythingService_GetMessageWithBody_0(ctx context.Context, m
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq.Data); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq.Data); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -1534,7 +1534,7 @@ func local_request_ABitOfEverythingService_GetMessageWithBody_0(ctx context.Cont
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq.Data); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq.Data); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	val, ok := pathParams["id"]
@@ -1555,7 +1555,7 @@ func request_ABitOfEverythingService_PostWithEmptyBody_0(ctx context.Context, ma
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -1579,7 +1579,7 @@ func local_request_ABitOfEverythingService_PostWithEmptyBody_0(ctx context.Conte
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	val, ok := pathParams["name"]
@@ -1720,7 +1720,7 @@ func request_ABitOfEverythingService_CheckPostQueryParams_0(ctx context.Context,
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq.SingleNested); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq.SingleNested); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -1750,7 +1750,7 @@ func local_request_ABitOfEverythingService_CheckPostQueryParams_0(ctx context.Co
 		metadata runtime.ServerMetadata
 		err      error
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq.SingleNested); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq.SingleNested); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	val, ok := pathParams["string_value"]
@@ -1776,7 +1776,7 @@ func request_ABitOfEverythingService_OverwriteRequestContentType_0(ctx context.C
 		protoReq Body
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -1791,7 +1791,7 @@ func local_request_ABitOfEverythingService_OverwriteRequestContentType_0(ctx con
 		protoReq Body
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.OverwriteRequestContentType(ctx, &protoReq)
@@ -2095,7 +2095,7 @@ func request_ABitOfEverythingService_PostOneofEnum_0(ctx context.Context, marsha
 	} else if _, ok := protoReq.One.(*oneofenum.OneofEnumMessage_ExampleEnum); !ok {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "expect type: *oneofenum.OneofEnumMessage_ExampleEnum, but: %t\n", protoReq.One)
 	}
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq.One.(*oneofenum.OneofEnumMessage_ExampleEnum).ExampleEnum); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq.One.(*oneofenum.OneofEnumMessage_ExampleEnum).ExampleEnum); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -2115,7 +2115,7 @@ func local_request_ABitOfEverythingService_PostOneofEnum_0(ctx context.Context,
 	} else if _, ok := protoReq.One.(*oneofenum.OneofEnumMessage_ExampleEnum); !ok {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "expect type: *oneofenum.OneofEnumMessage_ExampleEnum, but: %t\n", protoReq.One)
 	}
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq.One.(*oneofenum.OneofEnumMessage_ExampleEnum).ExampleEnum); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq.One.(*oneofenum.OneofEnumMessage_ExampleEnum).ExampleEnum); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.PostOneofEnum(ctx, &protoReq)
@@ -2127,7 +2127,7 @@ func request_ABitOfEverythingService_PostRequiredMessageType_0(ctx context.Conte
 		protoReq RequiredMessageTypeRequest
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -2142,7 +2142,7 @@ func local_request_ABitOfEverythingService_PostRequiredMessageType_0(ctx context
 		protoReq RequiredMessageTypeRequest
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.PostRequiredMessageType(ctx, &protoReq)
@@ -2254,8 +2254,8 @@ func local_request_SnakeEnumService_SnakeEnum_0(ctx context.Context, marshaler r
 // StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterABitOfEverythingServiceHandlerFromEndpoint instead.
 // GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
-func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server ABitOfEverythingServiceServer) error {
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_Create_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server ABitOfEverythingServiceServer, opts ...runtime.RegisterOption) error {
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_Create_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Create", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2274,8 +2274,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Create_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_CreateBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_CreateBody_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CreateBody", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2294,8 +2294,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CreateBody_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_CreateBook_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_CreateBook_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CreateBook", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2314,8 +2314,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CreateBook_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPatch, pattern_ABitOfEverythingService_UpdateBook_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPatch, pattern_ABitOfEverythingService_UpdateBook_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateBook", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2334,8 +2334,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_UpdateBook_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Lookup_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_Lookup_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Lookup", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2354,8 +2354,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Lookup_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_Custom_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_Custom_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Custom", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2374,8 +2374,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Custom_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_DoubleColon_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_DoubleColon_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/DoubleColon", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2394,8 +2394,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_DoubleColon_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPut, pattern_ABitOfEverythingService_Update_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPut, pattern_ABitOfEverythingService_Update_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Update", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2414,8 +2414,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Update_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPut, pattern_ABitOfEverythingService_UpdateV2_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPut, pattern_ABitOfEverythingService_UpdateV2_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2434,8 +2434,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_UpdateV2_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_1, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2", 1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2454,8 +2454,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_UpdateV2_1(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_2, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2", 2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2474,8 +2474,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_UpdateV2_2(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodDelete, pattern_ABitOfEverythingService_Delete_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodDelete, pattern_ABitOfEverythingService_Delete_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Delete", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2494,8 +2494,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Delete_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_GetQuery_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_GetQuery_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetQuery", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2514,8 +2514,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_GetQuery_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_GetRepeatedQuery_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_GetRepeatedQuery_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetRepeatedQuery", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2534,8 +2534,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_GetRepeatedQuery_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_Echo_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2554,8 +2554,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Echo_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_Echo_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_Echo_1, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo", 1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2574,8 +2574,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Echo_1(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Echo_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_Echo_2, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo", 2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2594,8 +2594,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Echo_2(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_DeepPathEcho_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_DeepPathEcho_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/DeepPathEcho", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2614,8 +2614,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_DeepPathEcho_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Timeout_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_Timeout_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Timeout", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2634,8 +2634,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Timeout_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_ErrorWithDetails_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_ErrorWithDetails_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/ErrorWithDetails", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2654,8 +2654,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_ErrorWithDetails_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_GetMessageWithBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_GetMessageWithBody_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetMessageWithBody", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2674,8 +2674,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_GetMessageWithBody_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_PostWithEmptyBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_PostWithEmptyBody_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostWithEmptyBody", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2694,8 +2694,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_PostWithEmptyBody_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckGetQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_CheckGetQueryParams_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckGetQueryParams", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2714,8 +2714,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CheckGetQueryParams_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckNestedEnumGetQueryParams", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2734,8 +2734,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_CheckPostQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_CheckPostQueryParams_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckPostQueryParams", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2754,8 +2754,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CheckPostQueryParams_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_OverwriteRequestContentType_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_OverwriteRequestContentType_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/OverwriteRequestContentType", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2774,8 +2774,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_OverwriteRequestContentType_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_OverwriteResponseContentType_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_OverwriteResponseContentType_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/OverwriteResponseContentType", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2794,8 +2794,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_OverwriteResponseContentType_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalPathEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalPathEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckExternalPathEnum", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2814,8 +2814,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CheckExternalPathEnum_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalNestedPathEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalNestedPathEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckExternalNestedPathEnum", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2834,8 +2834,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CheckExternalNestedPathEnum_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckStatus_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_CheckStatus_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckStatus", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2854,8 +2854,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CheckStatus_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodHead, pattern_ABitOfEverythingService_Exists_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodHead, pattern_ABitOfEverythingService_Exists_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Exists", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2874,8 +2874,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Exists_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodOptions, pattern_ABitOfEverythingService_CustomOptionsRequest_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodOptions, pattern_ABitOfEverythingService_CustomOptionsRequest_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CustomOptionsRequest", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2894,8 +2894,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CustomOptionsRequest_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodTrace, pattern_ABitOfEverythingService_TraceRequest_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodTrace, pattern_ABitOfEverythingService_TraceRequest_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/TraceRequest", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2914,8 +2914,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_TraceRequest_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_PostOneofEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_PostOneofEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostOneofEnum", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2934,8 +2934,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_PostOneofEnum_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_PostRequiredMessageType_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_PostRequiredMessageType_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostRequiredMessageType", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2954,7 +2954,7 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_PostRequiredMessageType_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
+	}, opts...)
 
 	return nil
 }
@@ -2964,8 +2964,8 @@ func RegisterABitOfEverythingServiceHandlerServer(ctx context.Context, mux *runt
 // StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterCamelCaseServiceNameHandlerFromEndpoint instead.
 // GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
-func RegisterCamelCaseServiceNameHandlerServer(ctx context.Context, mux *runtime.ServeMux, server CamelCaseServiceNameServer) error {
-	mux.Handle(http.MethodGet, pattern_CamelCaseServiceName_Empty_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+func RegisterCamelCaseServiceNameHandlerServer(ctx context.Context, mux *runtime.ServeMux, server CamelCaseServiceNameServer, opts ...runtime.RegisterOption) error {
+	mux.HandleBinding(http.MethodGet, pattern_CamelCaseServiceName_Empty_0, "/grpc.gateway.examples.internal.proto.examplepb.CamelCaseServiceName/Empty", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -2984,7 +2984,7 @@ func RegisterCamelCaseServiceNameHandlerServer(ctx context.Context, mux *runtime
 			return
 		}
 		forward_CamelCaseServiceName_Empty_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
+	}, opts...)
 
 	return nil
 }
@@ -2994,8 +2994,8 @@ func RegisterCamelCaseServiceNameHandlerServer(ctx context.Context, mux *runtime
 // StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterSnakeEnumServiceHandlerFromEndpoint instead.
 // GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
-func RegisterSnakeEnumServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server SnakeEnumServiceServer) error {
-	mux.Handle(http.MethodGet, pattern_SnakeEnumService_SnakeEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+func RegisterSnakeEnumServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server SnakeEnumServiceServer, opts ...runtime.RegisterOption) error {
+	mux.HandleBinding(http.MethodGet, pattern_SnakeEnumService_SnakeEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.SnakeEnumService/SnakeEnum", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -3014,14 +3014,14 @@ func RegisterSnakeEnumServiceHandlerServer(ctx context.Context, mux *runtime.Ser
 			return
 		}
 		forward_SnakeEnumService_SnakeEnum_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
+	}, opts...)
 
 	return nil
 }
 
 // RegisterABitOfEverythingServiceHandlerFromEndpoint is same as RegisterABitOfEverythingServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
-func RegisterABitOfEverythingServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+func RegisterABitOfEverythingServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, registerOpts ...runtime.RegisterOption) (err error) {
 	conn, err := grpc.NewClient(endpoint, opts...)
 	if err != nil {
 		return err
@@ -3040,22 +3040,24 @@ func RegisterABitOfEverythingServiceHandlerFromEndpoint(ctx context.Context, mux
 			}
 		}()
 	}()
-	return RegisterABitOfEverythingServiceHandler(ctx, mux, conn)
+	return RegisterABitOfEverythingServiceHandler(ctx, mux, conn, registerOpts...)
 }
 
 // RegisterABitOfEverythingServiceHandler registers the http handlers for service ABitOfEverythingService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
-func RegisterABitOfEverythingServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterABitOfEverythingServiceHandlerClient(ctx, mux, NewABitOfEverythingServiceClient(conn))
+// The bindings registered, and their paths, are selected by "opts".
+func RegisterABitOfEverythingServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts ...runtime.RegisterOption) error {
+	return RegisterABitOfEverythingServiceHandlerClient(ctx, mux, NewABitOfEverythingServiceClient(conn), opts...)
 }
 
 // RegisterABitOfEverythingServiceHandlerClient registers the http handlers for service ABitOfEverythingService
 // to "mux". The handlers forward requests to the grpc endpoint over the given implementation of "ABitOfEverythingServiceClient".
+// The methods of the service are also served with the protocols set by runtime.WithRPCProtocols, if any.
 // Note: the gRPC framework executes interceptors within the gRPC handler. If the passed in "ABitOfEverythingServiceClient"
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "ABitOfEverythingServiceClient" to call the correct interceptors. This client ignores the HTTP middlewares.
-func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient) error {
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_Create_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ABitOfEverythingServiceClient, opts ...runtime.RegisterOption) error {
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_Create_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Create", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3071,8 +3073,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Create_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_CreateBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_CreateBody_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CreateBody", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3088,8 +3090,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CreateBody_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_CreateBook_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_CreateBook_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CreateBook", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3105,8 +3107,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CreateBook_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPatch, pattern_ABitOfEverythingService_UpdateBook_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPatch, pattern_ABitOfEverythingService_UpdateBook_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateBook", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3122,8 +3124,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_UpdateBook_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Lookup_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_Lookup_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Lookup", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3139,8 +3141,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Lookup_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_Custom_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_Custom_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Custom", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3156,8 +3158,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Custom_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_DoubleColon_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_DoubleColon_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/DoubleColon", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3173,8 +3175,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_DoubleColon_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPut, pattern_ABitOfEverythingService_Update_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPut, pattern_ABitOfEverythingService_Update_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Update", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3190,8 +3192,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Update_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPut, pattern_ABitOfEverythingService_UpdateV2_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPut, pattern_ABitOfEverythingService_UpdateV2_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3207,8 +3209,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_UpdateV2_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_1, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2", 1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3224,8 +3226,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_UpdateV2_1(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPatch, pattern_ABitOfEverythingService_UpdateV2_2, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2", 2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3241,8 +3243,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_UpdateV2_2(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodDelete, pattern_ABitOfEverythingService_Delete_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodDelete, pattern_ABitOfEverythingService_Delete_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Delete", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3258,8 +3260,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Delete_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_GetQuery_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_GetQuery_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetQuery", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3275,8 +3277,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_GetQuery_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_GetRepeatedQuery_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_GetRepeatedQuery_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetRepeatedQuery", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3292,8 +3294,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_GetRepeatedQuery_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_Echo_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3309,8 +3311,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Echo_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_Echo_1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_Echo_1, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo", 1, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3326,8 +3328,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Echo_1(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Echo_2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_Echo_2, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo", 2, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3343,8 +3345,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Echo_2(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_DeepPathEcho_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_DeepPathEcho_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/DeepPathEcho", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3360,8 +3362,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_DeepPathEcho_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_Timeout_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_Timeout_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Timeout", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3377,8 +3379,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Timeout_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_ErrorWithDetails_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_ErrorWithDetails_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/ErrorWithDetails", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3394,8 +3396,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_ErrorWithDetails_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_GetMessageWithBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_GetMessageWithBody_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetMessageWithBody", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3411,8 +3413,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_GetMessageWithBody_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_PostWithEmptyBody_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_PostWithEmptyBody_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostWithEmptyBody", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3428,8 +3430,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_PostWithEmptyBody_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckGetQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_CheckGetQueryParams_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckGetQueryParams", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3445,8 +3447,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CheckGetQueryParams_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckNestedEnumGetQueryParams", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3462,8 +3464,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CheckNestedEnumGetQueryParams_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_CheckPostQueryParams_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_CheckPostQueryParams_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckPostQueryParams", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3479,8 +3481,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CheckPostQueryParams_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_OverwriteRequestContentType_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_OverwriteRequestContentType_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/OverwriteRequestContentType", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3496,8 +3498,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_OverwriteRequestContentType_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_OverwriteResponseContentType_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_OverwriteResponseContentType_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/OverwriteResponseContentType", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3513,8 +3515,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_OverwriteResponseContentType_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalPathEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalPathEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckExternalPathEnum", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3530,8 +3532,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CheckExternalPathEnum_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalNestedPathEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_CheckExternalNestedPathEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckExternalNestedPathEnum", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3547,8 +3549,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CheckExternalNestedPathEnum_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_ABitOfEverythingService_CheckStatus_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_ABitOfEverythingService_CheckStatus_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckStatus", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3564,8 +3566,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CheckStatus_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodHead, pattern_ABitOfEverythingService_Exists_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodHead, pattern_ABitOfEverythingService_Exists_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Exists", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3581,8 +3583,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_Exists_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodOptions, pattern_ABitOfEverythingService_CustomOptionsRequest_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodOptions, pattern_ABitOfEverythingService_CustomOptionsRequest_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CustomOptionsRequest", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3598,8 +3600,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_CustomOptionsRequest_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodTrace, pattern_ABitOfEverythingService_TraceRequest_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodTrace, pattern_ABitOfEverythingService_TraceRequest_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/TraceRequest", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3615,8 +3617,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_TraceRequest_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_PostOneofEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_PostOneofEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostOneofEnum", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3632,8 +3634,8 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_PostOneofEnum_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ABitOfEverythingService_PostRequiredMessageType_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ABitOfEverythingService_PostRequiredMessageType_0, "/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostRequiredMessageType", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3649,7 +3651,39 @@ func RegisterABitOfEverythingServiceHandlerClient(ctx context.Context, mux *runt
 			return
 		}
 		forward_ABitOfEverythingService_PostRequiredMessageType_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
+	}, opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Create", runtime.UnaryRPC(client.Create), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CreateBody", runtime.UnaryRPC(client.CreateBody), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CreateBook", runtime.UnaryRPC(client.CreateBook), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateBook", runtime.UnaryRPC(client.UpdateBook), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Lookup", runtime.UnaryRPC(client.Lookup), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Custom", runtime.UnaryRPC(client.Custom), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/DoubleColon", runtime.UnaryRPC(client.DoubleColon), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Update", runtime.UnaryRPC(client.Update), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/UpdateV2", runtime.UnaryRPC(client.UpdateV2), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Delete", runtime.UnaryRPC(client.Delete), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetQuery", runtime.UnaryRPC(client.GetQuery), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetRepeatedQuery", runtime.UnaryRPC(client.GetRepeatedQuery), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Echo", runtime.UnaryRPC(client.Echo), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/DeepPathEcho", runtime.UnaryRPC(client.DeepPathEcho), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/NoBindings", runtime.UnaryRPC(client.NoBindings), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Timeout", runtime.UnaryRPC(client.Timeout), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/ErrorWithDetails", runtime.UnaryRPC(client.ErrorWithDetails), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/GetMessageWithBody", runtime.UnaryRPC(client.GetMessageWithBody), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostWithEmptyBody", runtime.UnaryRPC(client.PostWithEmptyBody), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckGetQueryParams", runtime.UnaryRPC(client.CheckGetQueryParams), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckNestedEnumGetQueryParams", runtime.UnaryRPC(client.CheckNestedEnumGetQueryParams), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckPostQueryParams", runtime.UnaryRPC(client.CheckPostQueryParams), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/OverwriteRequestContentType", runtime.UnaryRPC(client.OverwriteRequestContentType), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/OverwriteResponseContentType", runtime.UnaryRPC(client.OverwriteResponseContentType), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckExternalPathEnum", runtime.UnaryRPC(client.CheckExternalPathEnum), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckExternalNestedPathEnum", runtime.UnaryRPC(client.CheckExternalNestedPathEnum), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CheckStatus", runtime.UnaryRPC(client.CheckStatus), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/Exists", runtime.UnaryRPC(client.Exists), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/CustomOptionsRequest", runtime.UnaryRPC(client.CustomOptionsRequest), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/TraceRequest", runtime.UnaryRPC(client.TraceRequest), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostOneofEnum", runtime.UnaryRPC(client.PostOneofEnum), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ABitOfEverythingService/PostRequiredMessageType", runtime.UnaryRPC(client.PostRequiredMessageType), opts...)
 	return nil
 }
 
@@ -3731,7 +3765,7 @@ var (
 
 // RegisterCamelCaseServiceNameHandlerFromEndpoint is same as RegisterCamelCaseServiceNameHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
-func RegisterCamelCaseServiceNameHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+func RegisterCamelCaseServiceNameHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, registerOpts ...runtime.RegisterOption) (err error) {
 	conn, err := grpc.NewClient(endpoint, opts...)
 	if err != nil {
 		return err
@@ -3750,22 +3784,24 @@ func RegisterCamelCaseServiceNameHandlerFromEndpoint(ctx context.Context, mux *r
 			}
 		}()
 	}()
-	return RegisterCamelCaseServiceNameHandler(ctx, mux, conn)
+	return RegisterCamelCaseServiceNameHandler(ctx, mux, conn, registerOpts...)
 }
 
 // RegisterCamelCaseServiceNameHandler registers the http handlers for service CamelCaseServiceName to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
-func RegisterCamelCaseServiceNameHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterCamelCaseServiceNameHandlerClient(ctx, mux, NewCamelCaseServiceNameClient(conn))
+// The bindings registered, and their paths, are selected by "opts".
+func RegisterCamelCaseServiceNameHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts ...runtime.RegisterOption) error {
+	return RegisterCamelCaseServiceNameHandlerClient(ctx, mux, NewCamelCaseServiceNameClient(conn), opts...)
 }
 
 // RegisterCamelCaseServiceNameHandlerClient registers the http handlers for service CamelCaseServiceName
 // to "mux". The handlers forward requests to the grpc endpoint over the given implementation of "CamelCaseServiceNameClient".
+// The methods of the service are also served with the protocols set by runtime.WithRPCProtocols, if any.
 // Note: the gRPC framework executes interceptors within the gRPC handler. If the passed in "CamelCaseServiceNameClient"
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "CamelCaseServiceNameClient" to call the correct interceptors. This client ignores the HTTP middlewares.
-func RegisterCamelCaseServiceNameHandlerClient(ctx context.Context, mux *runtime.ServeMux, client CamelCaseServiceNameClient) error {
-	mux.Handle(http.MethodGet, pattern_CamelCaseServiceName_Empty_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+func RegisterCamelCaseServiceNameHandlerClient(ctx context.Context, mux *runtime.ServeMux, client CamelCaseServiceNameClient, opts ...runtime.RegisterOption) error {
+	mux.HandleBinding(http.MethodGet, pattern_CamelCaseServiceName_Empty_0, "/grpc.gateway.examples.internal.proto.examplepb.CamelCaseServiceName/Empty", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3781,7 +3817,8 @@ func RegisterCamelCaseServiceNameHandlerClient(ctx context.Context, mux *runtime
 			return
 		}
 		forward_CamelCaseServiceName_Empty_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
+	}, opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.camelCaseServiceName/Empty", runtime.UnaryRPC(client.Empty), opts...)
 	return nil
 }
 
@@ -3795,7 +3832,7 @@ var (
 
 // RegisterSnakeEnumServiceHandlerFromEndpoint is same as RegisterSnakeEnumServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
-func RegisterSnakeEnumServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+func RegisterSnakeEnumServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, registerOpts ...runtime.RegisterOption) (err error) {
 	conn, err := grpc.NewClient(endpoint, opts...)
 	if err != nil {
 		return err
@@ -3814,22 +3851,24 @@ func RegisterSnakeEnumServiceHandlerFromEndpoint(ctx context.Context, mux *runti
 			}
 		}()
 	}()
-	return RegisterSnakeEnumServiceHandler(ctx, mux, conn)
+	return RegisterSnakeEnumServiceHandler(ctx, mux, conn, registerOpts...)
 }
 
 // RegisterSnakeEnumServiceHandler registers the http handlers for service SnakeEnumService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
-func RegisterSnakeEnumServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterSnakeEnumServiceHandlerClient(ctx, mux, NewSnakeEnumServiceClient(conn))
+// The bindings registered, and their paths, are selected by "opts".
+func RegisterSnakeEnumServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts ...runtime.RegisterOption) error {
+	return RegisterSnakeEnumServiceHandlerClient(ctx, mux, NewSnakeEnumServiceClient(conn), opts...)
 }
 
 // RegisterSnakeEnumServiceHandlerClient registers the http handlers for service SnakeEnumService
 // to "mux". The handlers forward requests to the grpc endpoint over the given implementation of "SnakeEnumServiceClient".
+// The methods of the service are also served with the protocols set by runtime.WithRPCProtocols, if any.
 // Note: the gRPC framework executes interceptors within the gRPC handler. If the passed in "SnakeEnumServiceClient"
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "SnakeEnumServiceClient" to call the correct interceptors. This client ignores the HTTP middlewares.
-func RegisterSnakeEnumServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client SnakeEnumServiceClient) error {
-	mux.Handle(http.MethodGet, pattern_SnakeEnumService_SnakeEnum_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+func RegisterSnakeEnumServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client SnakeEnumServiceClient, opts ...runtime.RegisterOption) error {
+	mux.HandleBinding(http.MethodGet, pattern_SnakeEnumService_SnakeEnum_0, "/grpc.gateway.examples.internal.proto.examplepb.SnakeEnumService/SnakeEnum", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -3845,7 +3884,8 @@ func RegisterSnakeEnumServiceHandlerClient(ctx context.Context, mux *runtime.Ser
 			return
 		}
 		forward_SnakeEnumService_SnakeEnum_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
+	}, opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.SnakeEnumService/SnakeEnum", runtime.UnaryRPC(client.SnakeEnum), opts...)
 	return nil
 }
 
@@ -40,7 +40,7 @@ func request_EnumWithSingleValueService_Echo_0(ctx context.Context, marshaler ru
 		protoReq EnumWithSingleValueServiceEchoRequest
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -55,7 +55,7 @@ func local_request_EnumWithSingleValueService_Echo_0(ctx context.Context, marsha
 		protoReq EnumWithSingleValueServiceEchoRequest
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.Echo(ctx, &protoReq)
@@ -67,8 +67,8 @@ func local_request_EnumWithSingleValueService_Echo_0(ctx context.Context, marsha
 // StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterEnumWithSingleValueServiceHandlerFromEndpoint instead.
 // GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
-func RegisterEnumWithSingleValueServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server EnumWithSingleValueServiceServer) error {
-	mux.Handle(http.MethodPost, pattern_EnumWithSingleValueService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+func RegisterEnumWithSingleValueServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server EnumWithSingleValueServiceServer, opts ...runtime.RegisterOption) error {
+	mux.HandleBinding(http.MethodPost, pattern_EnumWithSingleValueService_Echo_0, "/grpc.gateway.examples.internal.proto.examplepb.EnumWithSingleValueService/Echo", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -87,14 +87,14 @@ func RegisterEnumWithSingleValueServiceHandlerServer(ctx context.Context, mux *r
 			return
 		}
 		forward_EnumWithSingleValueService_Echo_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
+	}, opts...)
 
 	return nil
 }
 
 // RegisterEnumWithSingleValueServiceHandlerFromEndpoint is same as RegisterEnumWithSingleValueServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
-func RegisterEnumWithSingleValueServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+func RegisterEnumWithSingleValueServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, registerOpts ...runtime.RegisterOption) (err error) {
 	conn, err := grpc.NewClient(endpoint, opts...)
 	if err != nil {
 		return err
@@ -113,22 +113,24 @@ func RegisterEnumWithSingleValueServiceHandlerFromEndpoint(ctx context.Context,
 			}
 		}()
 	}()
-	return RegisterEnumWithSingleValueServiceHandler(ctx, mux, conn)
+	return RegisterEnumWithSingleValueServiceHandler(ctx, mux, conn, registerOpts...)
 }
 
 // RegisterEnumWithSingleValueServiceHandler registers the http handlers for service EnumWithSingleValueService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
-func RegisterEnumWithSingleValueServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterEnumWithSingleValueServiceHandlerClient(ctx, mux, NewEnumWithSingleValueServiceClient(conn))
+// The bindings registered, and their paths, are selected by "opts".
+func RegisterEnumWithSingleValueServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts ...runtime.RegisterOption) error {
+	return RegisterEnumWithSingleValueServiceHandlerClient(ctx, mux, NewEnumWithSingleValueServiceClient(conn), opts...)
 }
 
 // RegisterEnumWithSingleValueServiceHandlerClient registers the http handlers for service EnumWithSingleValueService
 // to "mux". The handlers forward requests to the grpc endpoint over the given implementation of "EnumWithSingleValueServiceClient".
+// The methods of the service are also served with the protocols set by runtime.WithRPCProtocols, if any.
 // Note: the gRPC framework executes interceptors within the gRPC handler. If the passed in "EnumWithSingleValueServiceClient"
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "EnumWithSingleValueServiceClient" to call the correct interceptors. This client ignores the HTTP middlewares.
-func RegisterEnumWithSingleValueServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client EnumWithSingleValueServiceClient) error {
-	mux.Handle(http.MethodPost, pattern_EnumWithSingleValueService_Echo_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+func RegisterEnumWithSingleValueServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client EnumWithSingleValueServiceClient, opts ...runtime.RegisterOption) error {
+	mux.HandleBinding(http.MethodPost, pattern_EnumWithSingleValueService_Echo_0, "/grpc.gateway.examples.internal.proto.examplepb.EnumWithSingleValueService/Echo", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -144,7 +146,8 @@ func RegisterEnumWithSingleValueServiceHandlerClient(ctx context.Context, mux *r
 			return
 		}
 		forward_EnumWithSingleValueService_Echo_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
+	}, opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.EnumWithSingleValueService/Echo", runtime.UnaryRPC(client.Echo), opts...)
 	return nil
 }
 
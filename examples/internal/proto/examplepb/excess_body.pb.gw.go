@@ -82,7 +82,7 @@ func request_ExcessBodyService_WithBodyRpc_0(ctx context.Context, marshaler runt
 		protoReq emptypb.Empty
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -97,7 +97,7 @@ func local_request_ExcessBodyService_WithBodyRpc_0(ctx context.Context, marshale
 		protoReq emptypb.Empty
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.WithBodyRpc(ctx, &protoReq)
@@ -109,7 +109,7 @@ func request_ExcessBodyService_WithBodyServerStream_0(ctx context.Context, marsh
 		protoReq emptypb.Empty
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -132,8 +132,8 @@ func request_ExcessBodyService_WithBodyServerStream_0(ctx context.Context, marsh
 // StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterExcessBodyServiceHandlerFromEndpoint instead.
 // GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
-func RegisterExcessBodyServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server ExcessBodyServiceServer) error {
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_NoBodyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+func RegisterExcessBodyServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server ExcessBodyServiceServer, opts ...runtime.RegisterOption) error {
+	mux.HandleBinding(http.MethodPost, pattern_ExcessBodyService_NoBodyRpc_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/NoBodyRpc", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -152,15 +152,15 @@ func RegisterExcessBodyServiceHandlerServer(ctx context.Context, mux *runtime.Se
 			return
 		}
 		forward_ExcessBodyService_NoBodyRpc_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
+	}, opts...)
 
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_NoBodyServerStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleBinding(http.MethodPost, pattern_ExcessBodyService_NoBodyServerStream_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/NoBodyServerStream", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
 		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 		return
-	})
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_WithBodyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ExcessBodyService_WithBodyRpc_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/WithBodyRpc", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -179,21 +179,21 @@ func RegisterExcessBodyServiceHandlerServer(ctx context.Context, mux *runtime.Se
 			return
 		}
 		forward_ExcessBodyService_WithBodyRpc_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
+	}, opts...)
 
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_WithBodyServerStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleBinding(http.MethodPost, pattern_ExcessBodyService_WithBodyServerStream_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/WithBodyServerStream", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
 		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 		return
-	})
+	}, opts...)
 
 	return nil
 }
 
 // RegisterExcessBodyServiceHandlerFromEndpoint is same as RegisterExcessBodyServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
-func RegisterExcessBodyServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+func RegisterExcessBodyServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, registerOpts ...runtime.RegisterOption) (err error) {
 	conn, err := grpc.NewClient(endpoint, opts...)
 	if err != nil {
 		return err
@@ -212,22 +212,24 @@ func RegisterExcessBodyServiceHandlerFromEndpoint(ctx context.Context, mux *runt
 			}
 		}()
 	}()
-	return RegisterExcessBodyServiceHandler(ctx, mux, conn)
+	return RegisterExcessBodyServiceHandler(ctx, mux, conn, registerOpts...)
 }
 
 // RegisterExcessBodyServiceHandler registers the http handlers for service ExcessBodyService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
-func RegisterExcessBodyServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterExcessBodyServiceHandlerClient(ctx, mux, NewExcessBodyServiceClient(conn))
+// The bindings registered, and their paths, are selected by "opts".
+func RegisterExcessBodyServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts ...runtime.RegisterOption) error {
+	return RegisterExcessBodyServiceHandlerClient(ctx, mux, NewExcessBodyServiceClient(conn), opts...)
 }
 
 // RegisterExcessBodyServiceHandlerClient registers the http handlers for service ExcessBodyService
 // to "mux". The handlers forward requests to the grpc endpoint over the given implementation of "ExcessBodyServiceClient".
+// The methods of the service are also served with the protocols set by runtime.WithRPCProtocols, if any.
 // Note: the gRPC framework executes interceptors within the gRPC handler. If the passed in "ExcessBodyServiceClient"
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "ExcessBodyServiceClient" to call the correct interceptors. This client ignores the HTTP middlewares.
-func RegisterExcessBodyServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ExcessBodyServiceClient) error {
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_NoBodyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+func RegisterExcessBodyServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ExcessBodyServiceClient, opts ...runtime.RegisterOption) error {
+	mux.HandleBinding(http.MethodPost, pattern_ExcessBodyService_NoBodyRpc_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/NoBodyRpc", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -243,8 +245,8 @@ func RegisterExcessBodyServiceHandlerClient(ctx context.Context, mux *runtime.Se
 			return
 		}
 		forward_ExcessBodyService_NoBodyRpc_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_NoBodyServerStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ExcessBodyService_NoBodyServerStream_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/NoBodyServerStream", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -260,8 +262,8 @@ func RegisterExcessBodyServiceHandlerClient(ctx context.Context, mux *runtime.Se
 			return
 		}
 		forward_ExcessBodyService_NoBodyServerStream_0(annotatedContext, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_WithBodyRpc_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ExcessBodyService_WithBodyRpc_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/WithBodyRpc", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -277,8 +279,8 @@ func RegisterExcessBodyServiceHandlerClient(ctx context.Context, mux *runtime.Se
 			return
 		}
 		forward_ExcessBodyService_WithBodyRpc_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_ExcessBodyService_WithBodyServerStream_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_ExcessBodyService_WithBodyServerStream_0, "/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/WithBodyServerStream", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -294,7 +296,11 @@ func RegisterExcessBodyServiceHandlerClient(ctx context.Context, mux *runtime.Se
 			return
 		}
 		forward_ExcessBodyService_WithBodyServerStream_0(annotatedContext, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
-	})
+	}, opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/NoBodyRpc", runtime.UnaryRPC(client.NoBodyRpc), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/NoBodyServerStream", runtime.ServerStreamingRPC(client.NoBodyServerStream), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/WithBodyRpc", runtime.UnaryRPC(client.WithBodyRpc), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.ExcessBodyService/WithBodyServerStream", runtime.ServerStreamingRPC(client.WithBodyServerStream), opts...)
 	return nil
 }
 
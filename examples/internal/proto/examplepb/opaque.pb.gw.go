@@ -128,7 +128,7 @@ func request_OpaqueEcommerceService_OpaqueProcessOrders_0(ctx context.Context, m
 	dec := marshaler.NewDecoder(req.Body)
 	for {
 		var protoReq OpaqueProcessOrdersRequest
-		err = dec.Decode(&protoReq)
+		err = runtime.DecodeRequest(ctx, dec, &protoReq)
 		if errors.Is(err, io.EOF) {
 			break
 		}
@@ -169,7 +169,7 @@ func request_OpaqueEcommerceService_OpaqueStreamCustomerActivity_0(ctx context.C
 	dec := marshaler.NewDecoder(req.Body)
 	handleSend := func() error {
 		var protoReq OpaqueStreamCustomerActivityRequest
-		err := dec.Decode(&protoReq)
+		err := runtime.DecodeRequest(ctx, dec, &protoReq)
 		if errors.Is(err, io.EOF) {
 			return err
 		}
@@ -207,8 +207,8 @@ func request_OpaqueEcommerceService_OpaqueStreamCustomerActivity_0(ctx context.C
 // StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterOpaqueEcommerceServiceHandlerFromEndpoint instead.
 // GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
-func RegisterOpaqueEcommerceServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server OpaqueEcommerceServiceServer) error {
-	mux.Handle(http.MethodGet, pattern_OpaqueEcommerceService_OpaqueGetProduct_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+func RegisterOpaqueEcommerceServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server OpaqueEcommerceServiceServer, opts ...runtime.RegisterOption) error {
+	mux.HandleBinding(http.MethodGet, pattern_OpaqueEcommerceService_OpaqueGetProduct_0, "/grpc.gateway.examples.internal.proto.examplepb.OpaqueEcommerceService/OpaqueGetProduct", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -227,35 +227,35 @@ func RegisterOpaqueEcommerceServiceHandlerServer(ctx context.Context, mux *runti
 			return
 		}
 		forward_OpaqueEcommerceService_OpaqueGetProduct_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
+	}, opts...)
 
-	mux.Handle(http.MethodGet, pattern_OpaqueEcommerceService_OpaqueSearchProducts_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleBinding(http.MethodGet, pattern_OpaqueEcommerceService_OpaqueSearchProducts_0, "/grpc.gateway.examples.internal.proto.examplepb.OpaqueEcommerceService/OpaqueSearchProducts", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
 		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 		return
-	})
+	}, opts...)
 
-	mux.Handle(http.MethodPost, pattern_OpaqueEcommerceService_OpaqueProcessOrders_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleBinding(http.MethodPost, pattern_OpaqueEcommerceService_OpaqueProcessOrders_0, "/grpc.gateway.examples.internal.proto.examplepb.OpaqueEcommerceService/OpaqueProcessOrders", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
 		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 		return
-	})
+	}, opts...)
 
-	mux.Handle(http.MethodPost, pattern_OpaqueEcommerceService_OpaqueStreamCustomerActivity_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	mux.HandleBinding(http.MethodPost, pattern_OpaqueEcommerceService_OpaqueStreamCustomerActivity_0, "/grpc.gateway.examples.internal.proto.examplepb.OpaqueEcommerceService/OpaqueStreamCustomerActivity", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		err := status.Error(codes.Unimplemented, "streaming calls are not yet supported in the in-process transport")
 		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
 		runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 		return
-	})
+	}, opts...)
 
 	return nil
 }
 
 // RegisterOpaqueEcommerceServiceHandlerFromEndpoint is same as RegisterOpaqueEcommerceServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
-func RegisterOpaqueEcommerceServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+func RegisterOpaqueEcommerceServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, registerOpts ...runtime.RegisterOption) (err error) {
 	conn, err := grpc.NewClient(endpoint, opts...)
 	if err != nil {
 		return err
@@ -274,22 +274,24 @@ func RegisterOpaqueEcommerceServiceHandlerFromEndpoint(ctx context.Context, mux
 			}
 		}()
 	}()
-	return RegisterOpaqueEcommerceServiceHandler(ctx, mux, conn)
+	return RegisterOpaqueEcommerceServiceHandler(ctx, mux, conn, registerOpts...)
 }
 
 // RegisterOpaqueEcommerceServiceHandler registers the http handlers for service OpaqueEcommerceService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
-func RegisterOpaqueEcommerceServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterOpaqueEcommerceServiceHandlerClient(ctx, mux, NewOpaqueEcommerceServiceClient(conn))
+// The bindings registered, and their paths, are selected by "opts".
+func RegisterOpaqueEcommerceServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts ...runtime.RegisterOption) error {
+	return RegisterOpaqueEcommerceServiceHandlerClient(ctx, mux, NewOpaqueEcommerceServiceClient(conn), opts...)
 }
 
 // RegisterOpaqueEcommerceServiceHandlerClient registers the http handlers for service OpaqueEcommerceService
 // to "mux". The handlers forward requests to the grpc endpoint over the given implementation of "OpaqueEcommerceServiceClient".
+// The methods of the service are also served with the protocols set by runtime.WithRPCProtocols, if any.
 // Note: the gRPC framework executes interceptors within the gRPC handler. If the passed in "OpaqueEcommerceServiceClient"
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "OpaqueEcommerceServiceClient" to call the correct interceptors. This client ignores the HTTP middlewares.
-func RegisterOpaqueEcommerceServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client OpaqueEcommerceServiceClient) error {
-	mux.Handle(http.MethodGet, pattern_OpaqueEcommerceService_OpaqueGetProduct_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+func RegisterOpaqueEcommerceServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client OpaqueEcommerceServiceClient, opts ...runtime.RegisterOption) error {
+	mux.HandleBinding(http.MethodGet, pattern_OpaqueEcommerceService_OpaqueGetProduct_0, "/grpc.gateway.examples.internal.proto.examplepb.OpaqueEcommerceService/OpaqueGetProduct", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -305,8 +307,8 @@ func RegisterOpaqueEcommerceServiceHandlerClient(ctx context.Context, mux *runti
 			return
 		}
 		forward_OpaqueEcommerceService_OpaqueGetProduct_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodGet, pattern_OpaqueEcommerceService_OpaqueSearchProducts_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodGet, pattern_OpaqueEcommerceService_OpaqueSearchProducts_0, "/grpc.gateway.examples.internal.proto.examplepb.OpaqueEcommerceService/OpaqueSearchProducts", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -322,8 +324,8 @@ func RegisterOpaqueEcommerceServiceHandlerClient(ctx context.Context, mux *runti
 			return
 		}
 		forward_OpaqueEcommerceService_OpaqueSearchProducts_0(annotatedContext, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_OpaqueEcommerceService_OpaqueProcessOrders_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_OpaqueEcommerceService_OpaqueProcessOrders_0, "/grpc.gateway.examples.internal.proto.examplepb.OpaqueEcommerceService/OpaqueProcessOrders", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -339,8 +341,8 @@ func RegisterOpaqueEcommerceServiceHandlerClient(ctx context.Context, mux *runti
 			return
 		}
 		forward_OpaqueEcommerceService_OpaqueProcessOrders_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_OpaqueEcommerceService_OpaqueStreamCustomerActivity_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_OpaqueEcommerceService_OpaqueStreamCustomerActivity_0, "/grpc.gateway.examples.internal.proto.examplepb.OpaqueEcommerceService/OpaqueStreamCustomerActivity", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -356,7 +358,11 @@ func RegisterOpaqueEcommerceServiceHandlerClient(ctx context.Context, mux *runti
 			return
 		}
 		forward_OpaqueEcommerceService_OpaqueStreamCustomerActivity_0(annotatedContext, mux, outboundMarshaler, w, req, func() (proto.Message, error) { return resp.Recv() }, mux.GetForwardResponseOptions()...)
-	})
+	}, opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.OpaqueEcommerceService/OpaqueGetProduct", runtime.UnaryRPC(client.OpaqueGetProduct), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.OpaqueEcommerceService/OpaqueSearchProducts", runtime.ServerStreamingRPC(client.OpaqueSearchProducts), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.OpaqueEcommerceService/OpaqueProcessOrders", runtime.ClientStreamingRPC(client.OpaqueProcessOrders), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.OpaqueEcommerceService/OpaqueStreamCustomerActivity", runtime.BidiStreamingRPC(client.OpaqueStreamCustomerActivity), opts...)
 	return nil
 }
 
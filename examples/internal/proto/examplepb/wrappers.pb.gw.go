@@ -42,7 +42,7 @@ func request_WrappersService_Create_0(ctx context.Context, marshaler runtime.Mar
 		protoReq Wrappers
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -57,7 +57,7 @@ func local_request_WrappersService_Create_0(ctx context.Context, marshaler runti
 		protoReq Wrappers
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.Create(ctx, &protoReq)
@@ -69,7 +69,7 @@ func request_WrappersService_CreateStringValue_0(ctx context.Context, marshaler
 		protoReq wrapperspb.StringValue
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -84,7 +84,7 @@ func local_request_WrappersService_CreateStringValue_0(ctx context.Context, mars
 		protoReq wrapperspb.StringValue
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.CreateStringValue(ctx, &protoReq)
@@ -96,7 +96,7 @@ func request_WrappersService_CreateInt32Value_0(ctx context.Context, marshaler r
 		protoReq wrapperspb.Int32Value
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -111,7 +111,7 @@ func local_request_WrappersService_CreateInt32Value_0(ctx context.Context, marsh
 		protoReq wrapperspb.Int32Value
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.CreateInt32Value(ctx, &protoReq)
@@ -123,7 +123,7 @@ func request_WrappersService_CreateInt64Value_0(ctx context.Context, marshaler r
 		protoReq wrapperspb.Int64Value
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -138,7 +138,7 @@ func local_request_WrappersService_CreateInt64Value_0(ctx context.Context, marsh
 		protoReq wrapperspb.Int64Value
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.CreateInt64Value(ctx, &protoReq)
@@ -150,7 +150,7 @@ func request_WrappersService_CreateFloatValue_0(ctx context.Context, marshaler r
 		protoReq wrapperspb.FloatValue
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -165,7 +165,7 @@ func local_request_WrappersService_CreateFloatValue_0(ctx context.Context, marsh
 		protoReq wrapperspb.FloatValue
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.CreateFloatValue(ctx, &protoReq)
@@ -177,7 +177,7 @@ func request_WrappersService_CreateDoubleValue_0(ctx context.Context, marshaler
 		protoReq wrapperspb.DoubleValue
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -192,7 +192,7 @@ func local_request_WrappersService_CreateDoubleValue_0(ctx context.Context, mars
 		protoReq wrapperspb.DoubleValue
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.CreateDoubleValue(ctx, &protoReq)
@@ -204,7 +204,7 @@ func request_WrappersService_CreateBoolValue_0(ctx context.Context, marshaler ru
 		protoReq wrapperspb.BoolValue
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -219,7 +219,7 @@ func local_request_WrappersService_CreateBoolValue_0(ctx context.Context, marsha
 		protoReq wrapperspb.BoolValue
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.CreateBoolValue(ctx, &protoReq)
@@ -231,7 +231,7 @@ func request_WrappersService_CreateUInt32Value_0(ctx context.Context, marshaler
 		protoReq wrapperspb.UInt32Value
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -246,7 +246,7 @@ func local_request_WrappersService_CreateUInt32Value_0(ctx context.Context, mars
 		protoReq wrapperspb.UInt32Value
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.CreateUInt32Value(ctx, &protoReq)
@@ -258,7 +258,7 @@ func request_WrappersService_CreateUInt64Value_0(ctx context.Context, marshaler
 		protoReq wrapperspb.UInt64Value
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -273,7 +273,7 @@ func local_request_WrappersService_CreateUInt64Value_0(ctx context.Context, mars
 		protoReq wrapperspb.UInt64Value
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.CreateUInt64Value(ctx, &protoReq)
@@ -285,7 +285,7 @@ func request_WrappersService_CreateBytesValue_0(ctx context.Context, marshaler r
 		protoReq wrapperspb.BytesValue
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -300,7 +300,7 @@ func local_request_WrappersService_CreateBytesValue_0(ctx context.Context, marsh
 		protoReq wrapperspb.BytesValue
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.CreateBytesValue(ctx, &protoReq)
@@ -312,7 +312,7 @@ func request_WrappersService_CreateEmpty_0(ctx context.Context, marshaler runtim
 		protoReq emptypb.Empty
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	if req.Body != nil {
@@ -327,7 +327,7 @@ func local_request_WrappersService_CreateEmpty_0(ctx context.Context, marshaler
 		protoReq emptypb.Empty
 		metadata runtime.ServerMetadata
 	)
-	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+	if err := runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq); err != nil && !errors.Is(err, io.EOF) {
 		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
 	}
 	msg, err := server.CreateEmpty(ctx, &protoReq)
@@ -339,8 +339,8 @@ func local_request_WrappersService_CreateEmpty_0(ctx context.Context, marshaler
 // StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
 // Note that using this registration option will cause many gRPC library features to stop working. Consider using RegisterWrappersServiceHandlerFromEndpoint instead.
 // GRPC interceptors will not work for this type of registration. To use interceptors, you must use the "runtime.WithMiddlewares" option in the "runtime.NewServeMux" call.
-func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server WrappersServiceServer) error {
-	mux.Handle(http.MethodPost, pattern_WrappersService_Create_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server WrappersServiceServer, opts ...runtime.RegisterOption) error {
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_Create_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/Create", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -359,8 +359,8 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_Create_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateStringValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateStringValue_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateStringValue", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -379,8 +379,8 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateStringValue_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateInt32Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateInt32Value_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateInt32Value", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -399,8 +399,8 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateInt32Value_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateInt64Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateInt64Value_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateInt64Value", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -419,8 +419,8 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateInt64Value_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateFloatValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateFloatValue_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateFloatValue", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -439,8 +439,8 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateFloatValue_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateDoubleValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateDoubleValue_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateDoubleValue", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -459,8 +459,8 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateDoubleValue_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateBoolValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateBoolValue_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateBoolValue", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -479,8 +479,8 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateBoolValue_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateUInt32Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateUInt32Value_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateUInt32Value", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -499,8 +499,8 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateUInt32Value_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateUInt64Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateUInt64Value_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateUInt64Value", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -519,8 +519,8 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateUInt64Value_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateBytesValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateBytesValue_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateBytesValue", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -539,8 +539,8 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateBytesValue_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateEmpty_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateEmpty_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateEmpty", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		var stream runtime.ServerTransportStream
@@ -559,14 +559,14 @@ func RegisterWrappersServiceHandlerServer(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateEmpty_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
+	}, opts...)
 
 	return nil
 }
 
 // RegisterWrappersServiceHandlerFromEndpoint is same as RegisterWrappersServiceHandler but
 // automatically dials to "endpoint" and closes the connection when "ctx" gets done.
-func RegisterWrappersServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) (err error) {
+func RegisterWrappersServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, registerOpts ...runtime.RegisterOption) (err error) {
 	conn, err := grpc.NewClient(endpoint, opts...)
 	if err != nil {
 		return err
@@ -585,22 +585,24 @@ func RegisterWrappersServiceHandlerFromEndpoint(ctx context.Context, mux *runtim
 			}
 		}()
 	}()
-	return RegisterWrappersServiceHandler(ctx, mux, conn)
+	return RegisterWrappersServiceHandler(ctx, mux, conn, registerOpts...)
 }
 
 // RegisterWrappersServiceHandler registers the http handlers for service WrappersService to "mux".
 // The handlers forward requests to the grpc endpoint over "conn".
-func RegisterWrappersServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-	return RegisterWrappersServiceHandlerClient(ctx, mux, NewWrappersServiceClient(conn))
+// The bindings registered, and their paths, are selected by "opts".
+func RegisterWrappersServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts ...runtime.RegisterOption) error {
+	return RegisterWrappersServiceHandlerClient(ctx, mux, NewWrappersServiceClient(conn), opts...)
 }
 
 // RegisterWrappersServiceHandlerClient registers the http handlers for service WrappersService
 // to "mux". The handlers forward requests to the grpc endpoint over the given implementation of "WrappersServiceClient".
+// The methods of the service are also served with the protocols set by runtime.WithRPCProtocols, if any.
 // Note: the gRPC framework executes interceptors within the gRPC handler. If the passed in "WrappersServiceClient"
 // doesn't go through the normal gRPC flow (creating a gRPC client etc.) then it will be up to the passed in
 // "WrappersServiceClient" to call the correct interceptors. This client ignores the HTTP middlewares.
-func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client WrappersServiceClient) error {
-	mux.Handle(http.MethodPost, pattern_WrappersService_Create_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client WrappersServiceClient, opts ...runtime.RegisterOption) error {
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_Create_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/Create", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -616,8 +618,8 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_Create_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateStringValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateStringValue_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateStringValue", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -633,8 +635,8 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateStringValue_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateInt32Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateInt32Value_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateInt32Value", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -650,8 +652,8 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateInt32Value_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateInt64Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateInt64Value_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateInt64Value", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -667,8 +669,8 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateInt64Value_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateFloatValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateFloatValue_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateFloatValue", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -684,8 +686,8 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateFloatValue_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateDoubleValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateDoubleValue_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateDoubleValue", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -701,8 +703,8 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateDoubleValue_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateBoolValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateBoolValue_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateBoolValue", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -718,8 +720,8 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateBoolValue_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateUInt32Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateUInt32Value_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateUInt32Value", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -735,8 +737,8 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateUInt32Value_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateUInt64Value_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateUInt64Value_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateUInt64Value", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -752,8 +754,8 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateUInt64Value_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateBytesValue_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateBytesValue_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateBytesValue", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -769,8 +771,8 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateBytesValue_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
-	mux.Handle(http.MethodPost, pattern_WrappersService_CreateEmpty_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+	}, opts...)
+	mux.HandleBinding(http.MethodPost, pattern_WrappersService_CreateEmpty_0, "/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateEmpty", 0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
@@ -786,7 +788,18 @@ func RegisterWrappersServiceHandlerClient(ctx context.Context, mux *runtime.Serv
 			return
 		}
 		forward_WrappersService_CreateEmpty_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
-	})
+	}, opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/Create", runtime.UnaryRPC(client.Create), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateStringValue", runtime.UnaryRPC(client.CreateStringValue), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateInt32Value", runtime.UnaryRPC(client.CreateInt32Value), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateInt64Value", runtime.UnaryRPC(client.CreateInt64Value), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateFloatValue", runtime.UnaryRPC(client.CreateFloatValue), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateDoubleValue", runtime.UnaryRPC(client.CreateDoubleValue), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateBoolValue", runtime.UnaryRPC(client.CreateBoolValue), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateUInt32Value", runtime.UnaryRPC(client.CreateUInt32Value), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateUInt64Value", runtime.UnaryRPC(client.CreateUInt64Value), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateBytesValue", runtime.UnaryRPC(client.CreateBytesValue), opts...)
+	mux.HandleRPC("/grpc.gateway.examples.internal.proto.examplepb.WrappersService/CreateEmpty", runtime.UnaryRPC(client.CreateEmpty), opts...)
 	return nil
 }
 
@@ -0,0 +1,337 @@
+package breaking
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/casing"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/descriptor"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// API is the HTTP API the gateway serves for a set of proto files.
+type API struct {
+	reg    *descriptor.Registry
+	routes map[string]*route
+}
+
+// route is a binding as routed by runtime.ServeMux.
+type route struct {
+	binding *descriptor.Binding
+	// key identifies the requests the binding matches, such as
+	// "GET /v1/things/*:cancel".
+	key string
+}
+
+// Load loads the API of the files of "set" into "reg", which must be
+// configured as the generators are, such as with
+// Registry.SetGenerateUnboundMethods. Files without a go_package option are
+// loaded as well.
+func Load(reg *descriptor.Registry, set *descriptorpb.FileDescriptorSet) (*API, error) {
+	req := &pluginpb.CodeGeneratorRequest{ProtoFile: set.GetFile()}
+	var params []string
+	for _, f := range set.GetFile() {
+		req.FileToGenerate = append(req.FileToGenerate, f.GetName())
+		if f.GetOptions().GetGoPackage() == "" {
+			// The Go package of the files is irrelevant to their HTTP API, but
+			// the registry needs one.
+			params = append(params, fmt.Sprintf("M%s=grpc-gateway-breaking.invalid/%s", f.GetName(), strings.TrimSuffix(f.GetName(), path.Ext(f.GetName()))))
+		}
+	}
+	if len(params) > 0 {
+		req.Parameter = proto.String(strings.Join(params, ","))
+	}
+	if err := reg.Load(req); err != nil {
+		return nil, err
+	}
+
+	api := &API{reg: reg, routes: make(map[string]*route)}
+	for _, name := range req.FileToGenerate {
+		f, err := reg.LookupFile(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, svc := range f.Services {
+			for _, m := range svc.Methods {
+				for _, b := range m.Bindings {
+					if b == nil {
+						continue
+					}
+					r := &route{binding: b, key: routeKey(b)}
+					// ServeMux routes the requests to the binding registered
+					// last.
+					api.routes[r.key] = r
+				}
+			}
+		}
+	}
+	return api, nil
+}
+
+// routeKey returns the HTTP method and the components matched by the path
+// template of "b", without the names of its variables.
+func routeKey(b *descriptor.Binding) string {
+	tmpl := b.PathTmpl
+	var segments []string
+	for i := 0; i+1 < len(tmpl.OpCodes); i += 2 {
+		switch utilities.OpCode(tmpl.OpCodes[i]) {
+		case utilities.OpPush:
+			segments = append(segments, "*")
+		case utilities.OpPushM:
+			segments = append(segments, "**")
+		case utilities.OpLitPush:
+			segments = append(segments, tmpl.Pool[tmpl.OpCodes[i+1]])
+		}
+	}
+	key := b.HTTPMethod + " /" + strings.Join(segments, "/")
+	if tmpl.Verb != "" {
+		key += ":" + tmpl.Verb
+	}
+	return key
+}
+
+// Change is a difference between two versions of an API.
+type Change struct {
+	// Kind is the kind of the change, such as "route-removed".
+	Kind string `json:"kind"`
+	// Breaking reports whether clients of the old API may fail with the new
+	// one.
+	Breaking bool `json:"breaking"`
+	// Route is the binding of the old API the change is about, such as
+	// "GET /v1/{name=things/*}", and Method the method it calls.
+	Route  string `json:"route,omitempty"`
+	Method string `json:"method,omitempty"`
+	// Message describes the change.
+	Message string `json:"message"`
+}
+
+func (c Change) String() string {
+	level := "info"
+	if c.Breaking {
+		level = "breaking"
+	}
+	if c.Route == "" {
+		return fmt.Sprintf("%s: %s (%s)", level, c.Message, c.Kind)
+	}
+	return fmt.Sprintf("%s: %s: %s (%s)", level, c.Route, c.Message, c.Kind)
+}
+
+// Compare returns the changes from "old" to "new", in the order of the routes
+// of "old", followed by the routes added.
+func Compare(old, new *API) []Change {
+	c := &comparer{old: old, new: new, seen: make(map[string]bool)}
+	for _, key := range sortedKeys(old.routes) {
+		c.compareRoute(old.routes[key], new.routes[key])
+	}
+	for _, key := range sortedKeys(new.routes) {
+		if _, ok := old.routes[key]; ok {
+			continue
+		}
+		b := new.routes[key].binding
+		c.changes = append(c.changes, Change{
+			Kind:    "route-added",
+			Method:  methodName(b.Method),
+			Message: fmt.Sprintf("%s %s was added", b.HTTPMethod, b.PathTmpl.Template),
+		})
+	}
+	return c.changes
+}
+
+func sortedKeys(routes map[string]*route) []string {
+	keys := make([]string, 0, len(routes))
+	for k := range routes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func methodName(m *descriptor.Method) string {
+	return strings.TrimPrefix(m.FQMN(), ".")
+}
+
+type comparer struct {
+	old, new *API
+	changes  []Change
+	// seen records the pairs of messages already compared, and the changes
+	// of their fields already reported through another route.
+	seen map[string]bool
+}
+
+func (c *comparer) compareRoute(oldRoute, newRoute *route) {
+	ob := oldRoute.binding
+	report := func(kind string, breaking bool, format string, args ...any) {
+		c.changes = append(c.changes, Change{
+			Kind:     kind,
+			Breaking: breaking,
+			Route:    ob.HTTPMethod + " " + ob.PathTmpl.Template,
+			Method:   methodName(ob.Method),
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+	if newRoute == nil {
+		// The method may still be bound to the path, with another HTTP method.
+		if m, err := c.new.reg.LookupMethod(ob.Method.FQMN()); err == nil {
+			oldPath := strings.SplitN(oldRoute.key, " ", 2)[1]
+			for _, b := range m.Bindings {
+				if b != nil && strings.SplitN(routeKey(b), " ", 2)[1] == oldPath {
+					report("http-method-changed", true, "the HTTP method is now %s", b.HTTPMethod)
+					return
+				}
+			}
+		}
+		report("route-removed", true, "the route was removed")
+		return
+	}
+
+	nb := newRoute.binding
+	if ob.Method.FQMN() != nb.Method.FQMN() {
+		report("method-changed", false, "the route now calls %s", methodName(nb.Method))
+	}
+	if op, np := pathParams(ob), pathParams(nb); !slices.Equal(op, np) {
+		report("path-param-changed", true, "the path parameters are now %q instead of %q", np, op)
+	}
+	if o, n := bodyName(ob.Body, "*"), bodyName(nb.Body, "*"); o != n {
+		report("body-changed", true, "the body is now %s instead of %s", n, o)
+	}
+	if o, n := bodyName(ob.ResponseBody, ""), bodyName(nb.ResponseBody, ""); o != n {
+		report("response-body-changed", true, "the response body is now %s instead of %s", n, o)
+	}
+
+	via := Change{Route: ob.HTTPMethod + " " + ob.PathTmpl.Template, Method: methodName(ob.Method)}
+	c.compareMessages(via, ob.Method.RequestType, nb.Method.RequestType, true)
+	c.compareMessages(via, ob.Method.ResponseType, nb.Method.ResponseType, false)
+}
+
+func pathParams(b *descriptor.Binding) []string {
+	params := make([]string, len(b.PathParams))
+	for i, p := range b.PathParams {
+		params[i] = p.FieldPath.String()
+	}
+	return params
+}
+
+// bodyName describes "body", whose empty field path means "whole".
+func bodyName(body *descriptor.Body, whole string) string {
+	switch {
+	case body == nil:
+		return "none"
+	case len(body.FieldPath) == 0:
+		if whole == "" {
+			return "none"
+		}
+		return fmt.Sprintf("%q", whole)
+	}
+	return fmt.Sprintf("%q", body.FieldPath.String())
+}
+
+// compareMessages reports the changes of the JSON representation of the
+// messages "o" and "n", whose fields are matched by number, found through
+// the route of "via". "request" reports whether the messages are sent by
+// clients, whose fields can also be query parameters, named after either the
+// proto or the JSON names of the fields.
+func (c *comparer) compareMessages(via Change, o, n *descriptor.Message, request bool) {
+	key := fmt.Sprintf("%s %s %t", o.FQMN(), n.FQMN(), request)
+	if c.seen[key] {
+		return
+	}
+	c.seen[key] = true
+
+	report := func(kind string, format string, args ...any) {
+		change := via
+		change.Kind = kind
+		change.Breaking = true
+		change.Message = fmt.Sprintf(format, args...)
+		if key := kind + " " + change.Message; !c.seen[key] {
+			c.seen[key] = true
+			c.changes = append(c.changes, change)
+		}
+	}
+	for _, of := range o.Fields {
+		var nf *descriptor.Field
+		for _, f := range n.Fields {
+			if f.GetNumber() == of.GetNumber() {
+				nf = f
+				break
+			}
+		}
+		name := strings.TrimPrefix(of.FQFN(), ".")
+		switch {
+		case nf == nil:
+			report("field-removed", "field %s was removed", name)
+			continue
+		case jsonName(of) != jsonName(nf):
+			report("json-name-changed", "the JSON name of field %s is now %q instead of %q", name, jsonName(nf), jsonName(of))
+		case request && of.GetName() != nf.GetName():
+			report("field-renamed", "field %s was renamed to %s, which changes its query parameter", name, nf.GetName())
+		}
+		if of.GetLabel() != nf.GetLabel() || of.GetType() != nf.GetType() || (of.GetType() == descriptorpb.FieldDescriptorProto_TYPE_ENUM && of.GetTypeName() != nf.GetTypeName()) {
+			report("field-type-changed", "the type of field %s changed", name)
+			continue
+		}
+		if of.GetType() == descriptorpb.FieldDescriptorProto_TYPE_ENUM {
+			c.compareEnums(of.GetTypeName(), report)
+			continue
+		}
+		if of.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+			continue
+		}
+		if strings.HasPrefix(of.GetTypeName(), ".google.protobuf.") || strings.HasPrefix(nf.GetTypeName(), ".google.protobuf.") {
+			if of.GetTypeName() != nf.GetTypeName() {
+				report("field-type-changed", "the type of field %s changed", name)
+			}
+			continue
+		}
+		om, err := c.old.reg.LookupMsg("", of.GetTypeName())
+		if err != nil {
+			continue
+		}
+		nm, err := c.new.reg.LookupMsg("", nf.GetTypeName())
+		if err != nil {
+			continue
+		}
+		c.compareMessages(via, om, nm, request)
+	}
+}
+
+// compareEnums reports the values of the enum "name" which were removed or
+// renamed, matched by number, as their JSON representation is their name.
+func (c *comparer) compareEnums(name string, report func(kind string, format string, args ...any)) {
+	oe, err := c.old.reg.LookupEnum("", name)
+	if err != nil {
+		return
+	}
+	ne, err := c.new.reg.LookupEnum("", name)
+	if err != nil {
+		return
+	}
+	enumName := strings.TrimPrefix(oe.FQEN(), ".")
+	for _, ov := range oe.GetValue() {
+		var names []string
+		for _, nv := range ne.GetValue() {
+			if nv.GetNumber() == ov.GetNumber() {
+				names = append(names, nv.GetName())
+			}
+		}
+		switch {
+		case len(names) == 0:
+			report("enum-value-removed", "value %s.%s was removed", enumName, ov.GetName())
+		case !slices.Contains(names, ov.GetName()):
+			report("enum-value-renamed", "value %s.%s was renamed to %s", enumName, ov.GetName(), names[0])
+		}
+	}
+}
+
+// jsonName returns the JSON name of "f", as protojson marshals it.
+func jsonName(f *descriptor.Field) string {
+	if f.JsonName != nil {
+		return f.GetJsonName()
+	}
+	return casing.JSONCamelCase(f.GetName())
+}
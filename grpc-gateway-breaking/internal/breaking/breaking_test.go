@@ -0,0 +1,172 @@
+package breaking
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/descriptor"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func loadAPI(t *testing.T, src string) *API {
+	t.Helper()
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := prototext.Unmarshal([]byte(src), set); err != nil {
+		t.Fatalf("prototext.Unmarshal(%s) failed with %v; want success", src, err)
+	}
+	api, err := Load(descriptor.NewRegistry(), set)
+	if err != nil {
+		t.Fatalf("Load(%v) failed with %v; want success", set, err)
+	}
+	return api
+}
+
+const oldSrc = `
+	file <
+		name: "example/thing.proto"
+		package: "example"
+		syntax: "proto3"
+		message_type <
+			name: "Thing"
+			field < name: "name" number: 1 type: TYPE_STRING json_name: "name" >
+			field < name: "display_name" number: 2 type: TYPE_STRING json_name: "displayName" >
+			field < name: "page_size" number: 3 type: TYPE_INT32 json_name: "pageSize" >
+			field < name: "labels" number: 4 label: LABEL_REPEATED type: TYPE_STRING json_name: "labels" >
+			field < name: "owner" number: 5 type: TYPE_MESSAGE type_name: ".example.Owner" json_name: "owner" >
+			field < name: "state" number: 7 type: TYPE_ENUM type_name: ".example.State" json_name: "state" >
+		>
+		message_type <
+			name: "Owner"
+			field < name: "email" number: 1 type: TYPE_STRING json_name: "email" >
+		>
+		enum_type <
+			name: "State"
+			value < name: "STATE_UNSPECIFIED" number: 0 >
+			value < name: "ACTIVE" number: 1 >
+			value < name: "ARCHIVED" number: 2 >
+		>
+		service <
+			name: "ThingService"
+			method <
+				name: "GetThing"
+				input_type: ".example.Thing"
+				output_type: ".example.Thing"
+				options < [google.api.http] < get: "/v1/{name=things/*}" > >
+			>
+			method <
+				name: "ListThings"
+				input_type: ".example.Thing"
+				output_type: ".example.Thing"
+				options < [google.api.http] < get: "/v1/things" > >
+			>
+			method <
+				name: "UpdateThing"
+				input_type: ".example.Thing"
+				output_type: ".example.Thing"
+				options < [google.api.http] < patch: "/v1/{name=things/*}" body: "*" > >
+			>
+			method <
+				name: "DeleteThing"
+				input_type: ".example.Thing"
+				output_type: ".example.Thing"
+				options < [google.api.http] < delete: "/v1/{name=things/*}" > >
+			>
+			method <
+				name: "ArchiveThing"
+				input_type: ".example.Thing"
+				output_type: ".example.Thing"
+				options < [google.api.http] < post: "/v1/{name=things/*}:archive" response_body: "owner" > >
+			>
+		>
+	>
+`
+
+const newSrc = `
+	file <
+		name: "example/thing.proto"
+		package: "example"
+		syntax: "proto3"
+		message_type <
+			name: "Thing"
+			field < name: "name" number: 1 type: TYPE_STRING json_name: "name" >
+			field < name: "display_name" number: 2 type: TYPE_STRING json_name: "title" >
+			field < name: "max_page_size" number: 3 type: TYPE_INT32 json_name: "pageSize" >
+			field < name: "labels" number: 4 type: TYPE_STRING json_name: "labels" >
+			field < name: "owner" number: 5 type: TYPE_MESSAGE type_name: ".example.Owner" json_name: "owner" >
+			field < name: "id" number: 6 type: TYPE_STRING json_name: "id" >
+			field < name: "state" number: 7 type: TYPE_ENUM type_name: ".example.State" json_name: "state" >
+		>
+		message_type <
+			name: "Owner"
+		>
+		enum_type <
+			name: "State"
+			value < name: "STATE_UNSPECIFIED" number: 0 >
+			value < name: "ENABLED" number: 1 >
+			value < name: "DRAFT" number: 3 >
+		>
+		service <
+			name: "ThingService"
+			method <
+				name: "GetThing"
+				input_type: ".example.Thing"
+				output_type: ".example.Thing"
+				options < [google.api.http] < get: "/v1/{id=things/*}" > >
+			>
+			method <
+				name: "UpdateThing"
+				input_type: ".example.Thing"
+				output_type: ".example.Thing"
+				options < [google.api.http] < patch: "/v1/{name=things/*}" body: "owner" > >
+			>
+			method <
+				name: "DeleteThing"
+				input_type: ".example.Thing"
+				output_type: ".example.Thing"
+				options < [google.api.http] < post: "/v1/{name=things/*}" > >
+			>
+			method <
+				name: "ArchiveThing"
+				input_type: ".example.Thing"
+				output_type: ".example.Thing"
+				options < [google.api.http] < post: "/v1/{name=things/*}:archive" > >
+			>
+			method <
+				name: "SearchThings"
+				input_type: ".example.Thing"
+				output_type: ".example.Thing"
+				options < [google.api.http] < get: "/v1/things:search" > >
+			>
+		>
+	>
+`
+
+func TestCompare(t *testing.T) {
+	got := Compare(loadAPI(t, oldSrc), loadAPI(t, newSrc))
+	want := []Change{
+		{Kind: "http-method-changed", Breaking: true, Route: "DELETE /v1/{name=things/*}", Method: "example.ThingService.DeleteThing", Message: "the HTTP method is now POST"},
+		{Kind: "route-removed", Breaking: true, Route: "GET /v1/things", Method: "example.ThingService.ListThings", Message: "the route was removed"},
+		{Kind: "path-param-changed", Breaking: true, Route: "GET /v1/{name=things/*}", Method: "example.ThingService.GetThing", Message: `the path parameters are now ["id"] instead of ["name"]`},
+		{Kind: "json-name-changed", Breaking: true, Route: "GET /v1/{name=things/*}", Method: "example.ThingService.GetThing", Message: `the JSON name of field example.Thing.display_name is now "title" instead of "displayName"`},
+		{Kind: "field-renamed", Breaking: true, Route: "GET /v1/{name=things/*}", Method: "example.ThingService.GetThing", Message: "field example.Thing.page_size was renamed to max_page_size, which changes its query parameter"},
+		{Kind: "field-type-changed", Breaking: true, Route: "GET /v1/{name=things/*}", Method: "example.ThingService.GetThing", Message: "the type of field example.Thing.labels changed"},
+		{Kind: "field-removed", Breaking: true, Route: "GET /v1/{name=things/*}", Method: "example.ThingService.GetThing", Message: "field example.Owner.email was removed"},
+		{Kind: "enum-value-renamed", Breaking: true, Route: "GET /v1/{name=things/*}", Method: "example.ThingService.GetThing", Message: "value example.State.ACTIVE was renamed to ENABLED"},
+		{Kind: "enum-value-removed", Breaking: true, Route: "GET /v1/{name=things/*}", Method: "example.ThingService.GetThing", Message: "value example.State.ARCHIVED was removed"},
+		{Kind: "body-changed", Breaking: true, Route: "PATCH /v1/{name=things/*}", Method: "example.ThingService.UpdateThing", Message: `the body is now "owner" instead of "*"`},
+		{Kind: "response-body-changed", Breaking: true, Route: "POST /v1/{name=things/*}:archive", Method: "example.ThingService.ArchiveThing", Message: `the response body is now none instead of "owner"`},
+		{Kind: "route-added", Method: "example.ThingService.SearchThings", Message: "GET /v1/things:search was added"},
+		{Kind: "route-added", Method: "example.ThingService.DeleteThing", Message: "POST /v1/{name=things/*} was added"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Compare() = %v; want %v", got, want)
+	}
+}
+
+func TestCompareUnchanged(t *testing.T) {
+	if got := Compare(loadAPI(t, oldSrc), loadAPI(t, oldSrc)); len(got) != 0 {
+		t.Errorf("Compare() = %v; want no changes", got)
+	}
+}
@@ -0,0 +1,3 @@
+// Package breaking compares the HTTP APIs the gateway serves for two versions
+// of a set of proto files.
+package breaking
@@ -0,0 +1,132 @@
+// Command grpc-gateway-breaking detects the changes of the HTTP API the
+// gateway serves between two versions of a set of proto files, such as
+// removed routes or renamed JSON fields, which break REST clients.
+//
+//	grpc-gateway-breaking [flags] old.binpb new.binpb
+//
+// Both files are FileDescriptorSets including imports, as built by
+// "buf build -o new.binpb" or "protoc --include_imports --descriptor_set_out".
+// The command exits with status 1 if it finds breaking changes, and 2 if it
+// fails.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/grpc-gateway-breaking/internal/breaking"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/descriptor"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+var (
+	format                  = flag.String("format", "text", "output format. Allowed values are `text` and `json`")
+	allowDeleteBody         = flag.Bool("allow_delete_body", false, "unless set, HTTP DELETE methods may not have a body")
+	generateUnboundMethods  = flag.Bool("generate_unbound_methods", false, "compare the default bindings of the RPC methods that have no HttpRule annotation")
+	unboundMethodBindings   = flag.String("unbound_method_bindings", "grpc", "bindings of the RPC methods compared by `generate_unbound_methods`. Allowed values are `grpc` and `aip`")
+	oldGrpcAPIConfiguration = flag.String("old_grpc_api_configuration", "", "path to the gRPC API Configuration in YAML format of the old files")
+	newGrpcAPIConfiguration = flag.String("new_grpc_api_configuration", "", "path to the gRPC API Configuration in YAML format of the new files")
+	includeInfo             = flag.Bool("include_info", false, "also report the changes which do not break clients, such as added routes")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] old.binpb new.binpb\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	breakingChanges, err := run(os.Stdout, flag.Arg(0), flag.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if breakingChanges > 0 {
+		os.Exit(1)
+	}
+}
+
+// run writes the changes from the FileDescriptorSet "oldPath" to "newPath"
+// to "w", and returns the number of breaking ones.
+func run(w io.Writer, oldPath, newPath string) (int, error) {
+	oldAPI, err := load(oldPath, *oldGrpcAPIConfiguration)
+	if err != nil {
+		return 0, err
+	}
+	newAPI, err := load(newPath, *newGrpcAPIConfiguration)
+	if err != nil {
+		return 0, err
+	}
+
+	var changes []breaking.Change
+	var breakingChanges int
+	for _, c := range breaking.Compare(oldAPI, newAPI) {
+		if c.Breaking {
+			breakingChanges++
+		} else if !*includeInfo {
+			continue
+		}
+		changes = append(changes, c)
+	}
+
+	switch *format {
+	case "text":
+		for _, c := range changes {
+			if _, err := fmt.Fprintln(w, c); err != nil {
+				return 0, err
+			}
+		}
+	case "json":
+		if changes == nil {
+			changes = []breaking.Change{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err := enc.Encode(struct {
+			Breaking int               `json:"breaking"`
+			Changes  []breaking.Change `json:"changes"`
+		}{breakingChanges, changes})
+		if err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("unknown format: %s", *format)
+	}
+	return breakingChanges, nil
+}
+
+func load(path, grpcAPIConfiguration string) (*breaking.API, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(buf, set); err != nil {
+		return nil, fmt.Errorf("failed to parse the FileDescriptorSet %s: %w", path, err)
+	}
+
+	reg := descriptor.NewRegistry()
+	reg.SetAllowDeleteBody(*allowDeleteBody)
+	reg.SetGenerateUnboundMethods(*generateUnboundMethods)
+	if err := reg.SetUnboundMethodBindings(*unboundMethodBindings); err != nil {
+		return nil, err
+	}
+	if grpcAPIConfiguration != "" {
+		if err := reg.LoadGrpcAPIServiceFromYAML(grpcAPIConfiguration); err != nil {
+			return nil, err
+		}
+	}
+	api, err := breaking.Load(reg, set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	return api, nil
+}
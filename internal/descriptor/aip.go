@@ -0,0 +1,431 @@
+package descriptor
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/casing"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// aipResource is a resource declared by a google.api.resource or
+// google.api.resource_definition annotation.
+type aipResource struct {
+	desc *options.ResourceDescriptor
+	// msg is the message of the resource, or nil if it is declared by a
+	// google.api.resource_definition file option.
+	msg *Message
+	// singular and plural are the UpperCamelCase names of the resource, such
+	// as "Book" and "Books".
+	singular, plural string
+}
+
+// pattern returns the first pattern of the resource, with its variables
+// replaced by wildcards, such as "publishers/*/books/*", or "" if it has none.
+func (res *aipResource) pattern() string {
+	if len(res.desc.GetPattern()) == 0 {
+		return ""
+	}
+	segments := strings.Split(res.desc.GetPattern()[0], "/")
+	for i, s := range segments {
+		if strings.Contains(s, "{") {
+			segments[i] = "*"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// collection returns the pattern of the parent of the resource, such as
+// "publishers/*", or "" for top-level resources, and its collection
+// identifier, such as "books".
+func (res *aipResource) collection() (parent, id string, ok bool) {
+	segments := strings.Split(res.pattern(), "/")
+	if len(segments) < 2 || segments[len(segments)-1] != "*" {
+		return "", "", false
+	}
+	return strings.Join(segments[:len(segments)-2], "/"), segments[len(segments)-2], true
+}
+
+var apiVersionPattern = regexp.MustCompile(`^v\d+((alpha|beta)\d*)?$`)
+
+// aipResources returns the resources declared in the loaded files, indexed
+// by type, such as "library.googleapis.com/Book".
+func (r *Registry) aipResources() map[string]*aipResource {
+	if r.resources != nil {
+		return r.resources
+	}
+	r.resources = make(map[string]*aipResource)
+	add := func(desc *options.ResourceDescriptor, msg *Message) {
+		if desc.GetType() == "" {
+			return
+		}
+		res := &aipResource{desc: desc, msg: msg}
+		_, res.singular, _ = strings.Cut(desc.GetType(), "/")
+		if s := desc.GetSingular(); s != "" {
+			res.singular = upperFirst(s)
+		}
+		res.plural = res.singular + "s"
+		if p := desc.GetPlural(); p != "" {
+			res.plural = upperFirst(p)
+		}
+		r.resources[desc.GetType()] = res
+	}
+	fqmns := r.GetAllFQMNs()
+	sort.Strings(fqmns)
+	for _, fqmn := range fqmns {
+		msg := r.msgs[fqmn]
+		if desc, ok := proto.GetExtension(msg.GetOptions(), options.E_Resource).(*options.ResourceDescriptor); ok && desc != nil {
+			add(desc, msg)
+		}
+	}
+	names := make([]string, 0, len(r.files))
+	for name := range r.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if descs, ok := proto.GetExtension(r.files[name].GetOptions(), options.E_ResourceDefinition).([]*options.ResourceDescriptor); ok {
+			for _, desc := range descs {
+				add(desc, nil)
+			}
+		}
+	}
+	return r.resources
+}
+
+// aipResourceOf returns the resource whose message is "msg", if any.
+func (r *Registry) aipResourceOf(msg *Message) *aipResource {
+	for _, res := range r.aipResources() {
+		if res.msg == msg {
+			return res
+		}
+	}
+	return nil
+}
+
+// aipResourceNamed returns the resource whose singular or plural name is
+// "noun", if any.
+func (r *Registry) aipResourceNamed(noun string, plural bool) *aipResource {
+	for _, t := range sortedResourceTypes(r.aipResources()) {
+		res := r.resources[t]
+		if (!plural && res.singular == noun) || (plural && res.plural == noun) {
+			return res
+		}
+	}
+	return nil
+}
+
+// aipReference returns the resource_reference of the field "name" of "msg",
+// if any.
+func aipReference(msg *Message, name string) *options.ResourceReference {
+	f := lookupField(msg, name)
+	if f == nil {
+		return nil
+	}
+	ref, _ := proto.GetExtension(f.GetOptions(), options.E_ResourceReference).(*options.ResourceReference)
+	return ref
+}
+
+// aipAPIOptions returns the HttpRule of the unbound method "md" of "svc",
+// inferred from the conventions of the standard methods of AIP-131 to
+// AIP-135 and of the custom methods of AIP-136, or nil if the method does not
+// follow them.
+func (r *Registry) aipAPIOptions(svc *Service, md *descriptorpb.MethodDescriptorProto) (*options.HttpRule, error) {
+	req, err := r.LookupMsg(svc.File.GetPackage(), md.GetInputType())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.LookupMsg(svc.File.GetPackage(), md.GetOutputType())
+	if err != nil {
+		return nil, err
+	}
+	prefix := ""
+	if pkg := strings.Split(svc.File.GetPackage(), "."); apiVersionPattern.MatchString(pkg[len(pkg)-1]) {
+		prefix = "/" + pkg[len(pkg)-1]
+	}
+	byReference := func(field string) *aipResource {
+		if ref := aipReference(req, field); ref != nil {
+			if res := r.aipResources()[ref.GetType()]; res != nil {
+				return res
+			}
+			return r.aipResources()[ref.GetChildType()]
+		}
+		return nil
+	}
+	// resourceField returns the field of the request whose type is the
+	// message of "res".
+	resourceField := func(res *aipResource) *Field {
+		if res == nil || res.msg == nil {
+			return nil
+		}
+		for _, f := range req.Fields {
+			if f.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE && f.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED && f.GetTypeName() == res.msg.FQMN() {
+				return f
+			}
+		}
+		return nil
+	}
+	// collectionPath returns the path of the collection of "res", bound to
+	// the parent field of the request, if any.
+	collectionPath := func(res *aipResource) (string, bool) {
+		if res == nil {
+			return "", false
+		}
+		parent, id, ok := res.collection()
+		switch {
+		case !ok:
+			return "", false
+		case parent == "":
+			return prefix + "/" + id, true
+		case lookupField(req, "parent") == nil:
+			return "", false
+		}
+		return fmt.Sprintf("%s/{parent=%s}/%s", prefix, parent, id), true
+	}
+
+	name := md.GetName()
+	switch verb, noun := splitAIPMethodName(name); verb {
+	case "Get", "Delete":
+		if lookupField(req, "name") == nil {
+			break
+		}
+		res := byReference("name")
+		if res == nil && verb == "Get" {
+			res = r.aipResourceOf(resp)
+		}
+		if res == nil {
+			res = r.aipResourceNamed(noun, false)
+		}
+		if res == nil || res.pattern() == "" {
+			break
+		}
+		path := fmt.Sprintf("%s/{name=%s}", prefix, res.pattern())
+		if verb == "Get" {
+			return &options.HttpRule{Pattern: &options.HttpRule_Get{Get: path}}, nil
+		}
+		return &options.HttpRule{Pattern: &options.HttpRule_Delete{Delete: path}}, nil
+
+	case "List":
+		var res *aipResource
+		for _, f := range resp.Fields {
+			if f.GetLabel() != descriptorpb.FieldDescriptorProto_LABEL_REPEATED || f.GetType() != descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+				continue
+			}
+			if msg, err := r.LookupMsg("", f.GetTypeName()); err == nil {
+				if res = r.aipResourceOf(msg); res != nil {
+					break
+				}
+			}
+		}
+		if ref := aipReference(req, "parent"); res == nil && ref != nil {
+			res = r.aipResources()[ref.GetChildType()]
+		}
+		if res == nil {
+			res = r.aipResourceNamed(noun, true)
+		}
+		if path, ok := collectionPath(res); ok {
+			return &options.HttpRule{Pattern: &options.HttpRule_Get{Get: path}}, nil
+		}
+
+	case "Create":
+		res := r.aipResourceOf(resp)
+		if res == nil {
+			res = r.aipResourceNamed(noun, false)
+		}
+		body := resourceField(res)
+		if body == nil {
+			break
+		}
+		if path, ok := collectionPath(res); ok {
+			return &options.HttpRule{Pattern: &options.HttpRule_Post{Post: path}, Body: body.GetName()}, nil
+		}
+
+	case "Update":
+		res := r.aipResourceOf(resp)
+		if res == nil {
+			res = r.aipResourceNamed(noun, false)
+		}
+		body := resourceField(res)
+		if body == nil || res.pattern() == "" {
+			break
+		}
+		nameField := res.desc.GetNameField()
+		if nameField == "" {
+			nameField = "name"
+		}
+		if lookupField(res.msg, nameField) == nil {
+			break
+		}
+		path := fmt.Sprintf("%s/{%s.%s=%s}", prefix, body.GetName(), nameField, res.pattern())
+		return &options.HttpRule{Pattern: &options.HttpRule_Patch{Patch: path}, Body: body.GetName()}, nil
+	}
+
+	// Custom methods are named after a verb and the resource, or the
+	// collection, they apply to, such as ArchiveBook or BatchGetBooks.
+	var res *aipResource
+	var verb string
+	var collection bool
+	for _, t := range sortedResourceTypes(r.aipResources()) {
+		candidate := r.resources[t]
+		for _, noun := range []string{candidate.singular, candidate.plural} {
+			v, ok := strings.CutSuffix(name, noun)
+			if !ok || v == "" || (res != nil && len(v) >= len(verb)) {
+				continue
+			}
+			res, verb, collection = candidate, v, noun == candidate.plural && noun != candidate.singular
+		}
+	}
+	if res == nil {
+		return nil, nil
+	}
+	verb = lowerFirst(verb)
+	if !collection && lookupField(req, "name") != nil && res.pattern() != "" {
+		return &options.HttpRule{
+			Pattern: &options.HttpRule_Post{Post: fmt.Sprintf("%s/{name=%s}:%s", prefix, res.pattern(), verb)},
+			Body:    "*",
+		}, nil
+	}
+	if path, ok := collectionPath(res); ok && collection {
+		return &options.HttpRule{
+			Pattern: &options.HttpRule_Post{Post: path + ":" + verb},
+			Body:    "*",
+		}, nil
+	}
+	if grpclog.V(1) {
+		grpclog.Infof("No AIP binding inferred for %s.%s", svc.GetName(), name)
+	}
+	return nil, nil
+}
+
+// splitAIPMethodName splits the name of a standard method into its verb and
+// noun, such as "Get" and "Book" for GetBook.
+func splitAIPMethodName(name string) (verb, noun string) {
+	for _, verb := range []string{"Get", "List", "Create", "Update", "Delete"} {
+		if noun, ok := strings.CutPrefix(name, verb); ok && noun != "" {
+			if r, _ := utf8.DecodeRuneInString(noun); unicode.IsUpper(r) {
+				return verb, noun
+			}
+		}
+	}
+	return "", ""
+}
+
+func sortedResourceTypes(resources map[string]*aipResource) []string {
+	types := make([]string, 0, len(resources))
+	for t := range resources {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func upperFirst(s string) string {
+	return casing.Camel(s)
+}
+
+func lowerFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[n:]
+}
+
+// ResourceName describes the resource names a field holds, as declared by
+// google.api.resource and google.api.resource_reference annotations.
+type ResourceName struct {
+	// Type is the type of the resources, such as "library.example.com/Book".
+	Type string
+	// Patterns are the patterns of the names, such as
+	// "publishers/{publisher}/books/{book}".
+	Patterns []string
+	// Parent reports whether the field holds the names of the parents of
+	// resources of Type, as declared by a child_type resource_reference, in
+	// which case Patterns are the patterns of the parents.
+	Parent bool
+}
+
+// LookupResourceName returns the resource names "f" holds, either as the
+// name field of a google.api.resource message or as a
+// google.api.resource_reference to a resource declared in the loaded files,
+// or nil if it holds none.
+func (r *Registry) LookupResourceName(f *Field) *ResourceName {
+	if f.GetType() != descriptorpb.FieldDescriptorProto_TYPE_STRING {
+		return nil
+	}
+	if res := r.aipResourceOf(f.Message); res != nil {
+		nameField := res.desc.GetNameField()
+		if nameField == "" {
+			nameField = "name"
+		}
+		if f.GetName() == nameField {
+			return &ResourceName{Type: res.desc.GetType(), Patterns: res.desc.GetPattern()}
+		}
+	}
+	ref, _ := proto.GetExtension(f.GetOptions(), options.E_ResourceReference).(*options.ResourceReference)
+	if ref == nil {
+		return nil
+	}
+	if res := r.aipResources()[ref.GetType()]; res != nil {
+		return &ResourceName{Type: res.desc.GetType(), Patterns: res.desc.GetPattern()}
+	}
+	res := r.aipResources()[ref.GetChildType()]
+	if res == nil {
+		return nil
+	}
+	name := &ResourceName{Type: res.desc.GetType(), Parent: true}
+	for _, pattern := range res.desc.GetPattern() {
+		segments := strings.Split(pattern, "/")
+		if len(segments) < 2 {
+			continue
+		}
+		parent := strings.Join(segments[:len(segments)-2], "/")
+		if parent != "" && !slices.Contains(name.Patterns, parent) {
+			name.Patterns = append(name.Patterns, parent)
+		}
+	}
+	return name
+}
+
+// Description describes the resource names of "n", listing its patterns as
+// alternatives if "split" is set and it has several.
+func (n *ResourceName) Description(split bool) string {
+	subject := "The resource name of a `" + n.Type + "`"
+	if n.Parent {
+		subject = "The resource name of the parent of a `" + n.Type + "`"
+	}
+	quoted := make([]string, len(n.Patterns))
+	for i, pattern := range n.Patterns {
+		quoted[i] = "`" + pattern + "`"
+	}
+	if split && len(quoted) > 1 {
+		return subject + ", in one of the formats:\n\n- " + strings.Join(quoted, "\n- ")
+	}
+	return subject + ", formatted as " + strings.Join(quoted, " or ") + "."
+}
+
+// ResourcePatternRegexp returns a regular expression matching the resource
+// names of "patterns", such as "^publishers/[^/]+/books/[^/]+$".
+func ResourcePatternRegexp(patterns []string) string {
+	exprs := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		segments := strings.Split(pattern, "/")
+		for j, segment := range segments {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				segments[j] = "[^/]+"
+			} else {
+				segments[j] = regexp.QuoteMeta(segment)
+			}
+		}
+		exprs[i] = strings.Join(segments, "/")
+	}
+	if len(exprs) == 1 {
+		return "^" + exprs[0] + "$"
+	}
+	return "^(" + strings.Join(exprs, "|") + ")$"
+}
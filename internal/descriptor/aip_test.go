@@ -0,0 +1,205 @@
+package descriptor
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const aipSrc = `
+	name: "library/v1/library.proto"
+	package: "library.v1"
+	message_type <
+		name: "Book"
+		field < name: "name" number: 1 type: TYPE_STRING >
+		field < name: "title" number: 2 type: TYPE_STRING >
+		options <
+			[google.api.resource] <
+				type: "library.example.com/Book"
+				pattern: "publishers/{publisher}/books/{book}"
+			>
+		>
+	>
+	message_type <
+		name: "Shelf"
+		field < name: "name" number: 1 type: TYPE_STRING >
+		options <
+			[google.api.resource] <
+				type: "library.example.com/Shelf"
+				pattern: "shelves/{shelf}"
+			>
+		>
+	>
+	message_type <
+		name: "GetBookRequest"
+		field <
+			name: "name"
+			number: 1
+			type: TYPE_STRING
+			options < [google.api.resource_reference] < type: "library.example.com/Book" > >
+		>
+	>
+	message_type <
+		name: "ListBooksRequest"
+		field <
+			name: "parent"
+			number: 1
+			type: TYPE_STRING
+			options < [google.api.resource_reference] < child_type: "library.example.com/Book" > >
+		>
+	>
+	message_type <
+		name: "ListBooksResponse"
+		field < name: "books" number: 1 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".library.v1.Book" >
+	>
+	message_type <
+		name: "ListShelvesRequest"
+	>
+	message_type <
+		name: "ListShelvesResponse"
+		field < name: "shelves" number: 1 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".library.v1.Shelf" >
+	>
+	message_type <
+		name: "CreateBookRequest"
+		field < name: "parent" number: 1 type: TYPE_STRING >
+		field < name: "book" number: 2 type: TYPE_MESSAGE type_name: ".library.v1.Book" >
+	>
+	message_type <
+		name: "UpdateBookRequest"
+		field < name: "book" number: 1 type: TYPE_MESSAGE type_name: ".library.v1.Book" >
+		field < name: "update_mask" number: 2 type: TYPE_MESSAGE type_name: ".google.protobuf.FieldMask" >
+	>
+	message_type <
+		name: "DeleteBookRequest"
+		field < name: "name" number: 1 type: TYPE_STRING >
+	>
+	message_type <
+		name: "ArchiveBookRequest"
+		field < name: "name" number: 1 type: TYPE_STRING >
+	>
+	message_type <
+		name: "BatchGetBooksRequest"
+		field < name: "parent" number: 1 type: TYPE_STRING >
+		field < name: "names" number: 2 label: LABEL_REPEATED type: TYPE_STRING >
+	>
+	message_type <
+		name: "Empty"
+	>
+	service <
+		name: "Library"
+		method < name: "GetBook" input_type: "GetBookRequest" output_type: "Book" >
+		method < name: "ListBooks" input_type: "ListBooksRequest" output_type: "ListBooksResponse" >
+		method < name: "ListShelves" input_type: "ListShelvesRequest" output_type: "ListShelvesResponse" >
+		method < name: "CreateBook" input_type: "CreateBookRequest" output_type: "Book" >
+		method < name: "UpdateBook" input_type: "UpdateBookRequest" output_type: "Book" >
+		method < name: "DeleteBook" input_type: "DeleteBookRequest" output_type: "Empty" >
+		method < name: "ArchiveBook" input_type: "ArchiveBookRequest" output_type: "Book" >
+		method < name: "BatchGetBooks" input_type: "BatchGetBooksRequest" output_type: "ListBooksResponse" >
+		method < name: "Ping" input_type: "Empty" output_type: "Empty" >
+	>
+`
+
+func TestAIPUnboundMethodBindings(t *testing.T) {
+	var fd descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(aipSrc), &fd); err != nil {
+		t.Fatalf("prototext.Unmarshal(%s, &fd) failed with %v; want success", aipSrc, err)
+	}
+	fieldMask := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("google/protobuf/field_mask.proto"),
+		Package: proto.String("google.protobuf"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("FieldMask"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:   proto.String("paths"),
+				Number: proto.Int32(1),
+				Label:  descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+				Type:   descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			}},
+		}},
+	}
+
+	reg := NewRegistry()
+	reg.SetGenerateUnboundMethods(true)
+	if err := reg.SetUnboundMethodBindings("aip"); err != nil {
+		t.Fatalf("reg.SetUnboundMethodBindings(%q) failed with %v; want success", "aip", err)
+	}
+	reg.loadFile(fieldMask.GetName(), &protogen.File{Proto: fieldMask})
+	reg.loadFile(fd.GetName(), &protogen.File{Proto: &fd})
+	file := reg.files[fd.GetName()]
+	if err := reg.loadServices(file); err != nil {
+		t.Fatalf("reg.loadServices(%q) failed with %v; want success", fd.GetName(), err)
+	}
+
+	for _, spec := range []struct {
+		method, httpMethod, template, body string
+	}{
+		{method: "GetBook", httpMethod: "GET", template: "/v1/{name=publishers/*/books/*}"},
+		{method: "ListBooks", httpMethod: "GET", template: "/v1/{parent=publishers/*}/books"},
+		{method: "ListShelves", httpMethod: "GET", template: "/v1/shelves"},
+		{method: "CreateBook", httpMethod: "POST", template: "/v1/{parent=publishers/*}/books", body: "book"},
+		{method: "UpdateBook", httpMethod: "PATCH", template: "/v1/{book.name=publishers/*/books/*}", body: "book"},
+		{method: "DeleteBook", httpMethod: "DELETE", template: "/v1/{name=publishers/*/books/*}"},
+		{method: "ArchiveBook", httpMethod: "POST", template: "/v1/{name=publishers/*/books/*}:archive", body: "*"},
+		{method: "BatchGetBooks", httpMethod: "POST", template: "/v1/{parent=publishers/*}/books:batchGet", body: "*"},
+		{method: "Ping", httpMethod: "POST", template: "/library.v1.Library/Ping", body: "*"},
+	} {
+		m, err := reg.LookupMethod(".library.v1.Library." + spec.method)
+		if err != nil {
+			t.Fatalf("reg.LookupMethod(%q) failed with %v; want success", spec.method, err)
+		}
+		if len(m.Bindings) != 1 {
+			t.Fatalf("%s has %d bindings; want 1", spec.method, len(m.Bindings))
+		}
+		b := m.Bindings[0]
+		if b.HTTPMethod != spec.httpMethod || b.PathTmpl.Template != spec.template {
+			t.Errorf("%s is bound to %s %s; want %s %s", spec.method, b.HTTPMethod, b.PathTmpl.Template, spec.httpMethod, spec.template)
+		}
+		var body string
+		if b.Body != nil {
+			body = "*"
+			if len(b.Body.FieldPath) > 0 {
+				body = b.Body.FieldPath.String()
+			}
+		}
+		if body != spec.body {
+			t.Errorf("%s has body %q; want %q", spec.method, body, spec.body)
+		}
+	}
+}
+
+func TestSetUnboundMethodBindings(t *testing.T) {
+	reg := NewRegistry()
+	if got := reg.GetUnboundMethodBindings(); got != "grpc" {
+		t.Errorf("reg.GetUnboundMethodBindings() = %q; want %q", got, "grpc")
+	}
+	if err := reg.SetUnboundMethodBindings("rest"); err == nil {
+		t.Errorf("reg.SetUnboundMethodBindings(%q) succeeded; want failure", "rest")
+	}
+}
+
+func TestResourceNameDescription(t *testing.T) {
+	name := &ResourceName{
+		Type:     "library.example.com/Book",
+		Patterns: []string{"publishers/{publisher}/books/{book}", "authors/{author}/books/{book}"},
+	}
+	if got, want := name.Description(false), "The resource name of a `library.example.com/Book`, formatted as `publishers/{publisher}/books/{book}` or `authors/{author}/books/{book}`."; got != want {
+		t.Errorf("name.Description(false) = %q; want %q", got, want)
+	}
+	if got, want := name.Description(true), "The resource name of a `library.example.com/Book`, in one of the formats:\n\n- `publishers/{publisher}/books/{book}`\n- `authors/{author}/books/{book}`"; got != want {
+		t.Errorf("name.Description(true) = %q; want %q", got, want)
+	}
+	if got, want := ResourcePatternRegexp(name.Patterns), "^(publishers/[^/]+/books/[^/]+|authors/[^/]+/books/[^/]+)$"; got != want {
+		t.Errorf("ResourcePatternRegexp(%q) = %q; want %q", name.Patterns, got, want)
+	}
+
+	parent := &ResourceName{Type: "library.example.com/Book", Patterns: []string{"shelves.v1/{shelf}"}, Parent: true}
+	if got, want := parent.Description(true), "The resource name of the parent of a `library.example.com/Book`, formatted as `shelves.v1/{shelf}`."; got != want {
+		t.Errorf("parent.Description(true) = %q; want %q", got, want)
+	}
+	if got, want := ResourcePatternRegexp(parent.Patterns), `^shelves\.v1/[^/]+$`; got != want {
+		t.Errorf("ResourcePatternRegexp(%q) = %q; want %q", parent.Patterns, got, want)
+	}
+}
@@ -0,0 +1,416 @@
+package descriptor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/httprule"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	options "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// Field numbers locating the google.api.http options of methods in the source
+// code info of files.
+const (
+	fileServiceField                = 6  // FileDescriptorProto.service
+	serviceMethodField              = 2  // ServiceDescriptorProto.method
+	methodOptionsField              = 4  // MethodDescriptorProto.options
+	httpRuleBodyField               = 7  // HttpRule.body
+	httpRuleAdditionalBindingsField = 11 // HttpRule.additional_bindings
+	httpRuleResponseBodyField       = 12 // HttpRule.response_body
+)
+
+// LintSeverity is the severity of a LintProblem.
+type LintSeverity int
+
+const (
+	// LintSeverityInfo reports a binding which is valid, but possibly not
+	// intended, such as a method without any HttpRule.
+	LintSeverityInfo LintSeverity = iota
+	// LintSeverityWarning reports bindings the gateway serves, but
+	// ambiguously, such as overlapping path templates.
+	LintSeverityWarning
+	// LintSeverityError reports bindings the generators reject.
+	LintSeverityError
+)
+
+var lintSeverityNames = []string{"info", "warning", "error"}
+
+// ParseLintSeverity parses the name of a severity, "info", "warning" or
+// "error".
+func ParseLintSeverity(name string) (LintSeverity, error) {
+	if i := slices.Index(lintSeverityNames, name); i >= 0 {
+		return LintSeverity(i), nil
+	}
+	return 0, fmt.Errorf("unknown lint severity: %s", name)
+}
+
+func (s LintSeverity) String() string {
+	if s < 0 || int(s) >= len(lintSeverityNames) {
+		return fmt.Sprintf("LintSeverity(%d)", int(s))
+	}
+	return lintSeverityNames[s]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s LintSeverity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *LintSeverity) UnmarshalText(text []byte) error {
+	severity, err := ParseLintSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = severity
+	return nil
+}
+
+// LintProblem is a problem in the google.api.http bindings of the methods,
+// found by Registry.Lint.
+type LintProblem struct {
+	// File is the name of the proto file the problem is in, or empty if the
+	// problem is not in a file, such as an HttpRule of the gRPC API
+	// Configuration without a matching method.
+	File string `json:"file,omitempty"`
+	// Line and Column are the 1-based position of the problem in File, or 0
+	// if the file has no source code info.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+	// Severity is the severity of the problem, and Rule the name of the
+	// check which found it, such as "overlapping-bindings".
+	Severity LintSeverity `json:"severity"`
+	Rule     string       `json:"rule"`
+	Message  string       `json:"message"`
+}
+
+// String formats p as "file:line:column: severity: message (rule)".
+func (p LintProblem) String() string {
+	var b strings.Builder
+	if p.File != "" {
+		b.WriteString(p.File)
+		if p.Line > 0 {
+			fmt.Fprintf(&b, ":%d:%d", p.Line, p.Column)
+		}
+		b.WriteString(": ")
+	}
+	fmt.Fprintf(&b, "%s: %s (%s)", p.Severity, p.Message, p.Rule)
+	return b.String()
+}
+
+// ruleError is an error in the field "field" of an HttpRule, found by the
+// lint rule "rule".
+type ruleError struct {
+	rule  string
+	field int32
+	err   error
+}
+
+func (e *ruleError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ruleError) Unwrap() error {
+	return e.err
+}
+
+// httpRulePatternField returns the field number of the pattern of "opts",
+// such as HttpRule.get.
+func httpRulePatternField(opts *options.HttpRule) int32 {
+	m := opts.ProtoReflect()
+	fd := m.WhichOneof(m.Descriptor().Oneofs().ByName("pattern"))
+	if fd == nil {
+		return 0
+	}
+	return int32(fd.Number())
+}
+
+// SetLintOnly sets lintOnly, causing Load to record the problems of the
+// bindings, returned by Lint, instead of failing on the first one.
+func (r *Registry) SetLintOnly(lintOnly bool) {
+	r.lintOnly = lintOnly
+}
+
+// GetLintOnly returns lintOnly
+func (r *Registry) GetLintOnly() bool {
+	return r.lintOnly
+}
+
+// lintMethod records "err", found by "rule" in the method at "path" of
+// "file", in lint mode. Otherwise, it returns "err".
+func (r *Registry) lintMethod(file *File, path []int32, rule string, err error) error {
+	if !r.lintOnly {
+		return err
+	}
+	r.addLintProblem(file, path, LintSeverityError, rule, err.Error())
+	return nil
+}
+
+// lintBinding records "err", found in the binding at "rulePath" of the method
+// at "methodPath" of "file", in lint mode. Otherwise, it returns "err".
+// "rulePath" is nil for bindings of the gRPC API Configuration.
+func (r *Registry) lintBinding(file *File, methodPath, rulePath []int32, err error) error {
+	if !r.lintOnly {
+		return err
+	}
+	rule, path := "invalid-binding", methodPath
+	if rerr := (*ruleError)(nil); errors.As(err, &rerr) {
+		rule = rerr.rule
+		if rulePath != nil && rerr.field != 0 {
+			rulePath = append(slices.Clip(rulePath), rerr.field)
+		}
+	}
+	if rulePath != nil {
+		path = rulePath
+	}
+	r.addLintProblem(file, path, LintSeverityError, rule, err.Error())
+	return nil
+}
+
+// setBindingSourcePath records the source path of the pattern of "opts", the
+// HttpRule at "rulePath" of "b", in lint mode.
+func (r *Registry) setBindingSourcePath(b *Binding, opts *options.HttpRule, rulePath []int32) {
+	if !r.lintOnly || rulePath == nil {
+		return
+	}
+	if r.bindingSourcePaths == nil {
+		r.bindingSourcePaths = make(map[*Binding][]int32)
+	}
+	r.bindingSourcePaths[b] = append(slices.Clip(rulePath), httpRulePatternField(opts))
+}
+
+func (r *Registry) addLintProblem(file *File, path []int32, severity LintSeverity, rule, msg string) {
+	p := LintProblem{
+		File:     file.GetName(),
+		Severity: severity,
+		Rule:     rule,
+		Message:  msg,
+	}
+	p.Line, p.Column = sourcePosition(file, path)
+	r.lintProblems = append(r.lintProblems, p)
+}
+
+// sourcePosition returns the 1-based position of the element at "path" in
+// "file", or of its closest enclosing element with a location, since protoc
+// does not record the location of every option.
+func sourcePosition(file *File, path []int32) (line, column int) {
+	var best []int32
+	for _, loc := range file.GetSourceCodeInfo().GetLocation() {
+		p := loc.GetPath()
+		if len(loc.GetSpan()) < 3 || len(p) > len(path) || !slices.Equal(p, path[:len(p)]) {
+			continue
+		}
+		if best == nil || len(p) > len(best) {
+			best = p
+			line, column = int(loc.GetSpan()[0])+1, int(loc.GetSpan()[1])+1
+		}
+	}
+	return line, column
+}
+
+// Lint returns the problems of the bindings of the loaded methods whose
+// severity is at least "minSeverity", sorted by position. Problems the
+// generators would fail on are only recorded while loading in lint mode.
+func (r *Registry) Lint(minSeverity LintSeverity) []LintProblem {
+	problems := slices.Clone(r.lintProblems)
+	for _, selector := range r.UnboundExternalHTTPRules() {
+		problems = append(problems, LintProblem{
+			Severity: LintSeverityError,
+			Rule:     "unmatched-selector",
+			Message:  fmt.Sprintf("HTTP rule without a matching selector: %s", selector),
+		})
+	}
+	problems = append(problems, r.lintRoutes()...)
+
+	problems = slices.DeleteFunc(problems, func(p LintProblem) bool {
+		return p.Severity < minSeverity
+	})
+	sort.SliceStable(problems, func(i, j int) bool {
+		a, b := problems[i], problems[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return problems
+}
+
+// lintRoute is a binding as routed by runtime.ServeMux.
+type lintRoute struct {
+	binding *Binding
+	// segments lists the components matched by the path template: a
+	// literal, "*" for a single component or "**" for any number of them.
+	segments []string
+}
+
+func (r *Registry) lintRoutes() []LintProblem {
+	var files []string
+	for name := range r.files {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	var routes []lintRoute
+	for _, name := range files {
+		for _, svc := range r.files[name].Services {
+			for _, m := range svc.Methods {
+				for _, b := range m.Bindings {
+					if b != nil {
+						routes = append(routes, lintRoute{binding: b, segments: templateSegments(b.PathTmpl)})
+					}
+				}
+			}
+		}
+	}
+
+	var problems []LintProblem
+	report := func(b *Binding, severity LintSeverity, rule, msg string) {
+		file := b.Method.Service.File
+		p := LintProblem{
+			File:     file.GetName(),
+			Severity: severity,
+			Rule:     rule,
+			Message:  msg,
+		}
+		path, ok := r.bindingSourcePaths[b]
+		if !ok {
+			path = r.methodSourcePath(b.Method)
+		}
+		p.Line, p.Column = sourcePosition(file, path)
+		problems = append(problems, p)
+	}
+	for j, b := range routes {
+		for _, a := range routes[:j] {
+			if a.binding.Method == b.binding.Method || a.binding.HTTPMethod != b.binding.HTTPMethod {
+				continue
+			}
+			other := fmt.Sprintf("%s of %s", a.binding.PathTmpl.Template, strings.TrimPrefix(a.binding.Method.FQMN(), "."))
+			verbA, verbB := a.binding.PathTmpl.Verb, b.binding.PathTmpl.Verb
+			switch {
+			case verbA == verbB && slices.Equal(a.segments, b.segments):
+				report(b.binding, LintSeverityError, "duplicate-binding", fmt.Sprintf("%s %s is also bound by %s", b.binding.HTTPMethod, b.binding.PathTmpl.Template, other))
+			case verbA == verbB && segmentsOverlap(a.segments, b.segments):
+				report(b.binding, LintSeverityWarning, "overlapping-bindings", fmt.Sprintf("%s %s overlaps %s; ServeMux routes the requests matching both by registration order", b.binding.HTTPMethod, b.binding.PathTmpl.Template, other))
+			case verbA != "" && verbB == "" && verbClash(a.segments, b.segments), verbB != "" && verbA == "" && verbClash(b.segments, a.segments):
+				report(b.binding, LintSeverityWarning, "verb-clash", fmt.Sprintf("%s %s overlaps %s, since the last segment of the template without a verb also matches the verb", b.binding.HTTPMethod, b.binding.PathTmpl.Template, other))
+			}
+		}
+	}
+	return problems
+}
+
+// methodSourcePath returns the source path of "m" within its file.
+func (r *Registry) methodSourcePath(m *Method) []int32 {
+	file := m.Service.File
+	for si, sd := range file.GetService() {
+		for mi, md := range sd.GetMethod() {
+			if md == m.MethodDescriptorProto {
+				return []int32{fileServiceField, int32(si), serviceMethodField, int32(mi)}
+			}
+		}
+	}
+	return nil
+}
+
+// templateSegments returns the components matched by "tmpl".
+func templateSegments(tmpl httprule.Template) []string {
+	var segments []string
+	for i := 0; i+1 < len(tmpl.OpCodes); i += 2 {
+		switch utilities.OpCode(tmpl.OpCodes[i]) {
+		case utilities.OpPush:
+			segments = append(segments, "*")
+		case utilities.OpPushM:
+			segments = append(segments, "**")
+		case utilities.OpLitPush:
+			segments = append(segments, tmpl.Pool[tmpl.OpCodes[i+1]])
+		}
+	}
+	return segments
+}
+
+// segmentsOverlap reports whether a path exists matching both "a" and "b".
+func segmentsOverlap(a, b []string) bool {
+	switch {
+	case len(a) > 0 && a[0] == "**":
+		return segmentsOverlap(a[1:], b) || (len(b) > 0 && segmentsOverlap(a, b[1:]))
+	case len(b) > 0 && b[0] == "**":
+		return segmentsOverlap(a, b[1:]) || (len(a) > 0 && segmentsOverlap(a[1:], b))
+	case len(a) == 0 || len(b) == 0:
+		return len(a) == len(b)
+	case a[0] == "*" || b[0] == "*" || a[0] == b[0]:
+		return segmentsOverlap(a[1:], b[1:])
+	}
+	return false
+}
+
+// verbClash reports whether the template without a verb "plain" matches
+// paths of the template with a verb "withVerb", its last component capturing
+// the verb, such as /v1/{name} and /v1/{name}:cancel.
+func verbClash(withVerb, plain []string) bool {
+	if len(withVerb) == 0 || len(plain) == 0 {
+		return false
+	}
+	if last := plain[len(plain)-1]; last != "*" && last != "**" {
+		return false
+	}
+	withVerb = slices.Clone(withVerb)
+	if last := len(withVerb) - 1; withVerb[last] != "**" {
+		// The last component, followed by the verb, only matches wildcards.
+		withVerb[last] = ":"
+	}
+	return segmentsOverlap(withVerb, plain)
+}
+
+// LintResponse returns the response of a plugin reporting "problems" in
+// "format". "text" fails with an error listing the problems if any of them is
+// an error, and otherwise writes them to "w", such as os.Stderr, without
+// failing. "json" generates a lint.json report of the problems.
+func LintResponse(problems []LintProblem, format string, w io.Writer) (*pluginpb.CodeGeneratorResponse, error) {
+	switch format {
+	case "text":
+		if len(problems) == 0 {
+			return &pluginpb.CodeGeneratorResponse{}, nil
+		}
+		lines := make([]string, len(problems))
+		for i, p := range problems {
+			lines[i] = p.String()
+		}
+		report := fmt.Sprintf("%d binding problem(s) found:\n%s", len(problems), strings.Join(lines, "\n"))
+		if !slices.ContainsFunc(problems, func(p LintProblem) bool { return p.Severity >= LintSeverityError }) {
+			if _, err := fmt.Fprintln(w, report); err != nil {
+				return nil, err
+			}
+			return &pluginpb.CodeGeneratorResponse{}, nil
+		}
+		return &pluginpb.CodeGeneratorResponse{
+			Error: proto.String(report),
+		}, nil
+	case "json":
+		if problems == nil {
+			problems = []LintProblem{}
+		}
+		report, err := json.MarshalIndent(struct {
+			Problems []LintProblem `json:"problems"`
+		}{problems}, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return &pluginpb.CodeGeneratorResponse{
+			File: []*pluginpb.CodeGeneratorResponse_File{{
+				Name:    proto.String("lint.json"),
+				Content: proto.String(string(report) + "\n"),
+			}},
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown lint format: %s", format)
+}
@@ -0,0 +1,269 @@
+package descriptor
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func newLintRegistry(t *testing.T, src string) *Registry {
+	t.Helper()
+	var fd descriptorpb.FileDescriptorProto
+	if err := prototext.Unmarshal([]byte(src), &fd); err != nil {
+		t.Fatalf("prototext.Unmarshal(%s, &fd) failed with %v; want success", src, err)
+	}
+	reg := NewRegistry()
+	reg.SetLintOnly(true)
+	reg.loadFile(fd.GetName(), &protogen.File{
+		Proto: &fd,
+	})
+	if err := reg.loadServices(reg.files[fd.GetName()]); err != nil {
+		t.Fatalf("loadServices(%q) failed with %v; want success in lint mode", fd.GetName(), err)
+	}
+	return reg
+}
+
+const lintSrc = `
+	name: "path/to/example.proto"
+	package: "example"
+	message_type <
+		name: "Thing"
+		field <
+			name: "name"
+			number: 1
+			type: TYPE_STRING
+		>
+	>
+	service <
+		name: "ThingService"
+		method <
+			name: "GetThing"
+			input_type: "Thing"
+			output_type: "Thing"
+			options <
+				[google.api.http] <
+					get: "/v1/{name=things/*}"
+				>
+			>
+		>
+		method <
+			name: "GetLatestThing"
+			input_type: "Thing"
+			output_type: "Thing"
+			options <
+				[google.api.http] <
+					get: "/v1/things/latest"
+				>
+			>
+		>
+		method <
+			name: "UpdateThing"
+			input_type: "Thing"
+			output_type: "Thing"
+			options <
+				[google.api.http] <
+					post: "/v1/{name=things/*}"
+					body: "*"
+				>
+			>
+		>
+		method <
+			name: "CancelThing"
+			input_type: "Thing"
+			output_type: "Thing"
+			options <
+				[google.api.http] <
+					post: "/v1/{name=things/*}:cancel"
+					additional_bindings <
+						get: "/v1/things:search"
+						body: "*"
+					>
+					additional_bindings <
+						get: "/v1/{missing}"
+					>
+				>
+			>
+		>
+		method <
+			name: "ListThings"
+			input_type: "Thing"
+			output_type: "Thing"
+		>
+	>
+	source_code_info <
+		location <
+			path: [6, 0, 2, 1]
+			span: [20, 2, 24, 3]
+		>
+		location <
+			path: [6, 0, 2, 1, 4, 72295728, 2]
+			span: [22, 6, 30]
+		>
+		location <
+			path: [6, 0, 2, 3, 4, 72295728]
+			span: [40, 4, 50, 6]
+		>
+		location <
+			path: [6, 0, 2, 3, 4, 72295728, 11, 0, 7]
+			span: [44, 8, 17]
+		>
+		location <
+			path: [6, 0, 2, 4]
+			span: [60, 2, 61, 3]
+		>
+	>
+`
+
+func TestRegistryLint(t *testing.T) {
+	reg := newLintRegistry(t, lintSrc)
+
+	got := reg.Lint(LintSeverityInfo)
+	want := []LintProblem{
+		{File: "path/to/example.proto", Line: 23, Column: 7, Severity: LintSeverityWarning, Rule: "overlapping-bindings", Message: "GET /v1/things/latest overlaps /v1/{name=things/*} of example.ThingService.GetThing; ServeMux routes the requests matching both by registration order"},
+		{File: "path/to/example.proto", Line: 41, Column: 5, Severity: LintSeverityError, Rule: "invalid-path-param", Message: `no field "missing" found in Thing`},
+		{File: "path/to/example.proto", Line: 41, Column: 5, Severity: LintSeverityWarning, Rule: "verb-clash", Message: "POST /v1/{name=things/*}:cancel overlaps /v1/{name=things/*} of example.ThingService.UpdateThing, since the last segment of the template without a verb also matches the verb"},
+		{File: "path/to/example.proto", Line: 45, Column: 9, Severity: LintSeverityError, Rule: "get-with-body", Message: "must not set request body when http method is GET: CancelThing"},
+		{File: "path/to/example.proto", Line: 61, Column: 3, Severity: LintSeverityInfo, Rule: "unbound-method", Message: "example.ThingService.ListThings has no HttpRule and is not exposed by the gateway"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("reg.Lint(%v) = %#v; want %#v", LintSeverityInfo, got, want)
+	}
+
+	got = reg.Lint(LintSeverityError)
+	if len(got) != 2 {
+		t.Errorf("reg.Lint(%v) = %v; want the 2 errors", LintSeverityError, got)
+	}
+}
+
+func TestRegistryLintDuplicateBinding(t *testing.T) {
+	reg := newLintRegistry(t, `
+		name: "path/to/example.proto"
+		package: "example"
+		message_type <
+			name: "Thing"
+		>
+		service <
+			name: "ThingService"
+			method <
+				name: "ListThings"
+				input_type: "Thing"
+				output_type: "Thing"
+				options <
+					[google.api.http] <
+						get: "/v1/things"
+					>
+				>
+			>
+			method <
+				name: "SearchThings"
+				input_type: "Thing"
+				output_type: "Thing"
+				options <
+					[google.api.http] <
+						get: "/v1/things"
+					>
+				>
+			>
+		>
+	`)
+
+	got := reg.Lint(LintSeverityWarning)
+	if len(got) != 1 || got[0].Rule != "duplicate-binding" || got[0].Severity != LintSeverityError {
+		t.Errorf("reg.Lint(%v) = %v; want a duplicate-binding error", LintSeverityWarning, got)
+	}
+}
+
+func TestSegmentsOverlap(t *testing.T) {
+	for _, spec := range []struct {
+		a, b string
+		want bool
+	}{
+		{a: "/v1/things", b: "/v1/things", want: true},
+		{a: "/v1/things", b: "/v1/others", want: false},
+		{a: "/v1/{name}", b: "/v1/things", want: true},
+		{a: "/v1/{name}", b: "/v1/things/latest", want: false},
+		{a: "/v1/{name=**}", b: "/v1/things/latest", want: true},
+		{a: "/v1/{name=**}/latest", b: "/v1/things", want: false},
+		{a: "/v1/{name=**}/latest", b: "/v1/*/{id}", want: true},
+		{a: "/v1/{a=**}/x", b: "/v1/{b=**}/y", want: false},
+	} {
+		a, b := templateSegments(compilePath(t, spec.a)), templateSegments(compilePath(t, spec.b))
+		if got := segmentsOverlap(a, b); got != spec.want {
+			t.Errorf("segmentsOverlap(%q, %q) = %v; want %v", spec.a, spec.b, got, spec.want)
+		}
+		if got := segmentsOverlap(b, a); got != spec.want {
+			t.Errorf("segmentsOverlap(%q, %q) = %v; want %v", spec.b, spec.a, got, spec.want)
+		}
+	}
+}
+
+func TestLintResponse(t *testing.T) {
+	problems := []LintProblem{
+		{File: "a.proto", Line: 3, Column: 5, Severity: LintSeverityError, Rule: "get-with-body", Message: "must not set request body"},
+		{Severity: LintSeverityError, Rule: "unmatched-selector", Message: "HTTP rule without a matching selector: .a.B.C"},
+	}
+
+	resp, err := LintResponse(problems, "text", io.Discard)
+	if err != nil {
+		t.Fatalf("LintResponse(%v, %q) failed with %v; want success", problems, "text", err)
+	}
+	want := "2 binding problem(s) found:\n" +
+		"a.proto:3:5: error: must not set request body (get-with-body)\n" +
+		"error: HTTP rule without a matching selector: .a.B.C (unmatched-selector)"
+	if got := resp.GetError(); got != want {
+		t.Errorf("LintResponse(%v, %q).Error = %q; want %q", problems, "text", got, want)
+	}
+
+	resp, err = LintResponse(problems, "json", io.Discard)
+	if err != nil {
+		t.Fatalf("LintResponse(%v, %q) failed with %v; want success", problems, "json", err)
+	}
+	if len(resp.GetFile()) != 1 || resp.GetFile()[0].GetName() != "lint.json" {
+		t.Fatalf("LintResponse(%v, %q).File = %v; want lint.json", problems, "json", resp.GetFile())
+	}
+	var report struct {
+		Problems []LintProblem `json:"problems"`
+	}
+	if err := json.Unmarshal([]byte(resp.GetFile()[0].GetContent()), &report); err != nil {
+		t.Fatalf("json.Unmarshal(%s) failed with %v; want success", resp.GetFile()[0].GetContent(), err)
+	}
+	if !reflect.DeepEqual(report.Problems, problems) {
+		t.Errorf("report.Problems = %v; want %v", report.Problems, problems)
+	}
+	if content := resp.GetFile()[0].GetContent(); !strings.Contains(content, `"severity": "error"`) {
+		t.Errorf("report = %s; want severities by name", content)
+	}
+
+	if _, err := LintResponse(problems, "xml", io.Discard); err == nil {
+		t.Errorf("LintResponse(%v, %q) succeeded; want failure", problems, "xml")
+	}
+}
+
+func TestLintResponseWarnings(t *testing.T) {
+	problems := []LintProblem{
+		{File: "a.proto", Line: 3, Column: 5, Severity: LintSeverityWarning, Rule: "overlapping-bindings", Message: "GET /v1/a overlaps /v1/{name}"},
+		{Severity: LintSeverityInfo, Rule: "unbound-method", Message: "a.B.C has no HttpRule"},
+	}
+
+	var stderr bytes.Buffer
+	resp, err := LintResponse(problems, "text", &stderr)
+	if err != nil {
+		t.Fatalf("LintResponse(%v, %q) failed with %v; want success", problems, "text", err)
+	}
+	if resp.Error != nil {
+		t.Errorf("LintResponse(%v, %q).Error = %q; want none", problems, "text", resp.GetError())
+	}
+	want := "2 binding problem(s) found:\n" +
+		"a.proto:3:5: warning: GET /v1/a overlaps /v1/{name} (overlapping-bindings)\n" +
+		"info: a.B.C has no HttpRule (unbound-method)\n"
+	if got := stderr.String(); got != want {
+		t.Errorf("LintResponse(%v, %q) wrote %q; want %q", problems, "text", got, want)
+	}
+}
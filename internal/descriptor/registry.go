@@ -2,6 +2,7 @@ package descriptor
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -202,6 +203,63 @@ type Registry struct {
 
 	// generateXGoType is a global generator option for generating x-go-type annotations
 	generateXGoType bool
+
+	// marshalerMediaTypes lists the media types, besides application/json, the gateway
+	// is configured to marshal, such as application/cbor and application/msgpack.
+	marshalerMediaTypes []string
+
+	// streamEnvelope is the envelope of the records of response streams,
+	// "result" (the default) or "none".
+	streamEnvelope string
+
+	// basePath is the path the gateway mounts its routes under, as set by
+	// runtime.WithBasePath, such as "/api/v2".
+	basePath string
+
+	// streamFraming is the framing of response streams, "delimited" (the
+	// default), "ndjson" or "json-seq".
+	streamFraming string
+
+	// generateHTTPClient causes the gateway generator to also generate typed
+	// Go clients calling the methods through their HTTP bindings.
+	generateHTTPClient bool
+
+	// validateRequests causes the gateway generator to validate the request
+	// messages with runtime.ValidateRequest before calling the methods.
+	validateRequests bool
+
+	// gatewayMain is the directory where the gateway generator also
+	// generates the main package of a gateway command, if not empty.
+	gatewayMain string
+
+	// lintOnly causes Load to record the problems of the bindings instead of
+	// failing on the first one.
+	lintOnly bool
+
+	// lintProblems lists the problems recorded by Load in lint mode.
+	lintProblems []LintProblem
+
+	// bindingSourcePaths is a mapping from bindings to the source path of
+	// their pattern, recorded by Load in lint mode.
+	bindingSourcePaths map[*Binding][]int32
+
+	// unboundMethodBindings is the strategy generating the bindings of the
+	// RPC methods that have no HttpRule annotation, "grpc" (the default) or
+	// "aip".
+	unboundMethodBindings string
+
+	// resources is a mapping from the types of the resources declared by
+	// google.api.resource and google.api.resource_definition annotations to
+	// the resources, built on demand by the "aip" unbound method bindings.
+	resources map[string]*aipResource
+
+	// splitResourcePatterns causes the OpenAPI generators to document the
+	// patterns of resource names with several patterns as alternatives.
+	splitResourcePatterns bool
+
+	// requestSchemaVariants causes the OpenAPI generators to describe request
+	// bodies with variants of the messages honoring their field behaviors.
+	requestSchemaVariants bool
 }
 
 type repeatedFieldSeparator struct {
@@ -303,6 +361,8 @@ func (r *Registry) loadFile(filePath string, file *protogen.File) {
 			}
 		}
 	}
+	// The resources are indexed again with the messages of the file.
+	r.resources = nil
 	f := &File{
 		FileDescriptorProto:     file.Proto,
 		GoPkg:                   pkg,
@@ -426,6 +486,16 @@ func (r *Registry) LookupFile(name string) (*File, error) {
 	return f, nil
 }
 
+// LookupMethod looks up a method by its fully-qualified name, such as
+// ".example.ExampleService.Echo".
+func (r *Registry) LookupMethod(fqmn string) (*Method, error) {
+	m, ok := r.meths[fqmn]
+	if !ok {
+		return nil, fmt.Errorf("no method found: %s", fqmn)
+	}
+	return m, nil
+}
+
 func (r *Registry) GetUseProto3FieldSemantics() bool {
 	return r.useProto3FieldSemantics
 }
@@ -1055,3 +1125,170 @@ func (r *Registry) SetGenerateXGoType(generateXGoType bool) {
 func (r *Registry) GetGenerateXGoType() bool {
 	return r.generateXGoType
 }
+
+// SetMarshalerMediaTypes sets the media types, besides application/json, which
+// request and response bodies may be encoded with.
+func (r *Registry) SetMarshalerMediaTypes(mediaTypes []string) {
+	r.marshalerMediaTypes = nil
+	for _, mediaType := range mediaTypes {
+		mediaType = strings.TrimSpace(mediaType)
+		if mediaType != "" && mediaType != "application/json" && !slices.Contains(r.marshalerMediaTypes, mediaType) {
+			r.marshalerMediaTypes = append(r.marshalerMediaTypes, mediaType)
+		}
+	}
+}
+
+// GetMarshalerMediaTypes returns the media types set with SetMarshalerMediaTypes.
+func (r *Registry) GetMarshalerMediaTypes() []string {
+	return r.marshalerMediaTypes
+}
+
+// SetBasePath sets the path the gateway mounts its routes under, as set by
+// runtime.WithBasePath.
+func (r *Registry) SetBasePath(basePath string) {
+	r.basePath = ""
+	if basePath = strings.Trim(basePath, "/"); basePath != "" {
+		r.basePath = "/" + basePath
+	}
+}
+
+// GetBasePath returns the path the gateway mounts its routes under, either
+// empty or with a leading slash and no trailing slash.
+func (r *Registry) GetBasePath() string {
+	return r.basePath
+}
+
+// SetStreamEnvelope sets the envelope the gateway wraps the records of
+// response streams in, "result" or "none", as set by runtime.WithStreamEnvelope.
+func (r *Registry) SetStreamEnvelope(envelope string) error {
+	switch envelope {
+	case "", "result":
+		r.streamEnvelope = ""
+	case "none":
+		r.streamEnvelope = envelope
+	default:
+		return fmt.Errorf("unknown stream envelope: %s", envelope)
+	}
+	return nil
+}
+
+// GetStreamEnvelope returns the envelope of the records of response streams,
+// "result" or "none".
+func (r *Registry) GetStreamEnvelope() string {
+	if r.streamEnvelope == "" {
+		return "result"
+	}
+	return r.streamEnvelope
+}
+
+// SetStreamFraming sets the framing of response streams, "delimited",
+// "ndjson" or "json-seq", as set by runtime.WithStreamFraming.
+func (r *Registry) SetStreamFraming(framing string) error {
+	switch framing {
+	case "", "delimited":
+		r.streamFraming = ""
+	case "ndjson", "json-seq":
+		r.streamFraming = framing
+	default:
+		return fmt.Errorf("unknown stream framing: %s", framing)
+	}
+	return nil
+}
+
+// GetStreamFraming returns the framing of response streams, "delimited",
+// "ndjson" or "json-seq".
+func (r *Registry) GetStreamFraming() string {
+	if r.streamFraming == "" {
+		return "delimited"
+	}
+	return r.streamFraming
+}
+
+// GetStreamMediaType returns the media type of response streams framed as
+// set by SetStreamFraming, or "" if streams use the media types of the
+// marshalers.
+func (r *Registry) GetStreamMediaType() string {
+	switch r.streamFraming {
+	case "ndjson":
+		return "application/x-ndjson"
+	case "json-seq":
+		return "application/json-seq"
+	}
+	return ""
+}
+
+// SetGenerateHTTPClient sets generateHTTPClient
+func (r *Registry) SetGenerateHTTPClient(generate bool) {
+	r.generateHTTPClient = generate
+}
+
+// GetGenerateHTTPClient returns generateHTTPClient
+func (r *Registry) GetGenerateHTTPClient() bool {
+	return r.generateHTTPClient
+}
+
+// SetValidateRequests sets validateRequests
+func (r *Registry) SetValidateRequests(validate bool) {
+	r.validateRequests = validate
+}
+
+// GetValidateRequests returns validateRequests
+func (r *Registry) GetValidateRequests() bool {
+	return r.validateRequests
+}
+
+// SetGatewayMain sets gatewayMain
+func (r *Registry) SetGatewayMain(dir string) {
+	r.gatewayMain = dir
+}
+
+// GetGatewayMain returns gatewayMain
+func (r *Registry) GetGatewayMain() string {
+	return r.gatewayMain
+}
+
+// SetUnboundMethodBindings sets the strategy generating the bindings of the
+// RPC methods that have no HttpRule annotation when generateUnboundMethods is
+// set: "grpc" binds them to POST /<package>.<Service>/<Method>, and "aip"
+// infers RESTful bindings from the resource-oriented design conventions of
+// AIP-131 to AIP-136, falling back to "grpc".
+func (r *Registry) SetUnboundMethodBindings(strategy string) error {
+	switch strategy {
+	case "", "grpc":
+		r.unboundMethodBindings = ""
+	case "aip":
+		r.unboundMethodBindings = strategy
+	default:
+		return fmt.Errorf("unknown unbound method bindings: %s", strategy)
+	}
+	return nil
+}
+
+// SetSplitResourcePatterns sets splitResourcePatterns
+func (r *Registry) SetSplitResourcePatterns(split bool) {
+	r.splitResourcePatterns = split
+}
+
+// GetSplitResourcePatterns returns splitResourcePatterns
+func (r *Registry) GetSplitResourcePatterns() bool {
+	return r.splitResourcePatterns
+}
+
+// SetRequestSchemaVariants sets requestSchemaVariants
+func (r *Registry) SetRequestSchemaVariants(variants bool) {
+	r.requestSchemaVariants = variants
+}
+
+// GetRequestSchemaVariants returns requestSchemaVariants
+func (r *Registry) GetRequestSchemaVariants() bool {
+	return r.requestSchemaVariants
+}
+
+// GetUnboundMethodBindings returns the strategy generating the bindings of
+// the RPC methods that have no HttpRule annotation, "grpc" or "aip".
+func (r *Registry) GetUnboundMethodBindings() string {
+	if r.unboundMethodBindings == "" {
+		return "grpc"
+	}
+	return r.unboundMethodBindings
+}
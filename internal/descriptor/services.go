@@ -3,6 +3,7 @@ package descriptor
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/httprule"
@@ -20,7 +21,7 @@ func (r *Registry) loadServices(file *File) error {
 		grpclog.Infof("Loading services from %s", file.GetName())
 	}
 	var svcs []*Service
-	for _, sd := range file.GetService() {
+	for si, sd := range file.GetService() {
 		if grpclog.V(2) {
 			grpclog.Infof("Registering %s", sd.GetName())
 		}
@@ -29,14 +30,18 @@ func (r *Registry) loadServices(file *File) error {
 			ServiceDescriptorProto: sd,
 			ForcePrefixedName:      r.standalone,
 		}
-		for _, md := range sd.GetMethod() {
+		for mi, md := range sd.GetMethod() {
 			if grpclog.V(2) {
 				grpclog.Infof("Processing %s.%s", sd.GetName(), md.GetName())
 			}
+			path := []int32{fileServiceField, int32(si), serviceMethodField, int32(mi)}
 			opts, err := extractAPIOptions(md)
 			if err != nil {
 				grpclog.Errorf("Failed to extract HttpRule from %s.%s: %v", svc.GetName(), md.GetName(), err)
-				return err
+				if err := r.lintMethod(file, path, "invalid-method", err); err != nil {
+					return err
+				}
+				continue
 			}
 			optsList := r.LookupExternalHTTPRules((&Method{Service: svc, MethodDescriptorProto: md}).FQMN())
 			if opts != nil {
@@ -44,13 +49,22 @@ func (r *Registry) loadServices(file *File) error {
 			}
 			if len(optsList) == 0 {
 				if r.generateUnboundMethods {
-					defaultOpts, err := defaultAPIOptions(svc, md)
+					var defaultOpts *options.HttpRule
+					if r.unboundMethodBindings == "aip" {
+						defaultOpts, err = r.aipAPIOptions(svc, md)
+					}
+					if defaultOpts == nil && err == nil {
+						defaultOpts, err = defaultAPIOptions(svc, md)
+					}
 					if err != nil {
 						grpclog.Errorf("Failed to generate default HttpRule from %s.%s: %v", svc.GetName(), md.GetName(), err)
 						return err
 					}
 					optsList = append(optsList, defaultOpts)
 				} else {
+					if r.lintOnly {
+						r.addLintProblem(file, path, LintSeverityInfo, "unbound-method", fmt.Sprintf("%s has no HttpRule and is not exposed by the gateway", strings.TrimPrefix((&Method{Service: svc, MethodDescriptorProto: md}).FQMN(), ".")))
+					}
 					if grpclog.V(1) {
 						logFn := grpclog.Infof
 						if r.warnOnUnboundMethods {
@@ -60,9 +74,12 @@ func (r *Registry) loadServices(file *File) error {
 					}
 				}
 			}
-			meth, err := r.newMethod(svc, md, optsList)
+			meth, err := r.newMethod(svc, md, path, optsList)
 			if err != nil {
-				return err
+				if err := r.lintMethod(file, path, "invalid-method", err); err != nil {
+					return err
+				}
+				continue
 			}
 			svc.Methods = append(svc.Methods, meth)
 			r.meths[meth.FQMN()] = meth
@@ -79,7 +96,9 @@ func (r *Registry) loadServices(file *File) error {
 	return nil
 }
 
-func (r *Registry) newMethod(svc *Service, md *descriptorpb.MethodDescriptorProto, optsList []*options.HttpRule) (*Method, error) {
+// newMethod returns the method "md" of "svc", bound by the rules of
+// "optsList". "path" is the source path of "md" within its file.
+func (r *Registry) newMethod(svc *Service, md *descriptorpb.MethodDescriptorProto, path []int32, optsList []*options.HttpRule) (*Method, error) {
 	requestType, err := r.LookupMsg(svc.File.GetPackage(), md.GetInputType())
 	if err != nil {
 		return nil, err
@@ -105,7 +124,7 @@ func (r *Registry) newMethod(svc *Service, md *descriptorpb.MethodDescriptorProt
 			httpMethod = "GET"
 			pathTemplate = opts.GetGet()
 			if opts.Body != "" {
-				return nil, fmt.Errorf("must not set request body when http method is GET: %s", md.GetName())
+				return nil, &ruleError{rule: "get-with-body", field: httpRuleBodyField, err: fmt.Errorf("must not set request body when http method is GET: %s", md.GetName())}
 			}
 
 		case opts.GetPut() != "":
@@ -120,7 +139,7 @@ func (r *Registry) newMethod(svc *Service, md *descriptorpb.MethodDescriptorProt
 			httpMethod = "DELETE"
 			pathTemplate = opts.GetDelete()
 			if opts.Body != "" && !r.allowDeleteBody {
-				return nil, fmt.Errorf("must not set request body when http method is DELETE except allow_delete_body option is true: %s", md.GetName())
+				return nil, &ruleError{rule: "delete-with-body", field: httpRuleBodyField, err: fmt.Errorf("must not set request body when http method is DELETE except allow_delete_body option is true: %s", md.GetName())}
 			}
 
 		case opts.GetPatch() != "":
@@ -139,14 +158,15 @@ func (r *Registry) newMethod(svc *Service, md *descriptorpb.MethodDescriptorProt
 			return nil, nil
 		}
 
+		patternField := httpRulePatternField(opts)
 		parsed, err := httprule.Parse(pathTemplate)
 		if err != nil {
-			return nil, err
+			return nil, &ruleError{rule: "invalid-template", field: patternField, err: err}
 		}
 		tmpl := parsed.Compile()
 
 		if md.GetClientStreaming() && len(tmpl.Fields) > 0 {
-			return nil, errors.New("cannot use path parameter in client streaming")
+			return nil, &ruleError{rule: "client-streaming-path-param", field: patternField, err: errors.New("cannot use path parameter in client streaming")}
 		}
 
 		b := &Binding{
@@ -159,7 +179,7 @@ func (r *Registry) newMethod(svc *Service, md *descriptorpb.MethodDescriptorProt
 		for _, f := range tmpl.Fields {
 			param, err := r.newParam(meth, f)
 			if err != nil {
-				return nil, err
+				return nil, &ruleError{rule: "invalid-path-param", field: patternField, err: err}
 			}
 			b.PathParams = append(b.PathParams, param)
 		}
@@ -168,42 +188,63 @@ func (r *Registry) newMethod(svc *Service, md *descriptorpb.MethodDescriptorProt
 
 		b.Body, err = r.newBody(meth, opts.Body)
 		if err != nil {
-			return nil, err
+			return nil, &ruleError{rule: "invalid-body", field: httpRuleBodyField, err: err}
 		}
 
 		b.ResponseBody, err = r.newResponse(meth, opts.ResponseBody)
 		if err != nil {
-			return nil, err
+			return nil, &ruleError{rule: "invalid-response-body", field: httpRuleResponseBodyField, err: err}
 		}
 
 		return b, nil
 	}
 
-	applyOpts := func(opts *options.HttpRule) error {
+	// applyOpts adds the bindings of "opts", whose source path is
+	// "rulePath", or nil if it does not come from the annotations of the
+	// method.
+	applyOpts := func(opts *options.HttpRule, rulePath []int32) error {
 		b, err := newBinding(opts, len(meth.Bindings))
 		if err != nil {
-			return err
-		}
-
-		if b != nil {
+			if err := r.lintBinding(svc.File, path, rulePath, err); err != nil {
+				return err
+			}
+		} else if b != nil {
+			r.setBindingSourcePath(b, opts, rulePath)
 			meth.Bindings = append(meth.Bindings, b)
 		}
-		for _, additional := range opts.GetAdditionalBindings() {
+		for i, additional := range opts.GetAdditionalBindings() {
+			var additionalPath []int32
+			if rulePath != nil {
+				additionalPath = append(slices.Clip(rulePath), httpRuleAdditionalBindingsField, int32(i))
+			}
 			if len(additional.AdditionalBindings) > 0 {
-				return fmt.Errorf("additional_binding in additional_binding not allowed: %s.%s", svc.GetName(), meth.GetName())
+				err := &ruleError{rule: "nested-additional-binding", field: httpRuleAdditionalBindingsField, err: fmt.Errorf("additional_binding in additional_binding not allowed: %s.%s", svc.GetName(), meth.GetName())}
+				if err := r.lintBinding(svc.File, path, additionalPath, err); err != nil {
+					return err
+				}
+				continue
 			}
 			b, err := newBinding(additional, len(meth.Bindings))
 			if err != nil {
-				return err
+				if err := r.lintBinding(svc.File, path, additionalPath, err); err != nil {
+					return err
+				}
+				continue
 			}
+			r.setBindingSourcePath(b, additional, additionalPath)
 			meth.Bindings = append(meth.Bindings, b)
 		}
 
 		return nil
 	}
 
+	annotation, _ := extractAPIOptions(md)
 	for _, opts := range optsList {
-		if err := applyOpts(opts); err != nil {
+		var rulePath []int32
+		if opts == annotation {
+			rulePath = append(slices.Clip(path), methodOptionsField, int32(options.E_Http.TypeDescriptor().Number()))
+		}
+		if err := applyOpts(opts, rulePath); err != nil {
 			return nil, err
 		}
 	}
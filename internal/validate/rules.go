@@ -0,0 +1,277 @@
+// Package validate decodes the buf.validate (protovalidate) rules of fields,
+// for the gateway runtime to enforce them and the OpenAPI generators to
+// document them.
+//
+// The rules are read from the encoded field options, so that neither the
+// generators nor the runtime depend on the Go package of buf.validate.
+package validate
+
+import (
+	"math"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// fieldExtension is the number of the buf.validate.field extension of
+// google.protobuf.FieldOptions.
+const fieldExtension = 1159
+
+// The values of buf.validate.Ignore.
+const (
+	ignoreIfZeroValue    = 1
+	ignoreIfDefaultValue = 2 // deprecated, handled as ignoreIfZeroValue
+	ignoreAlways         = 3
+)
+
+// Rules are the buf.validate rules of a field the gateway supports.
+type Rules struct {
+	// Required requires the field to be set, or to be non-empty for fields
+	// without presence.
+	Required bool
+	// IgnoreIfZeroValue skips the rules of fields which are unset or set to
+	// their zero value, such as empty strings.
+	IgnoreIfZeroValue bool
+
+	// MinLen and MaxLen bound the length of strings, in characters, and of
+	// bytes, in bytes.
+	MinLen, MaxLen *uint64
+	// Pattern is a RE2 regular expression strings must match.
+	Pattern                  string
+	Prefix, Suffix, Contains string
+	// In lists the values strings must be one of.
+	In []string
+
+	// GT, GTE, LT and LTE bound numbers.
+	GT, GTE, LT, LTE *float64
+
+	// DefinedOnly requires enums to be one of their defined values.
+	DefinedOnly bool
+
+	// MinItems and MaxItems bound the number of items of repeated fields,
+	// UniqueItems requires them to be unique, and Items are the rules of the
+	// items.
+	MinItems, MaxItems *uint64
+	UniqueItems        bool
+	Items              *Rules
+}
+
+// IsZero reports whether r has no rules.
+func (r *Rules) IsZero() bool {
+	return r == nil || !r.Required && r.MinLen == nil && r.MaxLen == nil &&
+		r.Pattern == "" && r.Prefix == "" && r.Suffix == "" && r.Contains == "" && len(r.In) == 0 &&
+		r.GT == nil && r.GTE == nil && r.LT == nil && r.LTE == nil && !r.DefinedOnly &&
+		r.MinItems == nil && r.MaxItems == nil && !r.UniqueItems && r.Items.IsZero()
+}
+
+// FieldRules returns the buf.validate rules of the field with options
+// "opts", or nil if it has none.
+func FieldRules(opts *descriptorpb.FieldOptions) *Rules {
+	if opts == nil {
+		return nil
+	}
+	// Marshal the options rather than reading their unknown fields, as the
+	// extension is known to binaries linking the buf.validate package.
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(opts)
+	if err != nil {
+		return nil
+	}
+	var (
+		rules   *Rules
+		ignored bool
+	)
+	forEachField(b, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) {
+		if num != fieldExtension || typ != protowire.BytesType {
+			return
+		}
+		if rules == nil {
+			rules = &Rules{}
+		}
+		ignored = rules.decodeFieldRules(v) || ignored
+	})
+	if ignored || rules.IsZero() {
+		return nil
+	}
+	if rules.IgnoreIfZeroValue {
+		// Unset fields are ignored, so they cannot be required.
+		rules.Required = false
+	}
+	return rules
+}
+
+// decodeFieldRules decodes a buf.validate.FieldRules message into r, and
+// reports whether its rules are always ignored.
+func (r *Rules) decodeFieldRules(b []byte) (ignored bool) {
+	forEachField(b, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) {
+		switch {
+		case num == 25 && typ == protowire.VarintType:
+			r.Required = x != 0
+		case num == 27 && typ == protowire.VarintType:
+			switch x {
+			case ignoreIfZeroValue, ignoreIfDefaultValue:
+				r.IgnoreIfZeroValue = true
+			case ignoreAlways:
+				ignored = true
+			}
+		case num >= 1 && num <= 12 && typ == protowire.BytesType:
+			r.decodeNumberRules(num, v)
+		case num == 14 && typ == protowire.BytesType:
+			r.decodeStringRules(v)
+		case num == 15 && typ == protowire.BytesType:
+			r.decodeBytesRules(v)
+		case num == 16 && typ == protowire.BytesType:
+			r.decodeEnumRules(v)
+		case num == 18 && typ == protowire.BytesType:
+			r.decodeRepeatedRules(v)
+		}
+	})
+	return ignored
+}
+
+func (r *Rules) decodeStringRules(b []byte) {
+	forEachField(b, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) {
+		switch num {
+		case 19: // len
+			r.MinLen, r.MaxLen = &x, &x
+		case 2: // min_len
+			r.MinLen = &x
+		case 3: // max_len
+			r.MaxLen = &x
+		case 6:
+			r.Pattern = string(v)
+		case 7:
+			r.Prefix = string(v)
+		case 8:
+			r.Suffix = string(v)
+		case 9:
+			r.Contains = string(v)
+		case 10:
+			r.In = append(r.In, string(v))
+		}
+	})
+}
+
+func (r *Rules) decodeBytesRules(b []byte) {
+	forEachField(b, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) {
+		switch num {
+		case 13: // len
+			r.MinLen, r.MaxLen = &x, &x
+		case 2: // min_len
+			r.MinLen = &x
+		case 3: // max_len
+			r.MaxLen = &x
+		}
+	})
+}
+
+func (r *Rules) decodeEnumRules(b []byte) {
+	forEachField(b, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) {
+		if num == 2 {
+			r.DefinedOnly = x != 0
+		}
+	})
+}
+
+func (r *Rules) decodeRepeatedRules(b []byte) {
+	forEachField(b, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) {
+		switch num {
+		case 1:
+			r.MinItems = &x
+		case 2:
+			r.MaxItems = &x
+		case 3:
+			r.UniqueItems = x != 0
+		case 4:
+			if typ != protowire.BytesType {
+				return
+			}
+			if r.Items == nil {
+				r.Items = &Rules{}
+			}
+			if r.Items.decodeFieldRules(v) {
+				r.Items = nil
+			}
+		}
+	})
+}
+
+// decodeNumberRules decodes the rules of the numeric type "kind", the number
+// of its field in buf.validate.FieldRules, such as 3 for int32.
+func (r *Rules) decodeNumberRules(kind protowire.Number, b []byte) {
+	forEachField(b, func(num protowire.Number, typ protowire.Type, v []byte, x uint64) {
+		var bound *float64
+		switch num {
+		case 2:
+			bound = new(float64)
+			r.LT = bound
+		case 3:
+			bound = new(float64)
+			r.LTE = bound
+		case 4:
+			bound = new(float64)
+			r.GT = bound
+		case 5:
+			bound = new(float64)
+			r.GTE = bound
+		default:
+			return
+		}
+		*bound = numberValue(kind, x)
+	})
+}
+
+// numberValue converts the raw value "x" of the numeric type "kind" to a
+// float64.
+func numberValue(kind protowire.Number, x uint64) float64 {
+	switch kind {
+	case 1: // float
+		return float64(math.Float32frombits(uint32(x)))
+	case 2: // double
+		return math.Float64frombits(x)
+	case 3, 11: // int32, sfixed32
+		return float64(int32(x))
+	case 4, 12: // int64, sfixed64
+		return float64(int64(x))
+	case 7, 8: // sint32, sint64
+		return float64(protowire.DecodeZigZag(x))
+	default: // uint32, uint64, fixed32, fixed64
+		return float64(x)
+	}
+}
+
+// forEachField calls f with the fields encoded in "b": the contents of
+// length-delimited fields as "v", and the value of the others as "x".
+// Malformed input stops the iteration.
+func forEachField(b []byte, f func(num protowire.Number, typ protowire.Type, v []byte, x uint64)) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return
+		}
+		b = b[n:]
+		var (
+			v []byte
+			x uint64
+		)
+		switch typ {
+		case protowire.VarintType:
+			x, n = protowire.ConsumeVarint(b)
+		case protowire.Fixed32Type:
+			var x32 uint32
+			x32, n = protowire.ConsumeFixed32(b)
+			x = uint64(x32)
+		case protowire.Fixed64Type:
+			x, n = protowire.ConsumeFixed64(b)
+		case protowire.BytesType:
+			v, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return
+		}
+		b = b[n:]
+		f(num, typ, v, x)
+	}
+}
@@ -0,0 +1,132 @@
+package validate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// message encodes the fields appended by "fields" as a length-delimited
+// field "num".
+func message(num protowire.Number, fields ...func([]byte) []byte) func([]byte) []byte {
+	return func(b []byte) []byte {
+		var m []byte
+		for _, f := range fields {
+			m = f(m)
+		}
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		return protowire.AppendBytes(b, m)
+	}
+}
+
+func varint(num protowire.Number, v uint64) func([]byte) []byte {
+	return func(b []byte) []byte {
+		b = protowire.AppendTag(b, num, protowire.VarintType)
+		return protowire.AppendVarint(b, v)
+	}
+}
+
+func fixed64(num protowire.Number, v uint64) func([]byte) []byte {
+	return func(b []byte) []byte {
+		b = protowire.AppendTag(b, num, protowire.Fixed64Type)
+		return protowire.AppendFixed64(b, v)
+	}
+}
+
+func str(num protowire.Number, v string) func([]byte) []byte {
+	return func(b []byte) []byte {
+		b = protowire.AppendTag(b, num, protowire.BytesType)
+		return protowire.AppendString(b, v)
+	}
+}
+
+// fieldOptions returns field options with a buf.validate.field extension
+// holding "fields".
+func fieldOptions(fields ...func([]byte) []byte) *descriptorpb.FieldOptions {
+	opts := &descriptorpb.FieldOptions{}
+	opts.ProtoReflect().SetUnknown(message(fieldExtension, fields...)(nil))
+	return opts
+}
+
+func uint64Ptr(v uint64) *uint64    { return &v }
+func float64Ptr(v float64) *float64 { return &v }
+
+func TestFieldRules(t *testing.T) {
+	for _, spec := range []struct {
+		name string
+		opts *descriptorpb.FieldOptions
+		want *Rules
+	}{
+		{
+			name: "no options",
+		},
+		{
+			name: "no rules",
+			opts: &descriptorpb.FieldOptions{Deprecated: func() *bool { b := true; return &b }()},
+		},
+		{
+			name: "required",
+			opts: fieldOptions(varint(25, 1)),
+			want: &Rules{Required: true},
+		},
+		{
+			name: "ignore if zero value",
+			opts: fieldOptions(varint(25, 1), varint(27, 1), message(14, varint(2, 1))),
+			want: &Rules{IgnoreIfZeroValue: true, MinLen: uint64Ptr(1)},
+		},
+		{
+			name: "ignore always",
+			opts: fieldOptions(varint(27, 3), message(14, varint(2, 1))),
+		},
+		{
+			name: "string",
+			opts: fieldOptions(message(14, varint(2, 1), varint(3, 10), str(6, "^[a-z]+$"), str(7, "a"), str(10, "ab"), str(10, "cd"))),
+			want: &Rules{MinLen: uint64Ptr(1), MaxLen: uint64Ptr(10), Pattern: "^[a-z]+$", Prefix: "a", In: []string{"ab", "cd"}},
+		},
+		{
+			name: "string length",
+			opts: fieldOptions(message(14, varint(19, 4))),
+			want: &Rules{MinLen: uint64Ptr(4), MaxLen: uint64Ptr(4)},
+		},
+		{
+			name: "int32",
+			opts: fieldOptions(message(3, varint(5, uint64(0xffffffffffffff9c)), varint(2, 100))),
+			want: &Rules{GTE: float64Ptr(-100), LT: float64Ptr(100)},
+		},
+		{
+			name: "sint64",
+			opts: fieldOptions(message(8, varint(4, protowire.EncodeZigZag(-5)))),
+			want: &Rules{GT: float64Ptr(-5)},
+		},
+		{
+			name: "double",
+			opts: fieldOptions(message(2, fixed64(3, math.Float64bits(0.5)))),
+			want: &Rules{LTE: float64Ptr(0.5)},
+		},
+		{
+			name: "enum",
+			opts: fieldOptions(message(16, varint(2, 1))),
+			want: &Rules{DefinedOnly: true},
+		},
+		{
+			name: "repeated",
+			opts: fieldOptions(message(18, varint(1, 1), varint(2, 3), varint(3, 1), message(4, message(14, varint(2, 2))))),
+			want: &Rules{MinItems: uint64Ptr(1), MaxItems: uint64Ptr(3), UniqueItems: true, Items: &Rules{MinLen: uint64Ptr(2)}},
+		},
+		{
+			name: "repeated ignored items",
+			opts: fieldOptions(message(18, varint(1, 1), message(4, varint(27, 3), message(14, varint(2, 2))))),
+			want: &Rules{MinItems: uint64Ptr(1)},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			got := FieldRules(spec.opts)
+			if diff := cmp.Diff(spec.want, got); diff != "" {
+				t.Errorf("FieldRules() differs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
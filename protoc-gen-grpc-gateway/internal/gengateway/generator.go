@@ -72,6 +72,18 @@ func New(reg *descriptor.Registry, useRequestContext bool, registerFuncSuffix st
 }
 
 func (g *generator) Generate(targets []*descriptor.File) ([]*descriptor.ResponseFile, error) {
+	var mainDir string
+	if g.reg != nil {
+		mainDir = g.reg.GetGatewayMain()
+	}
+	var mp mainParam
+	if mainDir != "" {
+		if g.standalone {
+			return nil, errors.New("generate_gateway_main cannot be used with standalone")
+		}
+		mp = newMainParam(targets, g.registerFuncSuffix)
+	}
+
 	var files []*descriptor.ResponseFile
 	for _, file := range targets {
 		if grpclog.V(1) {
@@ -100,7 +112,57 @@ func (g *generator) Generate(targets []*descriptor.File) ([]*descriptor.Response
 				Content: proto.String(string(formatted)),
 			},
 		})
+
+		if g.reg == nil || !g.reg.GetGenerateHTTPClient() {
+			continue
+		}
+		code, err = applyClientTemplate(param{
+			File:         file,
+			Imports:      g.baseImports,
+			UseOpaqueAPI: g.useOpaqueAPI,
+		}, g.standalone)
+		if errors.Is(err, errNoTargetService) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		formatted, err = format.Source([]byte(code))
+		if err != nil {
+			grpclog.Errorf("%v: %s", err, code)
+			return nil, err
+		}
+		files = append(files, &descriptor.ResponseFile{
+			GoPkg: file.GoPkg,
+			CodeGeneratorResponse_File: &pluginpb.CodeGeneratorResponse_File{
+				Name:    proto.String(file.GeneratedFilenamePrefix + ".pb.gw.client.go"),
+				Content: proto.String(string(formatted)),
+			},
+		})
+	}
+
+	if mainDir == "" {
+		return files, nil
+	}
+	code, err := applyMainTemplate(mp)
+	if errors.Is(err, errNoTargetService) {
+		return files, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source([]byte(code))
+	if err != nil {
+		grpclog.Errorf("%v: %s", err, code)
+		return nil, err
 	}
+	files = append(files, &descriptor.ResponseFile{
+		GoPkg: descriptor.GoPackage{Name: "main"},
+		CodeGeneratorResponse_File: &pluginpb.CodeGeneratorResponse_File{
+			Name:    proto.String(path.Join(mainDir, "main.go")),
+			Content: proto.String(string(formatted)),
+		},
+	})
 	return files, nil
 }
 
@@ -1,6 +1,7 @@
 package gengateway
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/descriptor"
@@ -110,3 +111,68 @@ func testGeneratorGenerate(t *testing.T, useOpaqueAPI bool) {
 		t.Fatalf("invalid name %q, expected %q", gotName, expectedName)
 	}
 }
+
+func TestGenerator_GenerateHTTPClient(t *testing.T) {
+	reg := descriptor.NewRegistry()
+	reg.SetGenerateHTTPClient(true)
+	g := New(reg, true, "Handler", true, false, false)
+	result, err := g.Generate([]*descriptor.File{
+		crossLinkFixture(newExampleFileDescriptorWithGoPkg(&descriptor.GoPackage{
+			Path: "example.com/path/to/example",
+			Name: "example_pb",
+		}, "path/to/example")),
+	})
+	if err != nil {
+		t.Fatalf("failed to generate stubs: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("expected to generate two files, got: %d", len(result))
+	}
+	expectedName := "path/to/example.pb.gw.client.go"
+	if gotName := result[1].GetName(); gotName != expectedName {
+		t.Fatalf("invalid name %q, expected %q", gotName, expectedName)
+	}
+}
+
+func TestGenerator_GenerateGatewayMain(t *testing.T) {
+	reg := descriptor.NewRegistry()
+	reg.SetGatewayMain("cmd/gateway")
+	g := New(reg, true, "Handler", true, false, false)
+	result, err := g.Generate([]*descriptor.File{
+		crossLinkFixture(newExampleFileDescriptorWithGoPkg(&descriptor.GoPackage{
+			Path: "example.com/path/to/example",
+			Name: "example_pb",
+		}, "path/to/example")),
+		crossLinkFixture(newExampleFileDescriptorWithGoPkg(&descriptor.GoPackage{
+			Path: "example.com/path/to/other",
+			Name: "example_pb",
+		}, "path/to/other")),
+	})
+	if err != nil {
+		t.Fatalf("failed to generate stubs: %v", err)
+	}
+	if len(result) != 3 {
+		t.Fatalf("expected to generate three files, got: %d", len(result))
+	}
+	main := result[2]
+	if want := "cmd/gateway/main.go"; main.GetName() != want {
+		t.Fatalf("invalid name %q, expected %q", main.GetName(), want)
+	}
+	for _, want := range []string{
+		"package main",
+		`"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/gatewaycmd"`,
+		`"example.com/path/to/example"`,
+		`example_pb_0 "example.com/path/to/other"`,
+		`gatewaycmd.Service{Name: "example.ExampleService", Register: example_pb.RegisterExampleServiceHandlerFromEndpoint}`,
+		`gatewaycmd.Service{Name: "example.ExampleService", Register: example_pb_0.RegisterExampleServiceHandlerFromEndpoint}`,
+	} {
+		if !strings.Contains(main.GetContent(), want) {
+			t.Errorf("main.go does not contain %s:\n%s", want, main.GetContent())
+		}
+	}
+
+	g = New(reg, true, "Handler", true, true, false)
+	if _, err := g.Generate(nil); err == nil {
+		t.Errorf("g.Generate() succeeded in standalone mode; want an error")
+	}
+}
@@ -0,0 +1,255 @@
+package gengateway
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/casing"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/descriptor"
+)
+
+type clientParam struct {
+	*descriptor.File
+	Imports  []descriptor.GoPackage
+	Services []clientService
+}
+
+type clientService struct {
+	*descriptor.Service
+	// Name is the name of the generated client interface, and StructName
+	// the name of its implementation.
+	Name       string
+	StructName string
+	Methods    []clientMethod
+}
+
+// clientMethod is a method called by a generated HTTP client, through its
+// first binding.
+type clientMethod struct {
+	*descriptor.Method
+	Binding *descriptor.Binding
+	// Name is the Go name of the method.
+	Name         string
+	RequestType  string
+	ResponseType string
+	// BodyFieldPath is the value of runtime.HTTPCall.BodyFieldPath, and
+	// BodyExpr the expression of the body within the request "in".
+	BodyFieldPath string
+	BodyExpr      string
+	// ResponseExpr is the expression receiving the response body within the
+	// response "out".
+	ResponseExpr string
+	// StreamName is the name of the generated stream interface of server
+	// streaming methods, and StreamStructName the name of its
+	// implementation.
+	StreamName       string
+	StreamStructName string
+}
+
+// newClientMethod returns the clientMethod calling m through b, or false if
+// the method cannot be called by an HTTP client.
+func newClientMethod(svc *descriptor.Service, m *descriptor.Method, b *descriptor.Binding, useOpaqueAPI bool) (clientMethod, bool, error) {
+	if m.GetClientStreaming() {
+		return clientMethod{}, false, nil
+	}
+	pkg := svc.File.GoPkg.Path
+	cm := clientMethod{
+		Method:       m,
+		Binding:      b,
+		Name:         casing.Camel(m.GetName()),
+		RequestType:  m.RequestType.GoType(pkg),
+		ResponseType: m.ResponseType.GoType(pkg),
+		ResponseExpr: "out",
+	}
+	if b.Body != nil {
+		cm.BodyFieldPath = "*"
+		cm.BodyExpr = "in"
+		if len(b.Body.FieldPath) > 0 {
+			cm.BodyFieldPath = b.Body.FieldPath.String()
+			for _, c := range b.Body.FieldPath {
+				cm.BodyExpr += ".Get" + casing.Camel(c.Name) + "()"
+			}
+		}
+	}
+	if b.ResponseBody != nil && len(b.ResponseBody.FieldPath) > 0 {
+		fp := b.ResponseBody.FieldPath
+		if useOpaqueAPI || len(fp) > 1 || (fp[0].Target.OneofIndex != nil && !fp[0].Target.GetProto3Optional()) {
+			return clientMethod{}, false, fmt.Errorf("%s: HTTP clients only support a response_body naming a top-level field which is not in a oneof, with the open struct API", m.FQMN())
+		}
+		cm.ResponseExpr = "&out." + fp[0].AssignableExpr()
+	}
+	if m.GetServerStreaming() {
+		cm.StreamName = svc.InstanceName() + "_" + cm.Name + "HTTPClient"
+		cm.StreamStructName = lowerFirst(svc.InstanceName()) + cm.Name + "HTTPClient"
+	}
+	return cm, true, nil
+}
+
+func lowerFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[n:]
+}
+
+// applyClientTemplate generates the HTTP clients of the services of p, or
+// returns errNoTargetService if no method can be called over HTTP.
+func applyClientTemplate(p param, standalone bool) (string, error) {
+	cp := clientParam{File: p.File}
+	imports := map[string]bool{p.GoPkg.Path: true}
+	for _, pkg := range p.Imports {
+		switch pkg.Path {
+		case "context", "net/http", "github.com/grpc-ecosystem/grpc-gateway/v2/runtime":
+			imports[pkg.Path] = true
+			cp.Imports = append(cp.Imports, pkg)
+		}
+	}
+	addImport := func(pkg descriptor.GoPackage) {
+		if !imports[pkg.Path] {
+			imports[pkg.Path] = true
+			cp.Imports = append(cp.Imports, pkg)
+		}
+	}
+	if standalone {
+		addImport(p.GoPkg)
+	}
+	for _, svc := range p.Services {
+		cs := clientService{
+			Service:    svc,
+			Name:       svc.InstanceName() + "HTTPClient",
+			StructName: lowerFirst(svc.InstanceName()) + "HTTPClient",
+		}
+		for _, m := range svc.Methods {
+			if len(m.Bindings) == 0 {
+				continue
+			}
+			cm, ok, err := newClientMethod(svc, m, m.Bindings[0], p.UseOpaqueAPI)
+			if err != nil {
+				return "", err
+			}
+			if !ok {
+				continue
+			}
+			addImport(m.RequestType.File.GoPkg)
+			addImport(m.ResponseType.File.GoPkg)
+			cs.Methods = append(cs.Methods, cm)
+		}
+		if len(cs.Methods) > 0 {
+			cp.Services = append(cp.Services, cs)
+		}
+	}
+	if len(cp.Services) == 0 {
+		return "", errNoTargetService
+	}
+
+	w := bytes.NewBuffer(nil)
+	if err := clientTemplate.Execute(w, cp); err != nil {
+		return "", err
+	}
+	return w.String(), nil
+}
+
+var clientTemplate = template.Must(template.New("client").Funcs(template.FuncMap{
+	"toHTTPMethod": func(method string) string {
+		if expr, ok := httpMethods[method]; ok {
+			return expr
+		}
+		return strconv.Quote(method)
+	},
+	"quote": strconv.Quote,
+}).Parse(`
+// Code generated by protoc-gen-grpc-gateway. DO NOT EDIT.
+// source: {{ .GetName }}
+
+package {{ .GoPkg.Name }}
+
+import (
+	{{ range $i := .Imports }}{{ if $i.Standard }}{{ $i | printf "%s\n" }}{{ end }}{{ end }}
+
+	{{ range $i := .Imports }}{{ if not $i.Standard }}{{ $i | printf "%s\n" }}{{ end }}{{ end }}
+)
+{{ range $svc := .Services }}
+// {{ $svc.Name }} is the client API for {{ $svc.GetName }} calling the methods
+// through their google.api.http bindings, over a runtime.HTTPClient.
+type {{ $svc.Name }} interface {
+	{{- range $m := $svc.Methods }}
+	{{- if $m.GetServerStreaming }}
+	{{ $m.Name }}(ctx context.Context, in *{{ $m.RequestType }}) ({{ $m.StreamName }}, error)
+	{{- else }}
+	{{ $m.Name }}(ctx context.Context, in *{{ $m.RequestType }}) (*{{ $m.ResponseType }}, error)
+	{{- end }}
+	{{- end }}
+}
+
+type {{ $svc.StructName }} struct {
+	client *runtime.HTTPClient
+}
+
+// New{{ $svc.Name }} returns a client of {{ $svc.GetName }} sending its requests with "client".
+func New{{ $svc.Name }}(client *runtime.HTTPClient) {{ $svc.Name }} {
+	return &{{ $svc.StructName }}{client: client}
+}
+{{ range $m := $svc.Methods }}
+{{- if $m.GetServerStreaming }}
+func (c *{{ $svc.StructName }}) {{ $m.Name }}(ctx context.Context, in *{{ $m.RequestType }}) ({{ $m.StreamName }}, error) {
+	stream, err := c.client.NewStream(ctx, &runtime.HTTPCall{
+		Method:        {{ $m.Binding.HTTPMethod | toHTTPMethod }},
+		PathTemplate:  {{ $m.Binding.PathTmpl.Template | quote }},
+		Request:       in,
+		{{- if $m.BodyFieldPath }}
+		BodyFieldPath: {{ $m.BodyFieldPath | quote }},
+		Body:          {{ $m.BodyExpr }},
+		{{- end }}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &{{ $m.StreamStructName }}{stream}, nil
+}
+
+// {{ $m.StreamName }} reads the messages of a {{ $m.Name }} stream.
+type {{ $m.StreamName }} interface {
+	// Recv returns the next message, io.EOF at the end of the stream, or the
+	// error which ended it.
+	Recv() (*{{ $m.ResponseType }}, error)
+	// Header returns the headers of the response.
+	Header() http.Header
+	// Close closes the stream, and cancels the call if it has not ended.
+	Close() error
+}
+
+type {{ $m.StreamStructName }} struct {
+	*runtime.HTTPClientStream
+}
+
+func (x *{{ $m.StreamStructName }}) Recv() (*{{ $m.ResponseType }}, error) {
+	out := new({{ $m.ResponseType }})
+	if err := x.RecvMsg({{ $m.ResponseExpr }}); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+{{ else }}
+func (c *{{ $svc.StructName }}) {{ $m.Name }}(ctx context.Context, in *{{ $m.RequestType }}) (*{{ $m.ResponseType }}, error) {
+	out := new({{ $m.ResponseType }})
+	err := c.client.Invoke(ctx, &runtime.HTTPCall{
+		Method:        {{ $m.Binding.HTTPMethod | toHTTPMethod }},
+		PathTemplate:  {{ $m.Binding.PathTmpl.Template | quote }},
+		Request:       in,
+		{{- if $m.BodyFieldPath }}
+		BodyFieldPath: {{ $m.BodyFieldPath | quote }},
+		Body:          {{ $m.BodyExpr }},
+		{{- end }}
+		Response:      {{ $m.ResponseExpr }},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+{{ end }}
+{{- end }}
+{{- end }}
+`))
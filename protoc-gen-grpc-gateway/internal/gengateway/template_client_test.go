@@ -0,0 +1,185 @@
+package gengateway
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/descriptor"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/httprule"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func newClientTemplateFixture(responseBody *descriptor.Body) *descriptor.File {
+	msgdesc := &descriptorpb.DescriptorProto{
+		Name: proto.String("ExampleMessage"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			{
+				Name:     proto.String("nested"),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+				TypeName: proto.String("NestedMessage"),
+				Number:   proto.Int32(1),
+			},
+		},
+	}
+	nesteddesc := &descriptorpb.DescriptorProto{
+		Name: proto.String("NestedMessage"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			{
+				Name:   proto.String("int32"),
+				Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:   descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(),
+				Number: proto.Int32(1),
+			},
+			{
+				Name:   proto.String("bool"),
+				Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				Type:   descriptorpb.FieldDescriptorProto_TYPE_BOOL.Enum(),
+				Number: proto.Int32(2),
+			},
+		},
+	}
+	echo := &descriptorpb.MethodDescriptorProto{
+		Name:       proto.String("Echo"),
+		InputType:  proto.String("ExampleMessage"),
+		OutputType: proto.String("ExampleMessage"),
+	}
+	watch := &descriptorpb.MethodDescriptorProto{
+		Name:            proto.String("Watch"),
+		InputType:       proto.String("ExampleMessage"),
+		OutputType:      proto.String("ExampleMessage"),
+		ServerStreaming: proto.Bool(true),
+	}
+	upload := &descriptorpb.MethodDescriptorProto{
+		Name:            proto.String("Upload"),
+		InputType:       proto.String("ExampleMessage"),
+		OutputType:      proto.String("ExampleMessage"),
+		ClientStreaming: proto.Bool(true),
+	}
+	svc := &descriptorpb.ServiceDescriptorProto{
+		Name:   proto.String("ExampleService"),
+		Method: []*descriptorpb.MethodDescriptorProto{echo, watch, upload},
+	}
+
+	msg := &descriptor.Message{DescriptorProto: msgdesc}
+	nested := &descriptor.Message{DescriptorProto: nesteddesc}
+	nestedField := &descriptor.Field{Message: msg, FieldDescriptorProto: msg.GetField()[0]}
+	intField := &descriptor.Field{Message: nested, FieldDescriptorProto: nested.GetField()[0]}
+	boolField := &descriptor.Field{Message: nested, FieldDescriptorProto: nested.GetField()[1]}
+	if responseBody != nil && len(responseBody.FieldPath) > 0 {
+		responseBody.FieldPath[0].Target = nestedField
+	}
+
+	file := &descriptor.File{
+		FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+			Name:        proto.String("example.proto"),
+			Package:     proto.String("example"),
+			MessageType: []*descriptorpb.DescriptorProto{msgdesc, nesteddesc},
+			Service:     []*descriptorpb.ServiceDescriptorProto{svc},
+		},
+		GoPkg: descriptor.GoPackage{
+			Path: "example.com/path/to/example/example.pb",
+			Name: "example_pb",
+		},
+		Messages: []*descriptor.Message{msg, nested},
+		Services: []*descriptor.Service{
+			{
+				ServiceDescriptorProto: svc,
+				Methods: []*descriptor.Method{
+					{
+						MethodDescriptorProto: echo,
+						RequestType:           msg,
+						ResponseType:          msg,
+						Bindings: []*descriptor.Binding{
+							{
+								HTTPMethod: "POST",
+								PathTmpl:   httprule.Template{Template: "/v1/{nested.int32}"},
+								PathParams: []descriptor.Parameter{
+									{
+										FieldPath: descriptor.FieldPath{
+											{Name: "nested", Target: nestedField},
+											{Name: "int32", Target: intField},
+										},
+										Target: intField,
+									},
+								},
+								Body: &descriptor.Body{
+									FieldPath: descriptor.FieldPath{
+										{Name: "nested", Target: nestedField},
+										{Name: "bool", Target: boolField},
+									},
+								},
+							},
+						},
+					},
+					{
+						MethodDescriptorProto: watch,
+						RequestType:           msg,
+						ResponseType:          msg,
+						Bindings: []*descriptor.Binding{
+							{
+								HTTPMethod:   "GET",
+								PathTmpl:     httprule.Template{Template: "/v1/watch"},
+								ResponseBody: responseBody,
+							},
+						},
+					},
+					{
+						MethodDescriptorProto: upload,
+						RequestType:           msg,
+						ResponseType:          msg,
+						Bindings: []*descriptor.Binding{
+							{
+								HTTPMethod: "POST",
+								PathTmpl:   httprule.Template{Template: "/v1/upload"},
+								Body:       &descriptor.Body{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return crossLinkFixture(file)
+}
+
+func TestApplyClientTemplate(t *testing.T) {
+	file := newClientTemplateFixture(&descriptor.Body{
+		FieldPath: descriptor.FieldPath{{Name: "nested"}},
+	})
+	got, err := applyClientTemplate(param{File: file}, false)
+	if err != nil {
+		t.Fatalf("applyClientTemplate(%#v) failed with %v; want success", file, err)
+	}
+	if _, err := format.Source([]byte(got)); err != nil {
+		t.Fatalf("applyClientTemplate(%#v) = %s; is not valid Go: %v", file, got, err)
+	}
+	for _, want := range []string{
+		`type ExampleServiceHTTPClient interface {`,
+		`func NewExampleServiceHTTPClient(client *runtime.HTTPClient) ExampleServiceHTTPClient {`,
+		`func (c *exampleServiceHTTPClient) Echo(ctx context.Context, in *ExampleMessage) (*ExampleMessage, error) {`,
+		`PathTemplate:  "/v1/{nested.int32}",`,
+		`BodyFieldPath: "nested.bool",`,
+		`Body:          in.GetNested().GetBool(),`,
+		`func (c *exampleServiceHTTPClient) Watch(ctx context.Context, in *ExampleMessage) (ExampleService_WatchHTTPClient, error) {`,
+		`if err := x.RecvMsg(&out.Nested); err != nil {`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("applyClientTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
+	}
+	if notWant := "Upload"; strings.Contains(got, notWant) {
+		t.Errorf("applyClientTemplate(%#v) = %s; want not to contain the client streaming method %s", file, got, notWant)
+	}
+}
+
+func TestApplyClientTemplateUnsupportedResponseBody(t *testing.T) {
+	file := newClientTemplateFixture(&descriptor.Body{
+		FieldPath: descriptor.FieldPath{{Name: "nested"}},
+	})
+	if _, err := applyClientTemplate(param{File: file, UseOpaqueAPI: true}, false); err == nil {
+		t.Errorf("applyClientTemplate(%#v) succeeded with the opaque API and a response body; want failure", file)
+	}
+}
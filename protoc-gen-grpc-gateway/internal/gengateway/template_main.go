@@ -0,0 +1,108 @@
+package gengateway
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/casing"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/descriptor"
+)
+
+const gatewaycmdPkgPath = "github.com/grpc-ecosystem/grpc-gateway/v2/runtime/gatewaycmd"
+
+// mainParam is the main package of a gateway command serving the services
+// of the generated files.
+type mainParam struct {
+	Imports  []descriptor.GoPackage
+	Services []mainService
+}
+
+type mainService struct {
+	// Name is the fully-qualified name of the service, and Register the
+	// qualified name of its generated Register*FromEndpoint function.
+	Name     string
+	Register string
+}
+
+// newMainParam returns the mainParam of the services of files having
+// bindings. It must be called before applyTemplate renames the services.
+func newMainParam(files []*descriptor.File, registerFuncSuffix string) mainParam {
+	p := mainParam{
+		Imports: []descriptor.GoPackage{{Path: gatewaycmdPkgPath, Name: "gatewaycmd"}},
+	}
+	paths := map[string]string{"gatewaycmd": gatewaycmdPkgPath}
+	qualifiers := map[string]string{}
+	for _, file := range files {
+		for _, svc := range file.Services {
+			if !hasBindings(svc) {
+				continue
+			}
+			pkg := file.GoPkg
+			q, ok := qualifiers[pkg.Path]
+			if !ok {
+				name := cmp.Or(pkg.Alias, pkg.Name)
+				q = name
+				for i := 0; paths[q] != ""; i++ {
+					q = fmt.Sprintf("%s_%d", name, i)
+				}
+				paths[q] = pkg.Path
+				qualifiers[pkg.Path] = q
+				imp := descriptor.GoPackage{Path: pkg.Path, Name: pkg.Name}
+				if q != pkg.Name {
+					imp.Alias = q
+				}
+				p.Imports = append(p.Imports, imp)
+			}
+			p.Services = append(p.Services, mainService{
+				Name:     strings.TrimPrefix(svc.FQSN(), "."),
+				Register: q + ".Register" + casing.Camel(svc.GetName()) + registerFuncSuffix + "FromEndpoint",
+			})
+		}
+	}
+	return p
+}
+
+func hasBindings(svc *descriptor.Service) bool {
+	for _, m := range svc.Methods {
+		if len(m.Bindings) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// applyMainTemplate generates the main package of a gateway command, or
+// returns errNoTargetService if no service has bindings.
+func applyMainTemplate(p mainParam) (string, error) {
+	if len(p.Services) == 0 {
+		return "", errNoTargetService
+	}
+	w := bytes.NewBuffer(nil)
+	if err := mainTemplate.Execute(w, p); err != nil {
+		return "", err
+	}
+	return w.String(), nil
+}
+
+var mainTemplate = template.Must(template.New("main").Parse(`
+// Code generated by protoc-gen-grpc-gateway. DO NOT EDIT.
+
+// Command gateway is a reverse proxy translating RESTful HTTP APIs into gRPC
+// calls. See the gatewaycmd package for its configuration.
+package main
+
+import (
+	{{ range $i := .Imports }}{{ $i | printf "%s\n" }}{{ end }}
+)
+
+func main() {
+	gatewaycmd.Main(
+		{{- range $svc := .Services }}
+		gatewaycmd.Service{Name: {{ printf "%q" $svc.Name }}, Register: {{ $svc.Register }}},
+		{{- end }}
+	)
+}
+`))
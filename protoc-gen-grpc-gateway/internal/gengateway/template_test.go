@@ -96,7 +96,7 @@ func TestApplyTemplateHeader(t *testing.T) {
 	if want := `grpclog.Errorf("Failed`; !strings.Contains(got, want) {
 		t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 	}
-	if want := `mux.Handle(http.MethodGet,`; !strings.Contains(got, want) {
+	if want := `mux.HandleBinding(http.MethodGet,`; !strings.Contains(got, want) {
 		t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 	}
 }
@@ -144,14 +144,17 @@ func TestApplyTemplateRequestWithoutClientStreaming(t *testing.T) {
 	for _, spec := range []struct {
 		serverStreaming bool
 		sigWant         string
+		rpcWant         string
 	}{
 		{
 			serverStreaming: false,
 			sigWant:         `func request_ExampleService_Echo_0(ctx context.Context, marshaler runtime.Marshaler, client ExampleServiceClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {`,
+			rpcWant:         `mux.HandleRPC("/example.ExampleService/Echo", runtime.UnaryRPC(client.Echo), opts...)`,
 		},
 		{
 			serverStreaming: true,
 			sigWant:         `func request_ExampleService_Echo_0(ctx context.Context, marshaler runtime.Marshaler, client ExampleServiceClient, req *http.Request, pathParams map[string]string) (ExampleService_EchoClient, runtime.ServerMetadata, error) {`,
+			rpcWant:         `mux.HandleRPC("/example.ExampleService/Echo", runtime.ServerStreamingRPC(client.Echo), opts...)`,
 		},
 	} {
 		meth.ServerStreaming = proto.Bool(spec.serverStreaming)
@@ -245,7 +248,10 @@ func TestApplyTemplateRequestWithoutClientStreaming(t *testing.T) {
 		if want := spec.sigWant; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
-		if want := `marshaler.NewDecoder(req.Body).Decode(&protoReq.GetNested().Bool)`; !strings.Contains(got, want) {
+		if want := spec.rpcWant; !strings.Contains(got, want) {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
+		if want := `runtime.DecodeRequest(ctx, marshaler.NewDecoder(req.Body), &protoReq.GetNested().Bool)`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
 		if want := `val, ok := pathParams["nested.int32"]`; !strings.Contains(got, want) {
@@ -254,7 +260,7 @@ func TestApplyTemplateRequestWithoutClientStreaming(t *testing.T) {
 		if want := `protoReq.GetNested().Int32, err = runtime.Int32P(val)`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
-		if want := `func RegisterExampleServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {`; !strings.Contains(got, want) {
+		if want := `func RegisterExampleServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts ...runtime.RegisterOption) error {`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
 		if want := `pattern_ExampleService_Echo_0 = runtime.MustPattern(runtime.NewPattern(1, []int{0, 0}, []string(nil), ""))`; !strings.Contains(got, want) {
@@ -266,7 +272,7 @@ func TestApplyTemplateRequestWithoutClientStreaming(t *testing.T) {
 		if want := `grpclog.Errorf("Failed`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
-		if want := `mux.Handle(http.MethodPost,`; !strings.Contains(got, want) {
+		if want := `mux.HandleBinding(http.MethodPost,`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
 	}
@@ -315,14 +321,17 @@ func TestApplyTemplateRequestWithClientStreaming(t *testing.T) {
 	for _, spec := range []struct {
 		serverStreaming bool
 		sigWant         string
+		rpcWant         string
 	}{
 		{
 			serverStreaming: false,
 			sigWant:         `func request_ExampleService_Echo_0(ctx context.Context, marshaler runtime.Marshaler, client ExampleServiceClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {`,
+			rpcWant:         `mux.HandleRPC("/example.ExampleService/Echo", runtime.ClientStreamingRPC(client.Echo), opts...)`,
 		},
 		{
 			serverStreaming: true,
 			sigWant:         `func request_ExampleService_Echo_0(ctx context.Context, marshaler runtime.Marshaler, client ExampleServiceClient, req *http.Request, pathParams map[string]string) (ExampleService_EchoClient, runtime.ServerMetadata, error) {`,
+			rpcWant:         `mux.HandleRPC("/example.ExampleService/Echo", runtime.BidiStreamingRPC(client.Echo), opts...)`,
 		},
 	} {
 		meth.ServerStreaming = proto.Bool(spec.serverStreaming)
@@ -415,7 +424,10 @@ func TestApplyTemplateRequestWithClientStreaming(t *testing.T) {
 		if want := spec.sigWant; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
-		if want := `func RegisterExampleServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {`; !strings.Contains(got, want) {
+		if want := spec.rpcWant; !strings.Contains(got, want) {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
+		if want := `func RegisterExampleServiceHandler(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn, opts ...runtime.RegisterOption) error {`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
 		if want := `pattern_ExampleService_Echo_0 = runtime.MustPattern(runtime.NewPattern(1, []int{0, 0}, []string(nil), ""))`; !strings.Contains(got, want) {
@@ -424,7 +436,7 @@ func TestApplyTemplateRequestWithClientStreaming(t *testing.T) {
 		if want := `grpclog.Errorf("Failed`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
-		if want := `mux.Handle(http.MethodPost,`; !strings.Contains(got, want) {
+		if want := `mux.HandleBinding(http.MethodPost,`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
 	}
@@ -600,13 +612,13 @@ func TestApplyTemplateInProcess(t *testing.T) {
 			}
 		}
 
-		if want := `func RegisterExampleServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server ExampleServiceServer) error {`; !strings.Contains(got, want) {
+		if want := `func RegisterExampleServiceHandlerServer(ctx context.Context, mux *runtime.ServeMux, server ExampleServiceServer, opts ...runtime.RegisterOption) error {`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
 		if want := `grpclog.Errorf("Failed`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
-		if want := `mux.Handle(http.MethodPost,`; !strings.Contains(got, want) {
+		if want := `mux.HandleBinding(http.MethodPost,`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
 	}
@@ -683,7 +695,7 @@ func TestAllowPatchFeature(t *testing.T) {
 			return
 		}
 		if allowPatchFeature {
-			if want := `marshaler.NewDecoder(newReader()).Decode(&protoReq.Abe)`; !strings.Contains(got, want) {
+			if want := `runtime.DecodeRequest(ctx, marshaler.NewDecoder(newReader()), &protoReq.Abe)`; !strings.Contains(got, want) {
 				t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 			}
 			if !strings.Contains(got, want) {
@@ -697,7 +709,7 @@ func TestAllowPatchFeature(t *testing.T) {
 		if want := `grpclog.Errorf("Failed`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
-		if want := `mux.Handle(http.MethodPatch,`; !strings.Contains(got, want) {
+		if want := `mux.HandleBinding(http.MethodPatch,`; !strings.Contains(got, want) {
 			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 		}
 	}
@@ -801,10 +813,10 @@ func TestIdentifierCapitalization(t *testing.T) {
 	if want := `grpclog.Errorf("Failed`; !strings.Contains(got, want) {
 		t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 	}
-	if want := `mux.Handle(http.MethodGet,`; !strings.Contains(got, want) {
+	if want := `mux.HandleBinding(http.MethodGet,`; !strings.Contains(got, want) {
 		t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 	}
-	if want := `mux.Handle(http.MethodPost,`; !strings.Contains(got, want) {
+	if want := `mux.HandleBinding(http.MethodPost,`; !strings.Contains(got, want) {
 		t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
 	}
 }
@@ -969,3 +981,144 @@ func TestDuplicatePathsInDifferentService(t *testing.T) {
 		return
 	}
 }
+
+func TestValidateRequests(t *testing.T) {
+	msgdesc := &descriptorpb.DescriptorProto{
+		Name: proto.String("ExampleMessage"),
+	}
+	meth := &descriptorpb.MethodDescriptorProto{
+		Name:       proto.String("Example"),
+		InputType:  proto.String("ExampleMessage"),
+		OutputType: proto.String("ExampleMessage"),
+	}
+	svc := &descriptorpb.ServiceDescriptorProto{
+		Name:   proto.String("ExampleService"),
+		Method: []*descriptorpb.MethodDescriptorProto{meth},
+	}
+	msg := &descriptor.Message{
+		DescriptorProto: msgdesc,
+	}
+	file := descriptor.File{
+		FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+			Name:        proto.String("example.proto"),
+			Package:     proto.String("example"),
+			MessageType: []*descriptorpb.DescriptorProto{msgdesc},
+			Service:     []*descriptorpb.ServiceDescriptorProto{svc},
+		},
+		GoPkg: descriptor.GoPackage{
+			Path: "example.com/path/to/example/example.pb",
+			Name: "example_pb",
+		},
+		Messages: []*descriptor.Message{msg},
+		Services: []*descriptor.Service{
+			{
+				ServiceDescriptorProto: svc,
+				Methods: []*descriptor.Method{
+					{
+						MethodDescriptorProto: meth,
+						RequestType:           msg,
+						ResponseType:          msg,
+						Bindings: []*descriptor.Binding{
+							{
+								HTTPMethod: "GET",
+								PathTmpl:   compilePath(t, "/v1"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	want := "if err := runtime.ValidateRequest(ctx, &protoReq); err != nil {\n"
+	for _, validateRequests := range []bool{true, false} {
+		reg := descriptor.NewRegistry()
+		reg.SetValidateRequests(validateRequests)
+		got, err := applyTemplate(param{File: crossLinkFixture(&file), RegisterFuncSuffix: "Handler", AllowPatchFeature: true}, reg)
+		if err != nil {
+			t.Errorf("applyTemplate(%#v) failed with %v; want success", file, err)
+			return
+		}
+		// Both the client and the local request functions validate the
+		// requests.
+		if n := strings.Count(got, want); validateRequests && n != 2 {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s twice", file, got, want)
+		} else if !validateRequests && n != 0 {
+			t.Errorf("applyTemplate(%#v) = %s; want to _not_ contain %s", file, got, want)
+		}
+	}
+}
+
+func TestRegisterOptions(t *testing.T) {
+	msgdesc := &descriptorpb.DescriptorProto{
+		Name: proto.String("ExampleMessage"),
+	}
+	meth := &descriptorpb.MethodDescriptorProto{
+		Name:       proto.String("Example"),
+		InputType:  proto.String("ExampleMessage"),
+		OutputType: proto.String("ExampleMessage"),
+	}
+	svc := &descriptorpb.ServiceDescriptorProto{
+		Name:   proto.String("ExampleService"),
+		Method: []*descriptorpb.MethodDescriptorProto{meth},
+	}
+	msg := &descriptor.Message{
+		DescriptorProto: msgdesc,
+	}
+	file := descriptor.File{
+		FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+			Name:        proto.String("example.proto"),
+			Package:     proto.String("example"),
+			MessageType: []*descriptorpb.DescriptorProto{msgdesc},
+			Service:     []*descriptorpb.ServiceDescriptorProto{svc},
+		},
+		GoPkg: descriptor.GoPackage{
+			Path: "example.com/path/to/example/example.pb",
+			Name: "example_pb",
+		},
+		Messages: []*descriptor.Message{msg},
+		Services: []*descriptor.Service{
+			{
+				ServiceDescriptorProto: svc,
+				Methods: []*descriptor.Method{
+					{
+						MethodDescriptorProto: meth,
+						RequestType:           msg,
+						ResponseType:          msg,
+						Bindings: []*descriptor.Binding{
+							{
+								HTTPMethod: "GET",
+								PathTmpl:   compilePath(t, "/v1"),
+							},
+							{
+								Index:      1,
+								HTTPMethod: "GET",
+								PathTmpl:   compilePath(t, "/v1/example"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	got, err := applyTemplate(param{File: crossLinkFixture(&file), RegisterFuncSuffix: "Handler", AllowPatchFeature: true}, descriptor.NewRegistry())
+	if err != nil {
+		t.Fatalf("applyTemplate(%#v) failed with %v; want success", file, err)
+	}
+	for _, want := range []string{
+		`func RegisterExampleServiceHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, registerOpts ...runtime.RegisterOption) (err error) {`,
+		`return RegisterExampleServiceHandler(ctx, mux, conn, registerOpts...)`,
+		`return RegisterExampleServiceHandlerClient(ctx, mux, NewExampleServiceClient(conn), opts...)`,
+		`func RegisterExampleServiceHandlerClient(ctx context.Context, mux *runtime.ServeMux, client ExampleServiceClient, opts ...runtime.RegisterOption) error {`,
+		`mux.HandleBinding(http.MethodGet, pattern_ExampleService_Example_0, "/example.ExampleService/Example", 0, func(`,
+		`mux.HandleBinding(http.MethodGet, pattern_ExampleService_Example_1, "/example.ExampleService/Example", 1, func(`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("applyTemplate(%#v) = %s; want to contain %s", file, got, want)
+		}
+	}
+	// Both the client and the local handlers are registered with the
+	// options.
+	if want := "}, opts...)\n"; strings.Count(got, want) != 4 {
+		t.Errorf("applyTemplate(%#v) = %s; want to contain %s 4 times", file, got, want)
+	}
+}
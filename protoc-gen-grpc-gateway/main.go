@@ -10,6 +10,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -36,7 +37,14 @@ var (
 	versionFlag                = flag.Bool("version", false, "print the current version")
 	warnOnUnboundMethods       = flag.Bool("warn_on_unbound_methods", false, "emit a warning message if an RPC method has no HttpRule annotation")
 	generateUnboundMethods     = flag.Bool("generate_unbound_methods", false, "generate proxy methods even for RPC methods that have no HttpRule annotation")
+	unboundMethodBindings      = flag.String("unbound_method_bindings", "grpc", "bindings of the RPC methods generated by `generate_unbound_methods`. Allowed values are `grpc`, binding POST /<package>.<Service>/<Method>, and `aip`, inferring RESTful bindings from AIP resource-oriented design conventions")
 	useOpaqueAPI               = flag.Bool("use_opaque_api", false, "generate code compatible with the new Opaque API instead of the older Open Struct API")
+	generateHTTPClient         = flag.Bool("generate_http_client", false, "also generate typed Go clients calling the methods through their HTTP bindings, in *.pb.gw.client.go files")
+	validateRequests           = flag.Bool("validate_requests", false, "if set, the generated handlers validate the request messages with the RequestValidator of the ServeMux, such as runtime.DefaultRequestValidator, before calling the methods")
+	generateGatewayMain        = flag.String("generate_gateway_main", "", "if set, also generates a runnable gateway command for the services of the generated files, in a main.go file of this directory. See the runtime/gatewaycmd package for its configuration")
+	lintOnly                   = flag.Bool("lint_only", false, "if set, only checks the google.api.http bindings, and reports their problems instead of generating code")
+	lintSeverity               = flag.String("lint_severity", "warning", "minimum severity of the problems reported by `lint_only`. Allowed values are `info`, `warning` and `error`")
+	lintFormat                 = flag.String("lint_format", "text", "format of the problems reported by `lint_only`. Allowed values are `text`, failing with an error listing the problems if any of them is an error and printing them to stderr otherwise, and `json`, generating a lint.json report")
 
 	_ = flag.Bool("logtostderr", false, "Legacy glog compatibility. This flag is a no-op, you can safely remove it")
 )
@@ -91,6 +99,10 @@ func main() {
 			return err
 		}
 
+		if *lintOnly {
+			return lint(gen, reg)
+		}
+
 		unboundHTTPRules := reg.UnboundExternalHTTPRules()
 		if len(unboundHTTPRules) != 0 {
 			return fmt.Errorf("HTTP rules without a matching selector: %s", strings.Join(unboundHTTPRules, ", "))
@@ -146,5 +158,35 @@ func applyFlags(reg *descriptor.Registry) error {
 	reg.SetOmitPackageDoc(*omitPackageDoc)
 	reg.SetWarnOnUnboundMethods(*warnOnUnboundMethods)
 	reg.SetGenerateUnboundMethods(*generateUnboundMethods)
+	if err := reg.SetUnboundMethodBindings(*unboundMethodBindings); err != nil {
+		return err
+	}
+	reg.SetGenerateHTTPClient(*generateHTTPClient)
+	reg.SetValidateRequests(*validateRequests)
+	reg.SetGatewayMain(*generateGatewayMain)
+	reg.SetLintOnly(*lintOnly)
 	return reg.SetRepeatedPathParamSeparator(*repeatedPathParamSeparator)
 }
+
+// lint reports the problems of the bindings loaded in "reg", instead of
+// generating code.
+func lint(gen *protogen.Plugin, reg *descriptor.Registry) error {
+	severity, err := descriptor.ParseLintSeverity(*lintSeverity)
+	if err != nil {
+		return err
+	}
+	resp, err := descriptor.LintResponse(reg.Lint(severity), *lintFormat, os.Stderr)
+	if err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return errors.New(resp.GetError())
+	}
+	for _, f := range resp.File {
+		genFile := gen.NewGeneratedFile(f.GetName(), "")
+		if _, err := genFile.Write([]byte(f.GetContent())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1938,3 +1938,127 @@ func TestGenerateXGoType(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateResourceName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                  string
+		splitResourcePatterns bool
+		wantYAML              string
+	}{
+		{
+			name:     "patterns",
+			wantYAML: "testdata/generator/resource_name.swagger.yaml",
+		},
+		{
+			name:                  "split patterns",
+			splitResourcePatterns: true,
+			wantYAML:              "testdata/generator/resource_name_split.swagger.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := os.ReadFile("testdata/generator/resource_name.prototext")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var req pluginpb.CodeGeneratorRequest
+			if err := prototext.Unmarshal(b, &req); err != nil {
+				t.Fatal(err)
+			}
+
+			reg := descriptor.NewRegistry()
+			reg.SetSplitResourcePatterns(tt.splitResourcePatterns)
+			if err := reg.Load(&req); err != nil {
+				t.Fatalf("failed to load request: %s", err)
+			}
+			f, err := reg.LookupFile(req.FileToGenerate[0])
+			if err != nil {
+				t.Fatalf("failed to lookup file: %s", err)
+			}
+
+			resp, err := genopenapi.New(reg, genopenapi.FormatYAML).Generate([]*descriptor.File{f})
+			if err != nil {
+				t.Fatalf("failed to generate: %s", err)
+			}
+			if len(resp) != 1 {
+				t.Fatalf("expected 1 file, got %d", len(resp))
+			}
+
+			want, err := os.ReadFile(tt.wantYAML)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(want), resp[0].GetContent()); diff != "" {
+				t.Errorf("content not match\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateFieldBehavior(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                  string
+		requestSchemaVariants bool
+		wantYAML              string
+	}{
+		{
+			name:     "field behaviors",
+			wantYAML: "testdata/generator/field_behavior.swagger.yaml",
+		},
+		{
+			name:                  "request schema variants",
+			requestSchemaVariants: true,
+			wantYAML:              "testdata/generator/field_behavior_variants.swagger.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := os.ReadFile("testdata/generator/field_behavior.prototext")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var req pluginpb.CodeGeneratorRequest
+			if err := prototext.Unmarshal(b, &req); err != nil {
+				t.Fatal(err)
+			}
+
+			reg := descriptor.NewRegistry()
+			reg.SetRequestSchemaVariants(tt.requestSchemaVariants)
+			if err := reg.Load(&req); err != nil {
+				t.Fatalf("failed to load request: %s", err)
+			}
+			f, err := reg.LookupFile(req.FileToGenerate[0])
+			if err != nil {
+				t.Fatalf("failed to lookup file: %s", err)
+			}
+
+			resp, err := genopenapi.New(reg, genopenapi.FormatYAML).Generate([]*descriptor.File{f})
+			if err != nil {
+				t.Fatalf("failed to generate: %s", err)
+			}
+			if len(resp) != 1 {
+				t.Fatalf("expected 1 file, got %d", len(resp))
+			}
+
+			want, err := os.ReadFile(tt.wantYAML)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(string(want), resp[0].GetContent()); diff != "" {
+				t.Errorf("content not match\n%s", diff)
+			}
+		})
+	}
+}
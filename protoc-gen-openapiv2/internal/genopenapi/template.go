@@ -20,6 +20,7 @@ import (
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/casing"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/descriptor"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/validate"
 	openapi_options "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
 	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/genproto/googleapis/api/visibility"
@@ -601,6 +602,8 @@ func renderMessageAsDefinition(msg *descriptor.Message, reg *descriptor.Registry
 		if err := updateOpenAPIDataFromComments(reg, &fieldSchema, f, comments, false); err != nil {
 			return openapiSchemaObject{}, err
 		}
+		updateSwaggerObjectFromResourceName(&fieldSchema, reg, f)
+		describeFieldBehavior(&fieldSchema)
 
 		if requiredIdx := find(schema.Required, *f.Name); requiredIdx != -1 && reg.GetUseJSONNamesForFields() {
 			schema.Required[requiredIdx] = f.GetJsonName()
@@ -626,11 +629,17 @@ func renderMessageAsDefinition(msg *descriptor.Message, reg *descriptor.Registry
 						schemaCore: schemaCore{
 							Example: fieldSchema.Example,
 						},
-						ReadOnly: fieldSchema.ReadOnly,
-						AllOf:    []allOfEntry{{Ref: fieldSchema.Ref}},
+						ReadOnly:  fieldSchema.ReadOnly,
+						inputOnly: fieldSchema.inputOnly,
+						immutable: fieldSchema.immutable,
+						AllOf:     []allOfEntry{{Ref: fieldSchema.Ref}},
 					}
 				} else {
-					fieldSchema = openapiSchemaObject{schemaCore: schemaCore{Ref: fieldSchema.Ref}}
+					fieldSchema = openapiSchemaObject{
+						schemaCore: schemaCore{Ref: fieldSchema.Ref},
+						inputOnly:  fieldSchema.inputOnly,
+						immutable:  fieldSchema.immutable,
+					}
 				}
 			}
 		}
@@ -869,6 +878,10 @@ func schemaOfField(f *descriptor.Field, reg *descriptor.Registry, refs refMap) o
 		updateSwaggerObjectFromFieldBehavior(&ret, j, reg, f)
 	}
 
+	if rules := validate.FieldRules(f.GetOptions()); rules != nil {
+		updateSwaggerObjectFromValidateRules(&ret, rules, reg, f)
+	}
+
 	for i, required := range ret.Required {
 		if required == f.GetName() {
 			ret.Required[i] = reg.FieldName(f)
@@ -1516,6 +1529,7 @@ func renderServices(services []*descriptor.Service, paths *openapiPathsObject, r
 									}
 									defName := methFQN + "Body"
 									schema.Ref = fmt.Sprintf("#/definitions/%s", defName)
+									messageSchema.requestBody = true
 									defs[defName] = messageSchema
 								} else {
 									schema = messageSchema
@@ -1666,8 +1680,13 @@ func renderServices(services []*descriptor.Service, paths *openapiPathsObject, r
 						desc = fieldProtoComments(reg, lastField.Target.Message, lastField.Target)
 					}
 				}
+				isHTTPBodyStream := meth.GetServerStreaming() && meth.ResponseType.FQMN() == ".google.api.HttpBody"
 				if meth.GetServerStreaming() {
 					desc += "(streaming responses)"
+				}
+				// Without an envelope, messages are streamed as they are, so the
+				// schema is the one of a message.
+				if meth.GetServerStreaming() && (isHTTPBodyStream || reg.GetStreamEnvelope() != "none") {
 					responseSchema.Type = "object"
 					swgRef, _ := fullyQualifiedNameToOpenAPIName(meth.ResponseType.FQMN(), reg)
 					responseSchema.Title = "Stream result of " + swgRef
@@ -1697,7 +1716,7 @@ func renderServices(services []*descriptor.Service, paths *openapiPathsObject, r
 					}
 
 					// Special case HttpBody responses, they will be unformatted bytes
-					if meth.ResponseType.FQMN() == ".google.api.HttpBody" {
+					if isHTTPBodyStream {
 						responseSchema.Type = "string"
 						responseSchema.Format = "binary"
 						responseSchema.Title = "Free form byte stream"
@@ -1715,6 +1734,11 @@ func renderServices(services []*descriptor.Service, paths *openapiPathsObject, r
 					Responses:  openapiResponsesObject{},
 					Deprecated: deprecated,
 				}
+				if meth.GetServerStreaming() && !isHTTPBodyStream {
+					if mediaType := reg.GetStreamMediaType(); mediaType != "" {
+						operationObject.Produces = []string{mediaType}
+					}
+				}
 
 				if !reg.GetDisableDefaultResponses() {
 					operationObject.Responses["200"] = openapiResponseObject{
@@ -1990,11 +2014,13 @@ func operationForMethod(httpMethod string) func(*openapiPathItemObject) *openapi
 func applyTemplate(p param) (*openapiSwaggerObject, error) {
 	// Create the basic template object. This is the object that everything is
 	// defined off of.
+	mediaTypes := append([]string{"application/json"}, p.reg.GetMarshalerMediaTypes()...)
 	s := openapiSwaggerObject{
 		// OpenAPI 2.0 is the version of this document
 		Swagger:     "2.0",
-		Consumes:    []string{"application/json"},
-		Produces:    []string{"application/json"},
+		Consumes:    mediaTypes,
+		Produces:    slices.Clone(mediaTypes),
+		BasePath:    p.reg.GetBasePath(),
 		Paths:       openapiPathsObject{},
 		Definitions: make(openapiDefinitionsObject),
 		Info: openapiInfoObject{
@@ -2294,9 +2320,224 @@ func applyTemplate(p param) (*openapiSwaggerObject, error) {
 		return nil, err
 	}
 
+	if p.reg.GetRequestSchemaVariants() {
+		renderRequestSchemaVariants(&s)
+	}
+
 	return &s, nil
 }
 
+// requestSchemaVariants derives the variants of definitions describing them
+// in request bodies, where OUTPUT_ONLY fields are left out and, in PATCH
+// requests, IMMUTABLE fields are read-only.
+type requestSchemaVariants struct {
+	defs openapiDefinitionsObject
+	// differs holds the definitions whose variants differ from them, and
+	// patchDiffers those whose variants differ in PATCH requests.
+	differs      map[string]bool
+	patchDiffers map[string]bool
+	// rendered holds the request body definitions already rewritten.
+	rendered map[string]bool
+}
+
+// renderRequestSchemaVariants makes the request bodies of "s" refer to
+// variants of the definitions honoring the field behaviors of their fields,
+// and leaves INPUT_ONLY fields out of the definitions themselves, which then
+// only describe responses.
+func renderRequestSchemaVariants(s *openapiSwaggerObject) {
+	v := &requestSchemaVariants{
+		defs:     s.Definitions,
+		rendered: map[string]bool{},
+	}
+	v.differs = v.findDiffering(func(p openapiSchemaObject) bool {
+		return p.ReadOnly || p.inputOnly
+	})
+	v.patchDiffers = v.findDiffering(func(p openapiSchemaObject) bool {
+		return p.immutable
+	})
+	bases := make([]string, 0, len(s.Definitions))
+	for name := range s.Definitions {
+		bases = append(bases, name)
+	}
+
+	for i := range s.Paths {
+		item := &s.Paths[i].PathItemObject
+		for _, op := range []*openapiOperationObject{item.Get, item.Delete, item.Post, item.Put, item.Patch, item.Head, item.Options} {
+			if op == nil {
+				continue
+			}
+			for j := range op.Parameters {
+				param := &op.Parameters[j]
+				if param.In != "body" || param.Schema == nil {
+					continue
+				}
+				body := v.request(*param.Schema, op == item.Patch)
+				param.Schema = &body
+			}
+		}
+	}
+
+	for _, name := range bases {
+		def := s.Definitions[name]
+		if def.requestBody || def.Properties == nil {
+			continue
+		}
+		def.Properties, def.Required = filterProperties(*def.Properties, def.Required, func(p openapiSchemaObject) bool {
+			return !p.inputOnly
+		})
+		s.Definitions[name] = def
+	}
+}
+
+// findDiffering returns the definitions with fields for which "differs"
+// holds, or with fields of such definitions.
+func (v *requestSchemaVariants) findDiffering(differs func(openapiSchemaObject) bool) map[string]bool {
+	found := map[string]bool{}
+	for changed := true; changed; {
+		changed = false
+		for name, def := range v.defs {
+			if found[name] || def.Properties == nil {
+				continue
+			}
+			for _, prop := range *def.Properties {
+				p, ok := prop.Value.(openapiSchemaObject)
+				if !ok {
+					continue
+				}
+				if differs(p) || slices.ContainsFunc(schemaRefs(p), func(ref string) bool {
+					return found[strings.TrimPrefix(ref, "#/definitions/")]
+				}) {
+					found[name] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return found
+}
+
+// schemaRefs returns the references "s" makes to definitions, directly or
+// through its items, additional properties or allOf entries.
+func schemaRefs(s openapiSchemaObject) []string {
+	var refs []string
+	if s.Ref != "" {
+		refs = append(refs, s.Ref)
+	}
+	if s.Items != nil {
+		refs = append(refs, schemaRefs(openapiSchemaObject(*s.Items))...)
+	}
+	if s.AdditionalProperties != nil {
+		refs = append(refs, schemaRefs(*s.AdditionalProperties)...)
+	}
+	for _, entry := range s.AllOf {
+		refs = append(refs, entry.Ref)
+	}
+	return refs
+}
+
+// variantName returns the name of the variant of the definition "name".
+func variantName(name string, patch bool) string {
+	if patch {
+		return name + "PatchInput"
+	}
+	return name + "Input"
+}
+
+// request returns the schema "s" describes in request bodies, rendering the
+// variants of the definitions it refers to.
+func (v *requestSchemaVariants) request(s openapiSchemaObject, patch bool) openapiSchemaObject {
+	if s.Properties != nil {
+		s.Properties, s.Required = filterProperties(*s.Properties, s.Required, func(p openapiSchemaObject) bool {
+			return !p.ReadOnly
+		})
+		props := *s.Properties
+		for i, prop := range props {
+			p, ok := prop.Value.(openapiSchemaObject)
+			if !ok {
+				continue
+			}
+			if patch && p.immutable {
+				p.ReadOnly = true
+			}
+			props[i].Value = v.request(p, patch)
+		}
+	}
+	if s.Ref != "" {
+		s.Ref = v.requestRef(s.Ref, patch)
+	}
+	if s.Items != nil {
+		items := openapiItemsObject(v.request(openapiSchemaObject(*s.Items), patch))
+		s.Items = &items
+	}
+	if s.AdditionalProperties != nil {
+		additional := v.request(*s.AdditionalProperties, patch)
+		s.AdditionalProperties = &additional
+	}
+	if len(s.AllOf) > 0 {
+		allOf := make([]allOfEntry, len(s.AllOf))
+		for i, entry := range s.AllOf {
+			allOf[i] = allOfEntry{Ref: v.requestRef(entry.Ref, patch)}
+		}
+		s.AllOf = allOf
+	}
+	return s
+}
+
+// requestRef returns the reference to the definition describing "ref" in
+// request bodies.
+func (v *requestSchemaVariants) requestRef(ref string, patch bool) string {
+	name, ok := strings.CutPrefix(ref, "#/definitions/")
+	if !ok {
+		return ref
+	}
+	def, ok := v.defs[name]
+	if !ok {
+		return ref
+	}
+	if def.requestBody {
+		if !v.rendered[name] {
+			v.rendered[name] = true
+			v.defs[name] = v.request(def, patch)
+		}
+		return ref
+	}
+	if patch && !v.patchDiffers[name] {
+		patch = false
+	}
+	if !patch && !v.differs[name] {
+		return ref
+	}
+	variant := variantName(name, patch)
+	if _, ok := v.defs[variant]; !ok {
+		// Register the variant before rendering it, for recursive messages.
+		v.defs[variant] = def
+		v.defs[variant] = v.request(def, patch)
+	}
+	return "#/definitions/" + variant
+}
+
+// filterProperties returns the properties for which "keep" holds, and the
+// names in "required" of those.
+func filterProperties(props openapiSchemaObjectProperties, required []string, keep func(openapiSchemaObject) bool) (*openapiSchemaObjectProperties, []string) {
+	kept := make(openapiSchemaObjectProperties, 0, len(props))
+	dropped := map[string]bool{}
+	for _, prop := range props {
+		if p, ok := prop.Value.(openapiSchemaObject); ok && !keep(p) {
+			dropped[prop.Key] = true
+			continue
+		}
+		kept = append(kept, prop)
+	}
+	var keptRequired []string
+	for _, name := range required {
+		if !dropped[name] {
+			keptRequired = append(keptRequired, name)
+		}
+	}
+	return &kept, keptRequired
+}
+
 func mergeTags(existingTags []openapiTagObject, tags []openapiTagObject) []openapiTagObject {
 	for _, tag := range tags {
 		matched := false
@@ -3250,7 +3491,9 @@ func updateSwaggerObjectFromFieldBehavior(s *openapiSchemaObject, j []annotation
 			required = false
 		case annotations.FieldBehavior_INPUT_ONLY:
 			// OpenAPI v3 supports a writeOnly property, but this is not supported in Open API v2
+			s.inputOnly = true
 		case annotations.FieldBehavior_IMMUTABLE:
+			s.immutable = true
 		}
 	}
 	if required {
@@ -3262,6 +3505,125 @@ func updateSwaggerObjectFromFieldBehavior(s *openapiSchemaObject, j []annotation
 	}
 }
 
+// updateSwaggerObjectFromValidateRules documents the buf.validate rules of
+// "field" on "s", without overwriting the values set by its
+// openapiv2_field option.
+func updateSwaggerObjectFromValidateRules(s *openapiSchemaObject, rules *validate.Rules, reg *descriptor.Registry, field *descriptor.Field) {
+	if rules.Required {
+		s.Required = append(s.Required, reg.FieldName(field))
+	}
+	if s.Type == "array" {
+		if rules.MinItems != nil && s.MinItems == 0 {
+			s.MinItems = *rules.MinItems
+		}
+		if rules.MaxItems != nil && s.MaxItems == 0 {
+			s.MaxItems = *rules.MaxItems
+		}
+		s.UniqueItems = s.UniqueItems || rules.UniqueItems
+		if rules.Items != nil && s.Items != nil {
+			applyValidateRules((*openapiSchemaObject)(s.Items), rules.Items)
+		}
+		return
+	}
+	applyValidateRules(s, rules)
+}
+
+// applyValidateRules documents the buf.validate rules of strings and numbers
+// on the schema "s" of a single value.
+func applyValidateRules(s *openapiSchemaObject, rules *validate.Rules) {
+	switch {
+	case s.Type == "string" && s.Format == "":
+		if rules.MinLen != nil && s.MinLength == 0 {
+			s.MinLength = *rules.MinLen
+		}
+		if rules.MaxLen != nil && s.MaxLength == 0 {
+			s.MaxLength = *rules.MaxLen
+		}
+		if rules.Pattern != "" && s.Pattern == "" {
+			s.Pattern = rules.Pattern
+		}
+		if len(rules.In) > 0 && s.Enum == nil {
+			s.Enum = rules.In
+		}
+	case s.Type == "integer" || s.Type == "number":
+		// Bounds of 0 can't be told apart from unset ones, and are left out.
+		if s.Minimum == 0 && !s.ExclusiveMinimum {
+			switch {
+			case rules.GT != nil && *rules.GT != 0:
+				s.Minimum, s.ExclusiveMinimum = *rules.GT, true
+			case rules.GTE != nil:
+				s.Minimum = *rules.GTE
+			}
+		}
+		if s.Maximum == 0 && !s.ExclusiveMaximum {
+			switch {
+			case rules.LT != nil && *rules.LT != 0:
+				s.Maximum, s.ExclusiveMaximum = *rules.LT, true
+			case rules.LTE != nil:
+				s.Maximum = *rules.LTE
+			}
+		}
+	}
+}
+
+// describeFieldBehavior documents the INPUT_ONLY and IMMUTABLE field
+// behaviors recorded on "s", which OpenAPI v2 can't express otherwise.
+func describeFieldBehavior(s *openapiSchemaObject) {
+	var notes []string
+	if s.inputOnly {
+		notes = append(notes, "Input only: this field is never returned in responses.")
+	}
+	if s.immutable {
+		notes = append(notes, "Immutable: this field can't be changed once the resource is created.")
+	}
+	for _, note := range notes {
+		if s.Description == "" {
+			s.Description = note
+		} else {
+			s.Description += paragraphDeliminator + note
+		}
+	}
+}
+
+// updateSwaggerObjectFromResourceName documents the resource names "field"
+// holds, as declared by google.api.resource and google.api.resource_reference
+// annotations: their patterns become a regular expression and a description,
+// and their type an x-resource-type extension, or x-resource-child-type for
+// the names of the parents of resources.
+func updateSwaggerObjectFromResourceName(s *openapiSchemaObject, reg *descriptor.Registry, field *descriptor.Field) {
+	name := reg.LookupResourceName(field)
+	if name == nil || len(name.Patterns) == 0 {
+		return
+	}
+	target := s
+	if s.Type == "array" && s.Items != nil {
+		target = (*openapiSchemaObject)(s.Items)
+	}
+	if target.Pattern == "" {
+		target.Pattern = descriptor.ResourcePatternRegexp(name.Patterns)
+	}
+	if s.Description == "" {
+		s.Description = name.Description(reg.GetSplitResourcePatterns())
+	} else {
+		s.Description += paragraphDeliminator + name.Description(reg.GetSplitResourcePatterns())
+	}
+
+	key := "x-resource-type"
+	if name.Parent {
+		key = "x-resource-child-type"
+	}
+	for _, ext := range s.extensions {
+		if ext.key == key {
+			return
+		}
+	}
+	value, err := json.Marshal(name.Type)
+	if err != nil {
+		return
+	}
+	s.extensions = append(s.extensions, extension{key: key, value: value})
+}
+
 func openapiSchemaFromProtoEnumSchema(s *openapi_options.EnumSchema, reg *descriptor.Registry, refs refMap, data interface{}) openapiSchemaObject {
 	ret := openapiSchemaObject{
 		ExternalDocs: protoExternalDocumentationToOpenAPIExternalDocumentation(s.GetExternalDocs(), reg, data),
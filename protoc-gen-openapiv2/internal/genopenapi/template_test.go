@@ -20,6 +20,7 @@ import (
 	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/genproto/googleapis/api/visibility"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -1554,6 +1555,86 @@ func TestApplyTemplateSimple(t *testing.T) {
 	}
 }
 
+func TestApplyTemplateMarshalerMediaTypes(t *testing.T) {
+	msgdesc := &descriptorpb.DescriptorProto{
+		Name: proto.String("ExampleMessage"),
+	}
+	file := descriptor.File{
+		FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+			SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+			Name:           proto.String("example.proto"),
+			Package:        proto.String("example"),
+			MessageType:    []*descriptorpb.DescriptorProto{msgdesc},
+			Options: &descriptorpb.FileOptions{
+				GoPackage: proto.String("github.com/grpc-ecosystem/grpc-gateway/runtime/internal/examplepb;example"),
+			},
+		},
+		Messages: []*descriptor.Message{{DescriptorProto: msgdesc}},
+	}
+	reg := descriptor.NewRegistry()
+	reg.SetMarshalerMediaTypes([]string{"application/cbor", " application/msgpack", "application/json", "application/cbor"})
+	fileCL := crossLinkFixture(&file)
+	if err := reg.Load(reqFromFile(fileCL)); err != nil {
+		t.Fatalf("reg.Load(%#v) failed with %v; want success", file, err)
+	}
+	result, err := applyTemplate(param{File: fileCL, reg: reg})
+	if err != nil {
+		t.Fatalf("applyTemplate(%#v) failed with %v; want success", file, err)
+	}
+	want := []string{"application/json", "application/cbor", "application/msgpack"}
+	if !reflect.DeepEqual(result.Consumes, want) {
+		t.Errorf("applyTemplate(%#v).Consumes = %v; want %v", file, result.Consumes, want)
+	}
+	if !reflect.DeepEqual(result.Produces, want) {
+		t.Errorf("applyTemplate(%#v).Produces = %v; want %v", file, result.Produces, want)
+	}
+}
+
+func TestApplyTemplateBasePath(t *testing.T) {
+	for _, spec := range []struct {
+		basePath string
+		swagger  *openapi_options.Swagger
+		want     string
+	}{
+		{basePath: "", want: ""},
+		{basePath: "/api/v2/", want: "/api/v2"},
+		{basePath: "api/v2", swagger: &openapi_options.Swagger{BasePath: "/v1"}, want: "/v1"},
+	} {
+		msgdesc := &descriptorpb.DescriptorProto{
+			Name: proto.String("ExampleMessage"),
+		}
+		opts := &descriptorpb.FileOptions{
+			GoPackage: proto.String("github.com/grpc-ecosystem/grpc-gateway/runtime/internal/examplepb;example"),
+		}
+		if spec.swagger != nil {
+			proto.SetExtension(opts, openapi_options.E_Openapiv2Swagger, spec.swagger)
+		}
+		file := descriptor.File{
+			FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+				SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+				Name:           proto.String("example.proto"),
+				Package:        proto.String("example"),
+				MessageType:    []*descriptorpb.DescriptorProto{msgdesc},
+				Options:        opts,
+			},
+			Messages: []*descriptor.Message{{DescriptorProto: msgdesc}},
+		}
+		reg := descriptor.NewRegistry()
+		reg.SetBasePath(spec.basePath)
+		fileCL := crossLinkFixture(&file)
+		if err := reg.Load(reqFromFile(fileCL)); err != nil {
+			t.Fatalf("reg.Load(%#v) failed with %v; want success", file, err)
+		}
+		result, err := applyTemplate(param{File: fileCL, reg: reg})
+		if err != nil {
+			t.Fatalf("applyTemplate(%#v) failed with %v; want success", file, err)
+		}
+		if result.BasePath != spec.want {
+			t.Errorf("applyTemplate(%#v).BasePath = %q with base path %q; want %q", file, result.BasePath, spec.basePath, spec.want)
+		}
+	}
+}
+
 func TestApplyTemplateMultiService(t *testing.T) {
 	msgdesc := &descriptorpb.DescriptorProto{
 		Name: proto.String("ExampleMessage"),
@@ -3057,6 +3138,122 @@ func TestApplyTemplateRequestWithClientStreaming(t *testing.T) {
 	}
 }
 
+func TestApplyTemplateServerStreamingFormat(t *testing.T) {
+	msgdesc := &descriptorpb.DescriptorProto{
+		Name: proto.String("ExampleMessage"),
+	}
+	meth := &descriptorpb.MethodDescriptorProto{
+		Name:            proto.String("Watch"),
+		InputType:       proto.String("ExampleMessage"),
+		OutputType:      proto.String("ExampleMessage"),
+		ServerStreaming: proto.Bool(true),
+	}
+	svc := &descriptorpb.ServiceDescriptorProto{
+		Name:   proto.String("ExampleService"),
+		Method: []*descriptorpb.MethodDescriptorProto{meth},
+	}
+	msg := &descriptor.Message{
+		DescriptorProto: msgdesc,
+	}
+	file := descriptor.File{
+		FileDescriptorProto: &descriptorpb.FileDescriptorProto{
+			SourceCodeInfo: &descriptorpb.SourceCodeInfo{},
+			Name:           proto.String("example.proto"),
+			Package:        proto.String("example"),
+			MessageType:    []*descriptorpb.DescriptorProto{msgdesc},
+			Service:        []*descriptorpb.ServiceDescriptorProto{svc},
+			Options: &descriptorpb.FileOptions{
+				GoPackage: proto.String("github.com/grpc-ecosystem/grpc-gateway/runtime/internal/examplepb;example"),
+			},
+		},
+		GoPkg: descriptor.GoPackage{
+			Path: "example.com/path/to/example/example.pb",
+			Name: "example_pb",
+		},
+		Messages: []*descriptor.Message{msg},
+		Services: []*descriptor.Service{
+			{
+				ServiceDescriptorProto: svc,
+				Methods: []*descriptor.Method{
+					{
+						MethodDescriptorProto: meth,
+						RequestType:           msg,
+						ResponseType:          msg,
+						Bindings: []*descriptor.Binding{
+							{
+								HTTPMethod: "GET",
+								PathTmpl: httprule.Template{
+									Version:  1,
+									OpCodes:  []int{0, 0},
+									Template: "/v1/watch",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, spec := range []struct {
+		envelope     string
+		framing      string
+		wantRef      string
+		wantProduces []string
+	}{
+		{
+			envelope: "result",
+			framing:  "delimited",
+		},
+		{
+			envelope:     "none",
+			framing:      "ndjson",
+			wantRef:      "#/definitions/exampleExampleMessage",
+			wantProduces: []string{"application/x-ndjson"},
+		},
+		{
+			envelope:     "result",
+			framing:      "json-seq",
+			wantProduces: []string{"application/json-seq"},
+		},
+	} {
+		t.Run(spec.envelope+"/"+spec.framing, func(t *testing.T) {
+			reg := descriptor.NewRegistry()
+			if err := AddErrorDefs(reg); err != nil {
+				t.Fatalf("AddErrorDefs(%#v) failed with %v; want success", reg, err)
+			}
+			if err := reg.SetStreamEnvelope(spec.envelope); err != nil {
+				t.Fatalf("reg.SetStreamEnvelope(%q) failed with %v; want success", spec.envelope, err)
+			}
+			if err := reg.SetStreamFraming(spec.framing); err != nil {
+				t.Fatalf("reg.SetStreamFraming(%q) failed with %v; want success", spec.framing, err)
+			}
+			fileCL := crossLinkFixture(&file)
+			if err := reg.Load(reqFromFile(fileCL)); err != nil {
+				t.Fatalf("reg.Load(%#v) failed with %v; want success", file, err)
+			}
+			result, err := applyTemplate(param{File: fileCL, reg: reg})
+			if err != nil {
+				t.Fatalf("applyTemplate(%#v) failed with %v; want success", file, err)
+			}
+
+			op := result.getPathItemObject("/v1/watch").Get
+			schema := op.Responses["200"].Schema
+			if got := schema.Ref; got != spec.wantRef {
+				t.Errorf("response schema Ref = %q; want %q", got, spec.wantRef)
+			}
+			if spec.envelope == "result" {
+				if schema.Properties == nil || len(*schema.Properties) != 2 {
+					t.Errorf("response schema properties = %v; want result and error", schema.Properties)
+				}
+			}
+			if !reflect.DeepEqual(op.Produces, spec.wantProduces) {
+				t.Errorf("operation Produces = %v; want %v", op.Produces, spec.wantProduces)
+			}
+		})
+	}
+}
+
 func TestApplyTemplateRequestWithServerStreamingAndNoStandardErrors(t *testing.T) {
 	msgdesc := &descriptorpb.DescriptorProto{
 		Name: proto.String("ExampleMessage"),
@@ -5327,6 +5524,123 @@ func TestSchemaOfField(t *testing.T) {
 	}
 }
 
+// validateFieldOptions returns field options with a buf.validate.field
+// extension holding the rules of "kind", the number of its field in
+// buf.validate.FieldRules, encoded in "rules".
+func validateFieldOptions(kind protowire.Number, rules []byte) *descriptorpb.FieldOptions {
+	var field []byte
+	field = protowire.AppendTag(field, kind, protowire.BytesType)
+	field = protowire.AppendBytes(field, rules)
+	var b []byte
+	b = protowire.AppendTag(b, 1159, protowire.BytesType)
+	b = protowire.AppendBytes(b, field)
+	opts := &descriptorpb.FieldOptions{}
+	opts.ProtoReflect().SetUnknown(b)
+	return opts
+}
+
+func TestSchemaOfFieldValidateRules(t *testing.T) {
+	var stringRules []byte
+	stringRules = protowire.AppendTag(stringRules, 2, protowire.VarintType)
+	stringRules = protowire.AppendVarint(stringRules, 3)
+	stringRules = protowire.AppendTag(stringRules, 6, protowire.BytesType)
+	stringRules = protowire.AppendString(stringRules, "^[a-z]+$")
+	var int32Rules []byte
+	int32Rules = protowire.AppendTag(int32Rules, 4, protowire.VarintType)
+	int32Rules = protowire.AppendVarint(int32Rules, 1)
+	int32Rules = protowire.AppendTag(int32Rules, 3, protowire.VarintType)
+	int32Rules = protowire.AppendVarint(int32Rules, 100)
+	var repeatedRules []byte
+	repeatedRules = protowire.AppendTag(repeatedRules, 1, protowire.VarintType)
+	repeatedRules = protowire.AppendVarint(repeatedRules, 1)
+	repeatedRules = protowire.AppendTag(repeatedRules, 4, protowire.BytesType)
+	repeatedRules = protowire.AppendBytes(repeatedRules, protowire.AppendBytes(protowire.AppendTag(nil, 14, protowire.BytesType), stringRules))
+	var requiredRules []byte
+	requiredRules = protowire.AppendTag(requiredRules, 25, protowire.VarintType)
+	requiredRules = protowire.AppendVarint(requiredRules, 1)
+	requiredOptions := &descriptorpb.FieldOptions{}
+	requiredOptions.ProtoReflect().SetUnknown(protowire.AppendBytes(protowire.AppendTag(nil, 1159, protowire.BytesType), requiredRules))
+
+	tests := []struct {
+		name     string
+		field    *descriptorpb.FieldDescriptorProto
+		expected openapiSchemaObject
+	}{
+		{
+			name: "string",
+			field: &descriptorpb.FieldDescriptorProto{
+				Name:    proto.String("title"),
+				Type:    descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Options: validateFieldOptions(14, stringRules),
+			},
+			expected: openapiSchemaObject{
+				schemaCore: schemaCore{Type: "string"},
+				MinLength:  3,
+				Pattern:    "^[a-z]+$",
+			},
+		},
+		{
+			name: "int32",
+			field: &descriptorpb.FieldDescriptorProto{
+				Name:    proto.String("pages"),
+				Type:    descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(),
+				Options: validateFieldOptions(3, int32Rules),
+			},
+			expected: openapiSchemaObject{
+				schemaCore:       schemaCore{Type: "integer", Format: "int32"},
+				Minimum:          1,
+				ExclusiveMinimum: true,
+				Maximum:          100,
+			},
+		},
+		{
+			name: "repeated",
+			field: &descriptorpb.FieldDescriptorProto{
+				Name:    proto.String("tags"),
+				Type:    descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Label:   descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+				Options: validateFieldOptions(18, repeatedRules),
+			},
+			expected: openapiSchemaObject{
+				schemaCore: schemaCore{
+					Type:  "array",
+					Items: &openapiItemsObject{schemaCore: schemaCore{Type: "string"}, MinLength: 3, Pattern: "^[a-z]+$"},
+				},
+				MinItems: 1,
+			},
+		},
+		{
+			name: "required",
+			field: &descriptorpb.FieldDescriptorProto{
+				Name:    proto.String("name"),
+				Type:    descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Options: requiredOptions,
+			},
+			expected: openapiSchemaObject{
+				schemaCore: schemaCore{Type: "string"},
+				Required:   []string{"name"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := &descriptor.Field{
+				FieldDescriptorProto: tt.field,
+				Message: &descriptor.Message{
+					DescriptorProto: &descriptorpb.DescriptorProto{Name: proto.String("Book")},
+					File: &descriptor.File{
+						FileDescriptorProto: &descriptorpb.FileDescriptorProto{Package: proto.String("example")},
+					},
+				},
+			}
+			actual := schemaOfField(field, descriptor.NewRegistry(), make(refMap))
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expected schemaOfField(%v) = \n%#+v, actual: \n%#+v", field, tt.expected, actual)
+			}
+		})
+	}
+}
+
 func TestRenderMessagesAsDefinition(t *testing.T) {
 	jsonSchema := &openapi_options.JSONSchema{
 		Title:       "field title",
@@ -343,6 +343,15 @@ type openapiSchemaObject struct {
 
 	extensions []extension
 
+	// inputOnly and immutable record the INPUT_ONLY and IMMUTABLE field
+	// behaviors of the field the schema describes, which OpenAPI v2 has no
+	// keywords for.
+	inputOnly bool
+	immutable bool
+	// requestBody marks the definitions describing nothing but the body of a
+	// request.
+	requestBody bool
+
 	AllOf []allOfEntry `json:"allOf,omitempty" yaml:"allOf,omitempty"`
 }
 
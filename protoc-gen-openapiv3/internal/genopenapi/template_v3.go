@@ -15,7 +15,9 @@ import (
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/casing"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/descriptor"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/validate"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv3/options"
+	"google.golang.org/genproto/googleapis/api/annotations"
 	"google.golang.org/genproto/googleapis/api/visibility"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/descriptorpb"
@@ -576,6 +578,10 @@ func applyTemplateV3(param param) (OpenAPIV3Document, error) {
 		return OpenAPIV3Document{}, err
 	}
 	hoistSharedPathParameters(paths)
+	if param.reg.GetRequestSchemaVariants() {
+		renderRequestSchemaVariants(paths, schemas)
+	}
+	addMarshalerMediaTypes(paths, param.reg.GetMarshalerMediaTypes())
 	openapiDocument := OpenAPIV3Document{
 		OpenAPI: "3.0.0",
 		Info: &OpenAPIV3Info{
@@ -587,10 +593,250 @@ func applyTemplateV3(param param) (OpenAPIV3Document, error) {
 		},
 		Tags: tags,
 	}
+	// The paths are relative to the server URL, which is the path the
+	// gateway mounts its routes under.
+	if basePath := param.reg.GetBasePath(); basePath != "" {
+		openapiDocument.Servers = []OpenAPIV3Server{{URL: basePath}}
+	}
 
 	return openapiDocument, nil
 }
 
+// requestSchemaVariants derives the variants of component schemas describing
+// them in request bodies, where OUTPUT_ONLY fields are left out and, in PATCH
+// requests, IMMUTABLE fields are read-only.
+type requestSchemaVariants struct {
+	schemas map[string]*OpenAPIV3SchemaRef
+	// differs holds the components whose variants differ from them, and
+	// patchDiffers those whose variants differ in PATCH requests.
+	differs      map[string]bool
+	patchDiffers map[string]bool
+}
+
+// renderRequestSchemaVariants makes the request bodies of "paths" refer to
+// variants of the component schemas honoring the field behaviors of their
+// fields, and leaves INPUT_ONLY fields out of the components themselves,
+// which then only describe responses.
+func renderRequestSchemaVariants(paths OpenAPIV3Paths, schemas map[string]*OpenAPIV3SchemaRef) {
+	v := &requestSchemaVariants{schemas: schemas}
+	v.differs = v.findDiffering(func(p *OpenAPIV3Schema) bool {
+		return p.ReadOnly || p.WriteOnly
+	})
+	v.patchDiffers = v.findDiffering(func(p *OpenAPIV3Schema) bool {
+		return p.immutable
+	})
+	bases := slices.Collect(maps.Keys(schemas))
+
+	for _, item := range paths {
+		for _, op := range []*OpenAPIV3Operation{item.Get, item.Put, item.Post, item.Delete, item.Options, item.Head, item.Patch, item.Trace} {
+			if op == nil || op.RequestBody == nil || op.RequestBody.OpenAPIV3RequestBody == nil {
+				continue
+			}
+			for mediaType, content := range op.RequestBody.Content {
+				content.Schema = v.request(content.Schema, op == item.Patch, map[*OpenAPIV3Schema]bool{})
+				op.RequestBody.Content[mediaType] = content
+			}
+		}
+	}
+
+	for _, name := range bases {
+		schemaRef := schemas[name]
+		if schemaRef == nil || schemaRef.OpenAPIV3Schema == nil || schemaRef.Properties == nil {
+			continue
+		}
+		schema := *schemaRef.OpenAPIV3Schema
+		schema.Properties, schema.Required = filterSchemaProperties(schema.Properties, schema.Required, func(p *OpenAPIV3Schema) bool {
+			return !p.WriteOnly
+		})
+		schemas[name] = &OpenAPIV3SchemaRef{OpenAPIV3Schema: &schema}
+	}
+}
+
+// findDiffering returns the components with properties, at any depth, for
+// which "differs" holds, or referring to such components.
+func (v *requestSchemaVariants) findDiffering(differs func(*OpenAPIV3Schema) bool) map[string]bool {
+	found := map[string]bool{}
+	for changed := true; changed; {
+		changed = false
+		for name, schemaRef := range v.schemas {
+			if !found[name] && differsIn(schemaRef, differs, found, map[*OpenAPIV3Schema]bool{}) {
+				found[name] = true
+				changed = true
+			}
+		}
+	}
+	return found
+}
+
+func differsIn(s *OpenAPIV3SchemaRef, differs func(*OpenAPIV3Schema) bool, found map[string]bool, seen map[*OpenAPIV3Schema]bool) bool {
+	if s == nil {
+		return false
+	}
+	if s.Ref != "" {
+		return found[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+	if s.OpenAPIV3Schema == nil || seen[s.OpenAPIV3Schema] {
+		return false
+	}
+	seen[s.OpenAPIV3Schema] = true
+	for _, p := range s.Properties {
+		if p != nil && p.OpenAPIV3Schema != nil && differs(p.OpenAPIV3Schema) {
+			return true
+		}
+		if differsIn(p, differs, found, seen) {
+			return true
+		}
+	}
+	for _, sub := range subschemas(s.OpenAPIV3Schema) {
+		if differsIn(sub, differs, found, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// subschemas returns the schemas "s" is composed of, besides its properties.
+func subschemas(s *OpenAPIV3Schema) []*OpenAPIV3SchemaRef {
+	subs := slices.Concat(s.AllOf, s.OneOf, s.AnyOf)
+	if s.Items != nil {
+		subs = append(subs, s.Items)
+	}
+	if s.Not != nil {
+		subs = append(subs, s.Not)
+	}
+	if additional, ok := s.AdditionalProperties.(*OpenAPIV3SchemaRef); ok {
+		subs = append(subs, additional)
+	}
+	return subs
+}
+
+// request returns a copy of "s" describing it in request bodies, rendering
+// the variants of the components it refers to.
+func (v *requestSchemaVariants) request(s *OpenAPIV3SchemaRef, patch bool, seen map[*OpenAPIV3Schema]bool) *OpenAPIV3SchemaRef {
+	if s == nil {
+		return nil
+	}
+	if s.Ref != "" {
+		return &OpenAPIV3SchemaRef{Ref: v.requestRef(s.Ref, patch)}
+	}
+	if s.OpenAPIV3Schema == nil || seen[s.OpenAPIV3Schema] {
+		return s
+	}
+	seen[s.OpenAPIV3Schema] = true
+	defer delete(seen, s.OpenAPIV3Schema)
+
+	schema := *s.OpenAPIV3Schema
+	if schema.Properties != nil {
+		schema.Properties, schema.Required = filterSchemaProperties(schema.Properties, schema.Required, func(p *OpenAPIV3Schema) bool {
+			return !p.ReadOnly
+		})
+		for name, p := range schema.Properties {
+			p = v.request(p, patch, seen)
+			if patch && p != nil && p.OpenAPIV3Schema != nil && p.immutable {
+				immutable := *p.OpenAPIV3Schema
+				immutable.ReadOnly = true
+				p = &OpenAPIV3SchemaRef{OpenAPIV3Schema: &immutable}
+			}
+			schema.Properties[name] = p
+		}
+	}
+	schema.Items = v.request(schema.Items, patch, seen)
+	schema.Not = v.request(schema.Not, patch, seen)
+	for _, subs := range []*[]*OpenAPIV3SchemaRef{&schema.AllOf, &schema.OneOf, &schema.AnyOf} {
+		if *subs == nil {
+			continue
+		}
+		requests := make([]*OpenAPIV3SchemaRef, len(*subs))
+		for i, sub := range *subs {
+			requests[i] = v.request(sub, patch, seen)
+		}
+		*subs = requests
+	}
+	if additional, ok := schema.AdditionalProperties.(*OpenAPIV3SchemaRef); ok {
+		schema.AdditionalProperties = v.request(additional, patch, seen)
+	}
+	return &OpenAPIV3SchemaRef{OpenAPIV3Schema: &schema}
+}
+
+// requestRef returns the reference to the component describing "ref" in
+// request bodies.
+func (v *requestSchemaVariants) requestRef(ref string, patch bool) string {
+	name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+	if !ok {
+		return ref
+	}
+	if patch && !v.patchDiffers[name] {
+		patch = false
+	}
+	if !patch && !v.differs[name] {
+		return ref
+	}
+	variant := name + "Input"
+	if patch {
+		variant = name + "PatchInput"
+	}
+	if _, ok := v.schemas[variant]; !ok {
+		// Register the variant before rendering it, for recursive messages.
+		placeholder := &OpenAPIV3SchemaRef{}
+		v.schemas[variant] = placeholder
+		*placeholder = *v.request(v.schemas[name], patch, map[*OpenAPIV3Schema]bool{})
+	}
+	return "#/components/schemas/" + variant
+}
+
+// filterSchemaProperties returns the properties for which "keep" holds, and
+// the names in "required" of those.
+func filterSchemaProperties(props map[string]*OpenAPIV3SchemaRef, required []string, keep func(*OpenAPIV3Schema) bool) (map[string]*OpenAPIV3SchemaRef, []string) {
+	kept := make(map[string]*OpenAPIV3SchemaRef, len(props))
+	dropped := map[string]bool{}
+	for name, p := range props {
+		if p != nil && p.OpenAPIV3Schema != nil && !keep(p.OpenAPIV3Schema) {
+			dropped[name] = true
+			dropped[casing.JSONCamelCase(name)] = true
+			continue
+		}
+		kept[name] = p
+	}
+	var keptRequired []string
+	for _, name := range required {
+		if !dropped[name] {
+			keptRequired = append(keptRequired, name)
+		}
+	}
+	return kept, keptRequired
+}
+
+// addMarshalerMediaTypes documents every JSON request and response body of
+// paths as also available in mediaTypes, with the same schema.
+func addMarshalerMediaTypes(paths OpenAPIV3Paths, mediaTypes []string) {
+	if len(mediaTypes) == 0 {
+		return
+	}
+	addTo := func(content map[string]OpenAPIV3MediaType) {
+		jsonContent, ok := content["application/json"]
+		if !ok || jsonContent.Schema == nil {
+			return
+		}
+		for _, mediaType := range mediaTypes {
+			if _, ok := content[mediaType]; !ok {
+				content[mediaType] = OpenAPIV3MediaType{Schema: jsonContent.Schema}
+			}
+		}
+	}
+	for _, pathItem := range paths {
+		for _, op := range pathItem.operations() {
+			if op.RequestBody != nil && op.RequestBody.OpenAPIV3RequestBody != nil {
+				addTo(op.RequestBody.OpenAPIV3RequestBody.Content)
+			}
+			for _, response := range op.Responses {
+				if response.OpenAPIV3Response != nil {
+					addTo(response.OpenAPIV3Response.Content)
+				}
+			}
+		}
+	}
+}
+
 func resolveNames(param param) map[string]string {
 	typeNamesSet := map[string]struct{}{}
 	for _, message := range param.Messages {
@@ -677,6 +923,7 @@ func buildOpenAPIV3Paths(param param, resolvedNames map[string]string) (OpenAPIV
 
 	// Track registered path + method combinations to detect duplicates
 	registeredPaths := make(map[pathMethodKey]pathMethodSource)
+	resourceGetters := resourceGetOperations(param)
 
 	for _, svc := range param.Services {
 		if !isVisible(getServiceVisibilityOption(svc), param.reg) {
@@ -704,7 +951,7 @@ func buildOpenAPIV3Paths(param param, resolvedNames map[string]string) (OpenAPIV
 			for _, b := range bindings {
 				tags := []string{}
 				summary := m.GetName()
-				operationID := fmt.Sprintf("%s_%s", svc.GetName(), m.GetName())
+				operationID := methodOperationID(svc, m)
 				deprecated := false
 				responses := OpenAPIV3Responses{}
 				externalDocs := &OpenAPIV3ExternalDocs{}
@@ -718,9 +965,6 @@ func buildOpenAPIV3Paths(param param, resolvedNames map[string]string) (OpenAPIV
 						if operation.Summary != "" {
 							summary = operation.Summary
 						}
-						if operation.OperationId != "" {
-							operationID = operation.OperationId
-						}
 						if operation.Description != "" {
 							description = operation.Description
 						}
@@ -769,6 +1013,12 @@ func buildOpenAPIV3Paths(param param, resolvedNames map[string]string) (OpenAPIV
 				if responseBody != nil {
 					responseBody.OpenAPIV3Response.Content["application/json"].Schema.OpenAPIV3Schema.CamelCase()
 					applyResponseExamples(responseBody.OpenAPIV3Response, successResponseExamples)
+					if m.GetServerStreaming() && m.ResponseType.FQMN() != ".google.api.HttpBody" {
+						streamResponseContent(responseBody.OpenAPIV3Response, param.reg, errorSchemaRef)
+					}
+				}
+				if responseBody != nil && b.ResponseBody == nil && !m.GetServerStreaming() {
+					responseBody.OpenAPIV3Response.Links = resourceLinks(m.ResponseType, operationID, resourceGetters, param.reg)
 				}
 				responses[successStatusCode] = *responseBody
 				op := &OpenAPIV3Operation{
@@ -1217,6 +1467,37 @@ func buildResponseBody(binding *descriptor.Binding, registry *descriptor.Registr
 	}
 }
 
+// streamResponseContent describes the records of a server-streaming response.
+// Each message is wrapped as {"result": message} unless the stream_envelope
+// option is "none", and the records are documented under the media type of
+// the stream_framing option, if any.
+func streamResponseContent(resp *OpenAPIV3Response, reg *descriptor.Registry, errorSchemaRef string) {
+	content, ok := resp.Content["application/json"]
+	if !ok || content.Schema == nil {
+		return
+	}
+	if reg.GetStreamEnvelope() == "result" {
+		properties := map[string]*OpenAPIV3SchemaRef{"result": content.Schema}
+		if errorSchemaRef != "" {
+			properties["error"] = &OpenAPIV3SchemaRef{Ref: errorSchemaRef}
+		}
+		content.Schema = &OpenAPIV3SchemaRef{
+			OpenAPIV3Schema: &OpenAPIV3Schema{
+				Type:        "object",
+				Description: "A record of the response stream, holding either a result or the error ending the stream.",
+				Properties:  properties,
+			},
+		}
+	}
+	mediaType := reg.GetStreamMediaType()
+	if mediaType == "" {
+		mediaType = "application/json"
+	} else {
+		delete(resp.Content, "application/json")
+	}
+	resp.Content[mediaType] = content
+}
+
 // fieldDescription reads openapiv3_field.description from a proto field so it
 // can be surfaced on the OpenAPI parameter (not only on the schema).
 func fieldDescription(field *descriptor.Field) string {
@@ -1233,6 +1514,272 @@ func fieldDescription(field *descriptor.Field) string {
 	return ext.Description
 }
 
+// methodOperationID returns the operationId of the operations of "m", as
+// overridden by its openapiv3_operation option.
+func methodOperationID(svc *descriptor.Service, m *descriptor.Method) string {
+	if operation, ok := proto.GetExtension(m.Options, options.E_Openapiv3Operation).(*options.Operation); ok && operation.GetOperationId() != "" {
+		return operation.GetOperationId()
+	}
+	return fmt.Sprintf("%s_%s", svc.GetName(), m.GetName())
+}
+
+// resourceGetter is the operation getting the resources of a type, by their
+// name in the path parameter "param".
+type resourceGetter struct {
+	operationID string
+	param       string
+}
+
+// resourceGetOperations returns the operations getting resources by name,
+// such as GET /v1/{name=publishers/*/books/*}, indexed by the type of the
+// resources.
+func resourceGetOperations(param param) map[string]resourceGetter {
+	getters := make(map[string]resourceGetter)
+	for _, svc := range param.Services {
+		if !isVisible(getServiceVisibilityOption(svc), param.reg) {
+			continue
+		}
+		for _, m := range svc.Methods {
+			if !isVisible(getMethodVisibilityOption(m), param.reg) {
+				continue
+			}
+			for _, b := range m.Bindings {
+				if b.Index != 0 || b.HTTPMethod != "GET" || len(b.PathParams) != 1 {
+					continue
+				}
+				field := b.PathParams[0].Target
+				name := param.reg.LookupResourceName(field)
+				if name == nil || name.Parent {
+					continue
+				}
+				if _, ok := getters[name.Type]; ok {
+					continue
+				}
+				paramName := field.GetName()
+				if fc := getFieldConfiguration(param.reg, field); fc != nil && fc.GetPathParamName() != "" {
+					paramName = fc.GetPathParamName()
+				}
+				getters[name.Type] = resourceGetter{operationID: methodOperationID(svc, m), param: paramName}
+			}
+		}
+	}
+	return getters
+}
+
+// resourceLinks returns the links from the response "msg" of the operation
+// "operationID" to the operations getting the resources whose names its
+// fields hold, as declared by google.api.resource_reference annotations.
+func resourceLinks(msg *descriptor.Message, operationID string, getters map[string]resourceGetter, reg *descriptor.Registry) map[string]OpenAPIV3LinkRef {
+	if msg == nil {
+		return nil
+	}
+	var links map[string]OpenAPIV3LinkRef
+	for _, field := range msg.Fields {
+		if field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED || !isVisible(getFieldVisibilityOption(field), reg) {
+			continue
+		}
+		name := reg.LookupResourceName(field)
+		if name == nil || name.Parent {
+			continue
+		}
+		getter, ok := getters[name.Type]
+		if !ok || getter.operationID == operationID {
+			continue
+		}
+		if links == nil {
+			links = make(map[string]OpenAPIV3LinkRef)
+		}
+		// The link points to the property of the field in the response schema.
+		property := schemaPropertyName(field.GetName())
+		links[property] = OpenAPIV3LinkRef{
+			OpenAPIV3Link: &OpenAPIV3Link{
+				OperationID: getter.operationID,
+				Parameters:  map[string]interface{}{getter.param: "$response.body#/" + property},
+				Description: fmt.Sprintf("Gets the `%s` named by `%s`.", name.Type, property),
+			},
+		}
+	}
+	return links
+}
+
+// fieldBehavior returns the google.api.field_behavior annotation of "field".
+func fieldBehavior(field *descriptor.Field) []annotations.FieldBehavior {
+	if field == nil || field.Options == nil || !proto.HasExtension(field.Options, annotations.E_FieldBehavior) {
+		return nil
+	}
+	behaviors, _ := proto.GetExtension(field.Options, annotations.E_FieldBehavior).([]annotations.FieldBehavior)
+	return behaviors
+}
+
+// fieldRequired reports whether "field" is REQUIRED by its
+// google.api.field_behavior or its buf.validate rules.
+func fieldRequired(field *descriptor.Field) bool {
+	if slices.Contains(fieldBehavior(field), annotations.FieldBehavior_REQUIRED) {
+		return true
+	}
+	rules := validate.FieldRules(field.GetOptions())
+	return rules != nil && rules.Required
+}
+
+// requiredFieldNames returns the names of the required "fields".
+func requiredFieldNames(fields []*descriptor.Field) []string {
+	var names []string
+	for _, field := range fields {
+		if fieldRequired(field) {
+			names = append(names, field.GetName())
+		}
+	}
+	return names
+}
+
+// applyValidateRules returns the schema of "field" constrained by its
+// buf.validate rules on the length, pattern and values of strings, the
+// bounds of numbers and the number and uniqueness of items, without
+// overwriting the values set by its openapiv3_field option. The schemas are
+// copied, as they may be shared with components.
+func applyValidateRules(schemaRef *OpenAPIV3SchemaRef, field *descriptor.Field) *OpenAPIV3SchemaRef {
+	rules := validate.FieldRules(field.GetOptions())
+	if rules == nil || schemaRef == nil || schemaRef.OpenAPIV3Schema == nil {
+		return schemaRef
+	}
+	schema := *schemaRef.OpenAPIV3Schema
+	if schema.Type == "array" {
+		if rules.MinItems != nil && (schema.MinItems == nil || *schema.MinItems == 0) {
+			schema.MinItems = rules.MinItems
+		}
+		if rules.MaxItems != nil && schema.MaxItems == 0 {
+			schema.MaxItems = *rules.MaxItems
+		}
+		schema.UniqueItems = schema.UniqueItems || rules.UniqueItems
+		if rules.Items != nil && schema.Items != nil && schema.Items.OpenAPIV3Schema != nil {
+			items := *schema.Items.OpenAPIV3Schema
+			applyValueRules(&items, rules.Items)
+			schema.Items = &OpenAPIV3SchemaRef{OpenAPIV3Schema: &items}
+		}
+	} else {
+		applyValueRules(&schema, rules)
+	}
+	return &OpenAPIV3SchemaRef{OpenAPIV3Schema: &schema}
+}
+
+// applyValueRules constrains the schema "schema" of a single string or
+// number with "rules".
+func applyValueRules(schema *OpenAPIV3Schema, rules *validate.Rules) {
+	switch {
+	case schema.Type == "string" && schema.Format == "":
+		if rules.MinLen != nil && (schema.MinLength == nil || *schema.MinLength == 0) {
+			schema.MinLength = rules.MinLen
+		}
+		if rules.MaxLen != nil && schema.MaxLength == 0 {
+			schema.MaxLength = *rules.MaxLen
+		}
+		if rules.Pattern != "" && schema.Pattern == "" {
+			schema.Pattern = rules.Pattern
+		}
+		if len(rules.In) > 0 && len(schema.Enum) == 0 {
+			schema.Enum = rules.In
+		}
+	case schema.Type == "integer" || schema.Type == "number":
+		if schema.Minimum == nil {
+			switch {
+			case rules.GT != nil:
+				schema.Minimum, schema.ExclusiveMinimum = rules.GT, true
+			case rules.GTE != nil:
+				schema.Minimum = rules.GTE
+			}
+		}
+		// Maximum can't tell a bound of 0 apart from an unset one.
+		if schema.Maximum == 0 && !schema.ExclusiveMaximum {
+			switch {
+			case rules.LT != nil && *rules.LT != 0:
+				schema.Maximum, schema.ExclusiveMaximum = *rules.LT, true
+			case rules.LTE != nil:
+				schema.Maximum = *rules.LTE
+			}
+		}
+	}
+}
+
+// applyFieldBehavior returns the schema of "field" marked readOnly if it is
+// OUTPUT_ONLY and writeOnly if it is INPUT_ONLY, and documented if it is
+// IMMUTABLE. References are wrapped in allOf, as OpenAPI 3.0 ignores the
+// siblings of $ref, and other schemas are copied, as they may be shared with
+// components.
+func applyFieldBehavior(schemaRef *OpenAPIV3SchemaRef, field *descriptor.Field) *OpenAPIV3SchemaRef {
+	var outputOnly, inputOnly, immutable bool
+	for _, behavior := range fieldBehavior(field) {
+		switch behavior {
+		case annotations.FieldBehavior_OUTPUT_ONLY:
+			outputOnly = true
+		case annotations.FieldBehavior_INPUT_ONLY:
+			inputOnly = true
+		case annotations.FieldBehavior_IMMUTABLE:
+			immutable = true
+		}
+	}
+	if schemaRef == nil || !outputOnly && !inputOnly && !immutable {
+		return schemaRef
+	}
+	var schema OpenAPIV3Schema
+	switch {
+	case schemaRef.Ref != "":
+		schema.AllOf = []*OpenAPIV3SchemaRef{{Ref: schemaRef.Ref}}
+	case schemaRef.OpenAPIV3Schema != nil:
+		schema = *schemaRef.OpenAPIV3Schema
+	}
+	schema.ReadOnly = schema.ReadOnly || outputOnly
+	schema.WriteOnly = schema.WriteOnly || inputOnly
+	if immutable {
+		schema.immutable = true
+		const note = "Immutable: this field can't be changed once the resource is created."
+		if schema.Description == "" {
+			schema.Description = note
+		} else {
+			schema.Description += "\n\n" + note
+		}
+	}
+	return &OpenAPIV3SchemaRef{OpenAPIV3Schema: &schema}
+}
+
+// applyResourceName documents the resource names "field" holds, as declared
+// by google.api.resource and google.api.resource_reference annotations:
+// their patterns become a regular expression, or alternatives if the
+// split_resource_patterns option is set, and a description.
+func applyResourceName(schemaRef *OpenAPIV3SchemaRef, field *descriptor.Field, registry *descriptor.Registry) {
+	if schemaRef == nil || schemaRef.OpenAPIV3Schema == nil {
+		return
+	}
+	name := registry.LookupResourceName(field)
+	if name == nil || len(name.Patterns) == 0 {
+		return
+	}
+	schema := schemaRef.OpenAPIV3Schema
+	target := schema
+	if schema.Type == "array" && schema.Items != nil && schema.Items.OpenAPIV3Schema != nil {
+		target = schema.Items.OpenAPIV3Schema
+	}
+	split := registry.GetSplitResourcePatterns() && len(name.Patterns) > 1
+	switch {
+	case split && len(target.OneOf) == 0:
+		for _, pattern := range name.Patterns {
+			target.OneOf = append(target.OneOf, &OpenAPIV3SchemaRef{
+				OpenAPIV3Schema: &OpenAPIV3Schema{
+					Type:        "string",
+					Pattern:     descriptor.ResourcePatternRegexp([]string{pattern}),
+					Description: "`" + pattern + "`",
+				},
+			})
+		}
+	case !split && target.Pattern == "":
+		target.Pattern = descriptor.ResourcePatternRegexp(name.Patterns)
+	}
+	if schema.Description == "" {
+		schema.Description = name.Description(split)
+	} else {
+		schema.Description += "\n\n" + name.Description(split)
+	}
+}
+
 func buildPathParameters(binding *descriptor.Binding, registry *descriptor.Registry, resolvedNames map[string]string) []OpenAPIV3ParameterRef {
 	parameterRefs := []OpenAPIV3ParameterRef{}
 	for _, param := range binding.PathParams {
@@ -1391,6 +1938,9 @@ func queryParameterRequired(message *descriptor.Message, field *descriptor.Field
 	if message == nil || field == nil {
 		return false
 	}
+	if fieldRequired(field) {
+		return true
+	}
 	fieldNames := map[string]struct{}{
 		field.GetName():                       {},
 		casing.JSONCamelCase(field.GetName()): {},
@@ -1620,6 +2170,7 @@ func extractRequestBodyFieldCombinations(binding *descriptor.Binding, registry *
 	}
 	oneofGroups = visibleOneOfGroups(oneofGroups, registry)
 	allBodyFields := append(slices.Clone(fieldsNotPartOfOneofGroup), flattenOneOfGroups(oneofGroups)...)
+	requiredFields = mergeRequiredFields(requiredFields, requiredFieldNames(allBodyFields))
 	for _, field := range allBodyFields {
 		bodyField := protoField{
 			FullPathToField: append(prefix, *field.Name),
@@ -2258,7 +2809,7 @@ func buildSchemaFromFieldsWithReferences(
 		ExternalDocs:        externalDocs,
 		OpenAPIV3Extensions: extensions,
 		Properties:          properties,
-		Required:            filterRequired(requiredFields, properties),
+		Required:            filterRequired(mergeRequiredFields(requiredFields, requiredFieldNames(fields)), properties),
 	}
 	if len(properties) == 0 {
 		schema.AdditionalProperties = false
@@ -2292,7 +2843,7 @@ func buildSchemaFromFields(
 		ExternalDocs:        externalDocs,
 		OpenAPIV3Extensions: extensions,
 		Properties:          properties,
-		Required:            filterRequired(requiredFields, properties),
+		Required:            filterRequired(mergeRequiredFields(requiredFields, requiredFieldNames(fields)), properties),
 	}
 	if len(properties) == 0 {
 		schema.AdditionalProperties = false
@@ -2333,12 +2884,15 @@ func buildPropertySchemaWithReferencesFromField(field *descriptor.Field, registr
 		// (title/description/readOnly/deprecated/extensions) goes on the array,
 		// not on message/enum item $refs.
 		applyOpenAPIV3FieldAnnotationsToArray(schema, field)
-		return &OpenAPIV3SchemaRef{
+		schemaRef := &OpenAPIV3SchemaRef{
 			OpenAPIV3Schema: schema,
 		}
+		applyResourceName(schemaRef, field, registry)
+		return applyFieldBehavior(applyValidateRules(schemaRef, field), field)
 	}
 	propertySchema, _ := buildPropertySchemaWithReferencesFromFieldType(field, registry, resolvedNames)
-	return propertySchema
+	applyResourceName(propertySchema, field, registry)
+	return applyFieldBehavior(applyValidateRules(propertySchema, field), field)
 }
 
 func buildPropertySchemaWithReferencesFromFieldType(field *descriptor.Field, registry *descriptor.Registry, resolvedNames map[string]string) (*OpenAPIV3SchemaRef, RawExample) {
@@ -2848,12 +3402,15 @@ func buildPropertySchemaFromField(field *descriptor.Field, schemaMap map[string]
 		// (title/description/readOnly/deprecated/extensions) goes on the array,
 		// not on message/enum item $refs.
 		applyOpenAPIV3FieldAnnotationsToArray(schema, field)
-		return &OpenAPIV3SchemaRef{
+		schemaRef := &OpenAPIV3SchemaRef{
 			OpenAPIV3Schema: schema,
 		}
+		applyResourceName(schemaRef, field, registry)
+		return applyFieldBehavior(applyValidateRules(schemaRef, field), field)
 	}
 	propertySchema, _ := buildPropertySchemaFromFieldType(field, schemaMap, resolvedNames, registry)
-	return propertySchema
+	applyResourceName(propertySchema, field, registry)
+	return applyFieldBehavior(applyValidateRules(propertySchema, field), field)
 }
 func buildPropertySchemaFromFieldType(field *descriptor.Field, schemaMap map[string]*OpenAPIV3SchemaRef, resolvedNames map[string]string, registry *descriptor.Registry) (*OpenAPIV3SchemaRef, RawExample) {
 	var title string
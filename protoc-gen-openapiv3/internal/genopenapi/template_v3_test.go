@@ -3,6 +3,8 @@ package genopenapi
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"reflect"
 	"slices"
 	"sort"
 	"strings"
@@ -12,6 +14,7 @@ import (
 	options "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv3/options"
 	"google.golang.org/genproto/googleapis/api/visibility"
 	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/types/descriptorpb"
@@ -770,6 +773,98 @@ func Test_sanitizeUrlPath(t *testing.T) {
 	}
 }
 
+func TestAddMarshalerMediaTypes(t *testing.T) {
+	schema := &OpenAPIV3SchemaRef{Ref: "#/components/schemas/Example"}
+	paths := OpenAPIV3Paths{
+		"/v1/example": &OpenAPIV3PathItem{
+			Post: &OpenAPIV3Operation{
+				RequestBody: &OpenAPIV3RequestBodyRef{OpenAPIV3RequestBody: &OpenAPIV3RequestBody{
+					Content: map[string]OpenAPIV3MediaType{"application/json": {Schema: schema}},
+				}},
+				Responses: OpenAPIV3Responses{
+					"200": {OpenAPIV3Response: &OpenAPIV3Response{
+						Content: map[string]OpenAPIV3MediaType{"application/json": {Schema: schema}},
+					}},
+					"204":     {OpenAPIV3Response: &OpenAPIV3Response{}},
+					"default": {Ref: "#/components/responses/Error"},
+				},
+			},
+		},
+	}
+	addMarshalerMediaTypes(paths, []string{"application/cbor", "application/msgpack"})
+
+	op := paths["/v1/example"].Post
+	for name, content := range map[string]map[string]OpenAPIV3MediaType{
+		"request body": op.RequestBody.Content,
+		"200 response": op.Responses["200"].Content,
+	} {
+		var got []string
+		for mediaType, media := range content {
+			got = append(got, mediaType)
+			if media.Schema != schema {
+				t.Errorf("%s schema of %s = %+v; want %+v", name, mediaType, media.Schema, schema)
+			}
+		}
+		sort.Strings(got)
+		if want := []string{"application/cbor", "application/json", "application/msgpack"}; !slices.Equal(got, want) {
+			t.Errorf("%s media types = %v; want %v", name, got, want)
+		}
+	}
+	if content := op.Responses["204"].Content; len(content) != 0 {
+		t.Errorf("204 response content = %v; want none", content)
+	}
+}
+
+func TestStreamResponseContent(t *testing.T) {
+	const errorRef = "#/components/schemas/Error"
+	for _, spec := range []struct {
+		envelope      string
+		framing       string
+		wantMediaType string
+		wantWrapped   bool
+	}{
+		{envelope: "result", framing: "delimited", wantMediaType: "application/json", wantWrapped: true},
+		{envelope: "none", framing: "ndjson", wantMediaType: "application/x-ndjson"},
+		{envelope: "result", framing: "json-seq", wantMediaType: "application/json-seq", wantWrapped: true},
+	} {
+		t.Run(spec.envelope+"/"+spec.framing, func(t *testing.T) {
+			reg := descriptor.NewRegistry()
+			if err := reg.SetStreamEnvelope(spec.envelope); err != nil {
+				t.Fatalf("reg.SetStreamEnvelope(%q) failed with %v; want success", spec.envelope, err)
+			}
+			if err := reg.SetStreamFraming(spec.framing); err != nil {
+				t.Fatalf("reg.SetStreamFraming(%q) failed with %v; want success", spec.framing, err)
+			}
+			schema := &OpenAPIV3SchemaRef{Ref: "#/components/schemas/Example"}
+			resp := &OpenAPIV3Response{
+				Content: map[string]OpenAPIV3MediaType{"application/json": {Schema: schema}},
+			}
+			streamResponseContent(resp, reg, errorRef)
+
+			if len(resp.Content) != 1 {
+				t.Fatalf("response content = %v; want only %s", resp.Content, spec.wantMediaType)
+			}
+			got, ok := resp.Content[spec.wantMediaType]
+			if !ok {
+				t.Fatalf("response content = %v; want %s", resp.Content, spec.wantMediaType)
+			}
+			if !spec.wantWrapped {
+				if got.Schema != schema {
+					t.Errorf("record schema = %+v; want %+v", got.Schema, schema)
+				}
+				return
+			}
+			properties := got.Schema.OpenAPIV3Schema.Properties
+			if properties["result"] != schema {
+				t.Errorf("result schema = %+v; want %+v", properties["result"], schema)
+			}
+			if properties["error"] == nil || properties["error"].Ref != errorRef {
+				t.Errorf("error schema = %+v; want a reference to %s", properties["error"], errorRef)
+			}
+		})
+	}
+}
+
 func TestApplyPathParamRenames(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1547,6 +1642,13 @@ proto_file: {
 // pipeline (merge + path sort + JSON encode), returning the merged spec bytes.
 // A fresh registry is used per call so the whole pipeline is re-exercised.
 func generateMergedSpec(t *testing.T, reqText string) string {
+	t.Helper()
+	return generateMergedSpecWith(t, reqText, nil)
+}
+
+// generateMergedSpecWith is generateMergedSpec with the registry further
+// configured by "configure", if not nil.
+func generateMergedSpecWith(t *testing.T, reqText string, configure func(*descriptor.Registry)) string {
 	t.Helper()
 	var req pluginpb.CodeGeneratorRequest
 	if err := prototext.Unmarshal([]byte(reqText), &req); err != nil {
@@ -1555,6 +1657,9 @@ func generateMergedSpec(t *testing.T, reqText string) string {
 	reg := descriptor.NewRegistry()
 	reg.SetAllowMerge(true)
 	reg.SetMergeFileName("apidocs")
+	if configure != nil {
+		configure(reg)
+	}
 	// AddErrorDefs registers google.rpc.Status (used for error responses) and must
 	// run before Load, mirroring the plugin's main().
 	if err := AddErrorDefs(reg); err != nil {
@@ -5340,3 +5445,411 @@ func TestHoistSharedPathParameters(t *testing.T) {
 		}
 	})
 }
+
+const resourceSpecRequest = `
+file_to_generate: "library/v1/library.proto"
+proto_file: {
+  name: "library/v1/library.proto"
+  package: "library.v1"
+  message_type: {
+    name: "Book"
+    field: { name: "name" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "name" }
+    field: {
+      name: "shelf_name" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "shelfRef"
+      options: { [google.api.resource_reference]: { type: "library.example.com/Shelf" } }
+    }
+    options: {
+      [google.api.resource]: {
+        type: "library.example.com/Book"
+        pattern: "publishers/{publisher}/books/{book}"
+        pattern: "authors/{author}/books/{book}"
+      }
+    }
+  }
+  message_type: {
+    name: "Shelf"
+    field: { name: "name" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "name" }
+    options: { [google.api.resource]: { type: "library.example.com/Shelf" pattern: "shelves/{shelf}" } }
+  }
+  message_type: {
+    name: "GetBookRequest"
+    field: {
+      name: "name" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "name"
+      options: { [google.api.resource_reference]: { type: "library.example.com/Book" } }
+    }
+  }
+  message_type: {
+    name: "GetShelfRequest"
+    field: {
+      name: "name" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "name"
+      options: { [google.api.resource_reference]: { type: "library.example.com/Shelf" } }
+    }
+  }
+  service: {
+    name: "Library"
+    method: { name: "GetBook" input_type: ".library.v1.GetBookRequest" output_type: ".library.v1.Book" options: { [google.api.http]: { get: "/v1/{name=publishers/*/books/*}" } } }
+    method: { name: "GetShelf" input_type: ".library.v1.GetShelfRequest" output_type: ".library.v1.Shelf" options: { [google.api.http]: { get: "/v1/{name=shelves/*}" } } }
+  }
+  options: { go_package: "example.com/library/v1;libraryv1" }
+  syntax: "proto3"
+}
+`
+
+func TestGeneratedSpecResourceNames(t *testing.T) {
+	var spec struct {
+		Paths map[string]struct {
+			Get struct {
+				Parameters []struct {
+					Name   string `json:"name"`
+					Schema struct {
+						Pattern string `json:"pattern"`
+					} `json:"schema"`
+				} `json:"parameters"`
+				Responses map[string]struct {
+					Links map[string]OpenAPIV3Link `json:"links"`
+				} `json:"responses"`
+			} `json:"get"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]OpenAPIV3Schema `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal([]byte(generateMergedSpec(t, resourceSpecRequest)), &spec); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	book := spec.Components.Schemas["Book"]
+	if book.Properties == nil {
+		t.Fatalf("schemas = %v; want Book", spec.Components.Schemas)
+	}
+	name := book.Properties["name"]
+	if want := "^(publishers/[^/]+/books/[^/]+|authors/[^/]+/books/[^/]+)$"; name.Pattern != want {
+		t.Errorf("Book.name.pattern = %q; want %q", name.Pattern, want)
+	}
+	if want := "The resource name of a `library.example.com/Book`, formatted as `publishers/{publisher}/books/{book}` or `authors/{author}/books/{book}`."; name.Description != want {
+		t.Errorf("Book.name.description = %q; want %q", name.Description, want)
+	}
+	if got, want := book.Properties["shelfName"].Pattern, "^shelves/[^/]+$"; got != want {
+		t.Errorf("Book.shelfName.pattern = %q; want %q", got, want)
+	}
+
+	get := spec.Paths["/v1/{name=publishers/*/books/*}"].Get
+	if len(get.Parameters) != 1 || get.Parameters[0].Schema.Pattern != name.Pattern {
+		t.Errorf("GetBook parameters = %+v; want name with pattern %q", get.Parameters, name.Pattern)
+	}
+
+	links := get.Responses["200"].Links
+	want := map[string]OpenAPIV3Link{
+		"shelfName": {
+			OperationID: "Library_GetShelf",
+			Parameters:  map[string]interface{}{"name": "$response.body#/shelfName"},
+			Description: "Gets the `library.example.com/Shelf` named by `shelfName`.",
+		},
+	}
+	if !reflect.DeepEqual(links, want) {
+		t.Errorf("links = %+v; want %+v", links, want)
+	}
+}
+
+func TestGeneratedSpecSplitResourcePatterns(t *testing.T) {
+	var spec struct {
+		Components struct {
+			Schemas map[string]OpenAPIV3Schema `json:"schemas"`
+		} `json:"components"`
+	}
+	content := generateMergedSpecWith(t, resourceSpecRequest, func(reg *descriptor.Registry) {
+		reg.SetSplitResourcePatterns(true)
+	})
+	if err := json.Unmarshal([]byte(content), &spec); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	name := spec.Components.Schemas["Book"].Properties["name"]
+	if name == nil || name.OpenAPIV3Schema == nil {
+		t.Fatalf("schemas = %v; want Book.name", spec.Components.Schemas)
+	}
+	if name.Pattern != "" || len(name.OneOf) != 2 {
+		t.Fatalf("Book.name = %+v; want 2 alternatives", name.OpenAPIV3Schema)
+	}
+	for i, want := range []string{"^publishers/[^/]+/books/[^/]+$", "^authors/[^/]+/books/[^/]+$"} {
+		if got := name.OneOf[i].Pattern; got != want {
+			t.Errorf("Book.name.oneOf[%d].pattern = %q; want %q", i, got, want)
+		}
+	}
+	if !strings.Contains(name.Description, "in one of the formats:") {
+		t.Errorf("Book.name.description = %q; want the formats listed", name.Description)
+	}
+}
+
+const fieldBehaviorSpecRequest = `
+file_to_generate: "library/v1/library.proto"
+proto_file: {
+  name: "library/v1/library.proto"
+  package: "library.v1"
+  message_type: {
+    name: "Book"
+    field: { name: "name" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "name" }
+    field: {
+      name: "title" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "title"
+      options: { [google.api.field_behavior]: REQUIRED }
+    }
+    field: {
+      name: "create_time" number: 3 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "createTime"
+      options: { [google.api.field_behavior]: OUTPUT_ONLY [google.api.field_behavior]: REQUIRED }
+    }
+    field: {
+      name: "isbn" number: 4 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "isbn"
+      options: { [google.api.field_behavior]: IMMUTABLE }
+    }
+    field: {
+      name: "access_code" number: 5 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "accessCode"
+      options: { [google.api.field_behavior]: INPUT_ONLY }
+    }
+    field: { name: "author" number: 6 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".library.v1.Author" json_name: "author" }
+  }
+  message_type: {
+    name: "Author"
+    field: { name: "display_name" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "displayName" }
+    field: {
+      name: "uid" number: 2 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "uid"
+      options: { [google.api.field_behavior]: OUTPUT_ONLY }
+    }
+  }
+  message_type: {
+    name: "CreateBookRequest"
+    field: { name: "parent" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "parent" }
+    field: { name: "book" number: 2 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".library.v1.Book" json_name: "book" }
+  }
+  message_type: {
+    name: "UpdateBookRequest"
+    field: { name: "book" number: 1 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".library.v1.Book" json_name: "book" }
+  }
+  service: {
+    name: "Library"
+    method: { name: "CreateBook" input_type: ".library.v1.CreateBookRequest" output_type: ".library.v1.Book" options: { [google.api.http]: { post: "/v1/{parent=publishers/*}/books" body: "book" } } }
+    method: { name: "UpdateBook" input_type: ".library.v1.UpdateBookRequest" output_type: ".library.v1.Book" options: { [google.api.http]: { patch: "/v1/{book.name=publishers/*/books/*}" body: "book" } } }
+  }
+  options: { go_package: "example.com/library/v1;libraryv1" }
+  syntax: "proto3"
+}
+`
+
+// fieldBehaviorSpec is the part of the spec of fieldBehaviorSpecRequest the
+// field behavior tests look at.
+type fieldBehaviorSpec struct {
+	Paths map[string]map[string]struct {
+		RequestBody struct {
+			Content map[string]struct {
+				Schema OpenAPIV3Schema `json:"schema"`
+			} `json:"content"`
+		} `json:"requestBody"`
+	} `json:"paths"`
+	Components struct {
+		Schemas map[string]OpenAPIV3Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+func (s fieldBehaviorSpec) requestBody(t *testing.T, method string) OpenAPIV3Schema {
+	t.Helper()
+	for _, item := range s.Paths {
+		if op, ok := item[method]; ok {
+			return op.RequestBody.Content["application/json"].Schema
+		}
+	}
+	t.Fatalf("paths = %v; want a %s operation", s.Paths, method)
+	return OpenAPIV3Schema{}
+}
+
+func TestGeneratedSpecFieldBehavior(t *testing.T) {
+	var spec fieldBehaviorSpec
+	if err := json.Unmarshal([]byte(generateMergedSpec(t, fieldBehaviorSpecRequest)), &spec); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	book := spec.Components.Schemas["Book"]
+	if book.Properties == nil {
+		t.Fatalf("schemas = %v; want Book", spec.Components.Schemas)
+	}
+	if !book.Properties["createTime"].ReadOnly {
+		t.Errorf("Book.createTime.readOnly = false; want true")
+	}
+	if !book.Properties["accessCode"].WriteOnly {
+		t.Errorf("Book.accessCode.writeOnly = false; want true")
+	}
+	if want := "Immutable: this field can't be changed once the resource is created."; book.Properties["isbn"].Description != want {
+		t.Errorf("Book.isbn.description = %q; want %q", book.Properties["isbn"].Description, want)
+	}
+	if want := []string{"title", "createTime"}; !reflect.DeepEqual(book.Required, want) {
+		t.Errorf("Book.required = %v; want %v", book.Required, want)
+	}
+	if want := []string{"title", "createTime"}; !reflect.DeepEqual(spec.requestBody(t, "post").Required, want) {
+		t.Errorf("CreateBook body required = %v; want %v", spec.requestBody(t, "post").Required, want)
+	}
+}
+
+func TestGeneratedSpecRequestSchemaVariants(t *testing.T) {
+	var spec fieldBehaviorSpec
+	content := generateMergedSpecWith(t, fieldBehaviorSpecRequest, func(reg *descriptor.Registry) {
+		reg.SetRequestSchemaVariants(true)
+	})
+	if err := json.Unmarshal([]byte(content), &spec); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	create := spec.requestBody(t, "post")
+	if _, ok := create.Properties["createTime"]; ok {
+		t.Errorf("CreateBook body has createTime; want it left out")
+	}
+	if _, ok := create.Properties["accessCode"]; !ok {
+		t.Errorf("CreateBook body has no accessCode; want it")
+	}
+	if create.Properties["isbn"].ReadOnly {
+		t.Errorf("CreateBook body isbn.readOnly = true; want false")
+	}
+	if want := []string{"title"}; !reflect.DeepEqual(create.Required, want) {
+		t.Errorf("CreateBook body required = %v; want %v", create.Required, want)
+	}
+	if got, want := create.Properties["author"].Ref, "#/components/schemas/AuthorInput"; got != want {
+		t.Errorf("CreateBook body author = %q; want %q", got, want)
+	}
+
+	update := spec.requestBody(t, "patch")
+	if !update.Properties["isbn"].ReadOnly {
+		t.Errorf("UpdateBook body isbn.readOnly = false; want true")
+	}
+
+	if _, ok := spec.Components.Schemas["AuthorInput"].Properties["uid"]; ok {
+		t.Errorf("AuthorInput has uid; want it left out")
+	}
+	book := spec.Components.Schemas["Book"]
+	if _, ok := book.Properties["accessCode"]; ok {
+		t.Errorf("Book has accessCode; want it left out of responses")
+	}
+	if want := []string{"title", "createTime"}; !reflect.DeepEqual(book.Required, want) {
+		t.Errorf("Book.required = %v; want %v", book.Required, want)
+	}
+}
+
+// validateRulesField returns a field with a buf.validate.field extension
+// holding the rules of "kind", the number of its field in
+// buf.validate.FieldRules, encoded in "rules".
+func validateRulesField(kind protowire.Number, rules []byte) *descriptor.Field {
+	var field []byte
+	field = protowire.AppendTag(field, kind, protowire.BytesType)
+	field = protowire.AppendBytes(field, rules)
+	var b []byte
+	b = protowire.AppendTag(b, 1159, protowire.BytesType)
+	b = protowire.AppendBytes(b, field)
+	opts := &descriptorpb.FieldOptions{}
+	opts.ProtoReflect().SetUnknown(b)
+	return &descriptor.Field{FieldDescriptorProto: &descriptorpb.FieldDescriptorProto{Name: proto.String("field"), Options: opts}}
+}
+
+func TestApplyValidateRules(t *testing.T) {
+	var stringRules []byte
+	stringRules = protowire.AppendTag(stringRules, 2, protowire.VarintType)
+	stringRules = protowire.AppendVarint(stringRules, 3)
+	stringRules = protowire.AppendTag(stringRules, 6, protowire.BytesType)
+	stringRules = protowire.AppendString(stringRules, "^[a-z]+$")
+	var int32Rules []byte
+	int32Rules = protowire.AppendTag(int32Rules, 4, protowire.VarintType)
+	int32Rules = protowire.AppendVarint(int32Rules, 0)
+	int32Rules = protowire.AppendTag(int32Rules, 3, protowire.VarintType)
+	int32Rules = protowire.AppendVarint(int32Rules, 100)
+	var repeatedRules []byte
+	repeatedRules = protowire.AppendTag(repeatedRules, 1, protowire.VarintType)
+	repeatedRules = protowire.AppendVarint(repeatedRules, 1)
+	repeatedRules = protowire.AppendTag(repeatedRules, 3, protowire.VarintType)
+	repeatedRules = protowire.AppendVarint(repeatedRules, 1)
+	var itemRules []byte
+	itemRules = protowire.AppendTag(itemRules, 14, protowire.BytesType)
+	itemRules = protowire.AppendBytes(itemRules, stringRules)
+	repeatedRules = protowire.AppendTag(repeatedRules, 4, protowire.BytesType)
+	repeatedRules = protowire.AppendBytes(repeatedRules, itemRules)
+
+	zero, one, three := uint64(0), uint64(1), uint64(3)
+	zeroBound := float64(0)
+	tests := []struct {
+		name   string
+		field  *descriptor.Field
+		schema OpenAPIV3Schema
+		want   OpenAPIV3Schema
+	}{
+		{
+			name:   "string",
+			field:  validateRulesField(14, stringRules),
+			schema: OpenAPIV3Schema{Type: "string"},
+			want:   OpenAPIV3Schema{Type: "string", MinLength: &three, Pattern: "^[a-z]+$"},
+		},
+		{
+			name:   "annotated string",
+			field:  validateRulesField(14, stringRules),
+			schema: OpenAPIV3Schema{Type: "string", Pattern: "^[A-Z]+$"},
+			want:   OpenAPIV3Schema{Type: "string", MinLength: &three, Pattern: "^[A-Z]+$"},
+		},
+		{
+			name:   "int32",
+			field:  validateRulesField(3, int32Rules),
+			schema: OpenAPIV3Schema{Type: "integer", Format: "int32"},
+			want:   OpenAPIV3Schema{Type: "integer", Format: "int32", Minimum: &zeroBound, ExclusiveMinimum: true, Maximum: 100},
+		},
+		{
+			name:   "repeated",
+			field:  validateRulesField(18, repeatedRules),
+			schema: OpenAPIV3Schema{Type: "array", MinItems: &zero, Items: &OpenAPIV3SchemaRef{OpenAPIV3Schema: &OpenAPIV3Schema{Type: "string"}}},
+			want:   OpenAPIV3Schema{Type: "array", MinItems: &one, UniqueItems: true, Items: &OpenAPIV3SchemaRef{OpenAPIV3Schema: &OpenAPIV3Schema{Type: "string", MinLength: &three, Pattern: "^[a-z]+$"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.schema
+			got := applyValidateRules(&OpenAPIV3SchemaRef{OpenAPIV3Schema: &tt.schema}, tt.field)
+			if !reflect.DeepEqual(*got.OpenAPIV3Schema, tt.want) {
+				t.Errorf("applyValidateRules() = %+v; want %+v", *got.OpenAPIV3Schema, tt.want)
+			}
+			if !reflect.DeepEqual(tt.schema, original) {
+				t.Errorf("applyValidateRules() modified its input to %+v", tt.schema)
+			}
+		})
+	}
+}
+
+func TestFieldRequiredByValidateRules(t *testing.T) {
+	var b []byte
+	b = protowire.AppendTag(b, 25, protowire.VarintType)
+	b = protowire.AppendVarint(b, 1)
+	opts := &descriptorpb.FieldOptions{}
+	opts.ProtoReflect().SetUnknown(protowire.AppendBytes(protowire.AppendTag(nil, 1159, protowire.BytesType), b))
+	fields := []*descriptor.Field{
+		{FieldDescriptorProto: &descriptorpb.FieldDescriptorProto{Name: proto.String("name"), Options: opts}},
+		{FieldDescriptorProto: &descriptorpb.FieldDescriptorProto{Name: proto.String("title")}},
+	}
+	if got, want := requiredFieldNames(fields), []string{"name"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("requiredFieldNames() = %v; want %v", got, want)
+	}
+}
+
+func TestBasePathServers(t *testing.T) {
+	for _, spec := range []struct {
+		basePath string
+		want     []OpenAPIV3Server
+	}{
+		{basePath: "", want: nil},
+		{basePath: "api/v2/", want: []OpenAPIV3Server{{URL: "/api/v2"}}},
+	} {
+		content := generateMergedSpecWith(t, deterministicSpecRequest, func(reg *descriptor.Registry) {
+			reg.SetBasePath(spec.basePath)
+		})
+		var doc OpenAPIV3Document
+		if err := json.Unmarshal([]byte(content), &doc); err != nil {
+			t.Fatalf("json.Unmarshal() failed with %v", err)
+		}
+		if !reflect.DeepEqual(doc.Servers, spec.want) {
+			t.Errorf("servers with base path %q = %v; want %v", spec.basePath, doc.Servers, spec.want)
+		}
+		// The paths stay relative to the server URL.
+		if _, ok := doc.Paths["/v1/pets"]; !ok {
+			t.Errorf("paths with base path %q = %v; want /v1/pets", spec.basePath, slices.Collect(maps.Keys(doc.Paths)))
+		}
+	}
+}
@@ -286,6 +286,10 @@ type OpenAPIV3Schema struct {
 	Example              RawExample                     `json:"example,omitempty" yaml:"example,omitempty"`
 	Deprecated           bool                           `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 	OpenAPIV3Extensions  `json:"-" yaml:"-"`
+
+	// immutable records the IMMUTABLE field behavior of the field the schema
+	// describes, which OpenAPI has no keyword for.
+	immutable bool
 }
 
 func (s *OpenAPIV3SchemaRef) MarshalJSON() ([]byte, error) {
@@ -303,6 +307,12 @@ func (s *OpenAPIV3SchemaRef) MarshalJSON() ([]byte, error) {
 	return json.Marshal(schema)
 }
 
+// schemaPropertyName returns the name of the property of a schema whose key is
+// "key", the proto name of a field, once CamelCase has been applied to it.
+func schemaPropertyName(key string) string {
+	return casing.JSONCamelCase(key)
+}
+
 func (s *OpenAPIV3Schema) CamelCase() {
 	if s == nil {
 		return
@@ -326,19 +336,19 @@ func (s *OpenAPIV3Schema) CamelCase() {
 		s.Not.CamelCase()
 	}
 	if s.Discriminator != nil {
-		s.Discriminator.PropertyName = casing.JSONCamelCase(s.Discriminator.PropertyName)
+		s.Discriminator.PropertyName = schemaPropertyName(s.Discriminator.PropertyName)
 	}
 	newProperties := make(map[string]*OpenAPIV3SchemaRef)
 	newRequiredFields := make([]string, 0, len(s.Required))
 	for _, requiredField := range s.Required {
-		newRequiredFields = append(newRequiredFields, casing.JSONCamelCase(requiredField))
+		newRequiredFields = append(newRequiredFields, schemaPropertyName(requiredField))
 	}
 	for k, v := range s.Properties {
 		if v != nil {
 			// Recursively call CamelCase on the nested schema
 			v.CamelCase()
 		}
-		newProperties[casing.JSONCamelCase(k)] = v
+		newProperties[schemaPropertyName(k)] = v
 	}
 	if s.Items != nil {
 		s.Items.CamelCase()
@@ -373,8 +383,8 @@ type OpenAPIV3Example struct {
 }
 
 type OpenAPIV3LinkRef struct {
-	Ref  string         `json:"$ref,omitempty" yaml:"$ref,omitempty"`
-	Link *OpenAPIV3Link `json:"-" yaml:"-"`
+	Ref            string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	*OpenAPIV3Link `json:",omitempty" yaml:",omitempty"`
 }
 
 type OpenAPIV3Link struct {
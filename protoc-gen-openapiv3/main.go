@@ -41,6 +41,7 @@ var (
 	proto3OptionalNullable         = flag.Bool("proto3_optional_nullable", false, "whether Proto3 Optional fields should be marked as x-nullable")
 	openAPIConfiguration           = flag.String("openapi_configuration", "", "path to file which describes the OpenAPI Configuration in YAML format")
 	generateUnboundMethods         = flag.Bool("generate_unbound_methods", false, "generate swagger metadata even for RPC methods that have no HttpRule annotation")
+	unboundMethodBindings          = flag.String("unbound_method_bindings", "grpc", "bindings of the RPC methods generated by `generate_unbound_methods`. Allowed values are `grpc`, binding POST /<package>.<Service>/<Method>, and `aip`, inferring RESTful bindings from AIP resource-oriented design conventions")
 	recursiveDepth                 = flag.Int("recursive-depth", 1000, "maximum recursion count allowed for a field type")
 	omitEnumDefaultValue           = flag.Bool("omit_enum_default_value", false, "if set, omit default enum value")
 	outputFormat                   = flag.String("output_format", string(genopenapi.FormatJSON), fmt.Sprintf("output content format. Allowed values are: `%s`, `%s`", genopenapi.FormatJSON, genopenapi.FormatYAML))
@@ -54,6 +55,15 @@ var (
 	expandSlashedPathPatterns      = flag.Bool("expand_slashed_path_patterns", false, "if set, expands path parameters with URI sub-paths into the URI. For example, \"/v1/{name=projects/*}/resource\" becomes \"/v1/projects/{project}/resource\".")
 	useProto3FieldSemantics        = flag.Bool("use_proto3_field_semantics", false, "if set, uses proto3 field semantics for the OpenAPI schema. This means that fields are required by default.")
 	generateXGoType                = flag.Bool("generate_x_go_type", false, "if set, generates x-go-type extension using the go_package option from proto files")
+	splitResourcePatterns          = flag.Bool("split_resource_patterns", false, "if set, documents the patterns of resource names with several google.api.resource patterns as alternatives")
+	requestSchemaVariants          = flag.Bool("request_schema_variants", false, "if set, describes request bodies with variants of the messages leaving out OUTPUT_ONLY fields and showing IMMUTABLE fields as read-only in PATCH requests, and leaves INPUT_ONLY fields out of responses")
+	marshalerMediaTypes            = utilities.StringArrayFlag(flag.CommandLine, "marshaler_media_types", "list of media types, such as `application/cbor` or `application/msgpack`, which the gateway is configured to marshal besides `application/json`. They are listed as alternatives wherever a JSON request or response body is documented. Repeat this option to supply multiple values.")
+	basePath                       = flag.String("base_path", "", "path the gateway mounts its routes under, as set by `runtime.WithBasePath`, such as `/api/v2`")
+	streamEnvelope                 = flag.String("stream_envelope", "result", "envelope the gateway wraps the records of response streams in, as set by `runtime.WithStreamEnvelope`. Allowed values are `result` and `none`")
+	streamFraming                  = flag.String("stream_framing", "delimited", "framing of response streams, as set by `runtime.WithStreamFraming`. Allowed values are `delimited`, `ndjson` and `json-seq`")
+	lintOnly                       = flag.Bool("lint_only", false, "if set, only checks the google.api.http bindings, and reports their problems instead of generating OpenAPI files")
+	lintSeverity                   = flag.String("lint_severity", "warning", "minimum severity of the problems reported by `lint_only`. Allowed values are `info`, `warning` and `error`")
+	lintFormat                     = flag.String("lint_format", "text", "format of the problems reported by `lint_only`. Allowed values are `text`, failing with an error listing the problems if any of them is an error and printing them to stderr otherwise, and `json`, generating a lint.json report")
 
 	_ = flag.Bool("logtostderr", false, "Legacy glog compatibility. This flag is a no-op, you can safely remove it")
 )
@@ -181,11 +191,28 @@ func main() {
 	reg.SetEnableRpcDeprecation(*enableRpcDeprecation)
 	reg.SetExpandSlashedPathPatterns(*expandSlashedPathPatterns)
 	reg.SetGenerateXGoType(*generateXGoType)
+	reg.SetSplitResourcePatterns(*splitResourcePatterns)
+	reg.SetRequestSchemaVariants(*requestSchemaVariants)
+	reg.SetMarshalerMediaTypes(*marshalerMediaTypes)
+	reg.SetBasePath(*basePath)
+	reg.SetLintOnly(*lintOnly)
 
 	if err := reg.SetRepeatedPathParamSeparator(*repeatedPathParamSeparator); err != nil {
 		emitError(err)
 		return
 	}
+	if err := reg.SetStreamEnvelope(*streamEnvelope); err != nil {
+		emitError(err)
+		return
+	}
+	if err := reg.SetStreamFraming(*streamFraming); err != nil {
+		emitError(err)
+		return
+	}
+	if err := reg.SetUnboundMethodBindings(*unboundMethodBindings); err != nil {
+		emitError(err)
+		return
+	}
 	for k, v := range pkgMap {
 		reg.AddPkgMap(k, v)
 	}
@@ -215,6 +242,11 @@ func main() {
 		return
 	}
 
+	if *lintOnly {
+		emitLint(reg)
+		return
+	}
+
 	if *openAPIConfiguration != "" {
 		if err := reg.LoadOpenAPIConfigFromYAML(*openAPIConfiguration); err != nil {
 			emitError(err)
@@ -252,6 +284,23 @@ func emitFiles(out []*descriptor.ResponseFile) {
 	emitResp(resp)
 }
 
+// emitLint emits the problems of the bindings loaded in "reg", instead of
+// OpenAPI files.
+func emitLint(reg *descriptor.Registry) {
+	severity, err := descriptor.ParseLintSeverity(*lintSeverity)
+	if err != nil {
+		emitError(err)
+		return
+	}
+	resp, err := descriptor.LintResponse(reg.Lint(severity), *lintFormat, os.Stderr)
+	if err != nil {
+		emitError(err)
+		return
+	}
+	codegenerator.SetSupportedFeaturesOnCodeGeneratorResponse(resp)
+	emitResp(resp)
+}
+
 func emitError(err error) {
 	emitResp(&pluginpb.CodeGeneratorResponse{Error: proto.String(err.Error())})
 }
@@ -297,6 +346,11 @@ func parseReqParam(param string, f *flag.FlagSet, pkgMap map[string]string) erro
 					return fmt.Errorf("cannot set flag %s: %w", p, err)
 				}
 				continue
+			case "lint_only":
+				if err := f.Set(spec[0], "true"); err != nil {
+					return fmt.Errorf("cannot set flag %s: %w", p, err)
+				}
+				continue
 			}
 			if err := f.Set(spec[0], ""); err != nil {
 				return fmt.Errorf("cannot set flag %s: %w", p, err)
@@ -0,0 +1,95 @@
+package runtime
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// AccessLogEntry describes a request handled by a ServeMux. See WithAccessLogger.
+type AccessLogEntry struct {
+	// Method is the HTTP method of the request, after any X-HTTP-Method-Override.
+	Method string
+	// Path is the URL path of the request.
+	Path string
+	// RemoteAddr is the network address of the peer which sent the request.
+	RemoteAddr string
+	// HTTPPathPattern is the path pattern of the matched route, as passed to
+	// WithHTTPPathPattern, or empty if no route matched.
+	HTTPPathPattern string
+	// RPCMethod is the gRPC method the request was forwarded to, in the format
+	// of "/package.service/method", or empty if no gRPC call was made.
+	RPCMethod string
+	// HTTPStatus is the status code written to the response.
+	HTTPStatus int
+	// GRPCCode is the gRPC status code the request completed with.
+	GRPCCode codes.Code
+	// BytesRead is the number of bytes read from the request body.
+	BytesRead int64
+	// BytesWritten is the number of bytes written to the response body.
+	BytesWritten int64
+	// Start is the time the ServeMux started handling the request.
+	Start time.Time
+	// Duration is the time it took to handle the request.
+	Duration time.Duration
+}
+
+// WithAccessLogger returns a ServeMuxOption which calls fn after every request handled by the
+// ServeMux, including streaming calls, routing errors and the health endpoint.
+//
+// fn is called from the goroutine serving the request, after the response has been written.
+// See SlogAccessLogger for an implementation logging to a slog.Logger.
+func WithAccessLogger(fn func(context.Context, AccessLogEntry)) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.accessLogger = fn
+	}
+}
+
+// SlogAccessLogger returns an access logger for WithAccessLogger which logs every request
+// to logger. Requests which failed with a server error are logged at the error level,
+// other requests at the info level.
+func SlogAccessLogger(logger *slog.Logger) func(context.Context, AccessLogEntry) {
+	return func(ctx context.Context, entry AccessLogEntry) {
+		level := slog.LevelInfo
+		if entry.HTTPStatus >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logger.LogAttrs(ctx, level, "request completed",
+			slog.String("method", entry.Method),
+			slog.String("path", entry.Path),
+			slog.String("remote_addr", entry.RemoteAddr),
+			slog.String("http_path_pattern", entry.HTTPPathPattern),
+			slog.String("rpc_method", entry.RPCMethod),
+			slog.Int("http_status", entry.HTTPStatus),
+			slog.String("grpc_code", entry.GRPCCode.String()),
+			slog.Int64("bytes_read", entry.BytesRead),
+			slog.Int64("bytes_written", entry.BytesWritten),
+			slog.Duration("duration", entry.Duration),
+		)
+	}
+}
+
+// logAccess reports a completed request to the access logger.
+func (s *ServeMux) logAccess(r *http.Request, tracker *requestTracker, start time.Time) {
+	stats := tracker.snapshot()
+	tracker.mu.Lock()
+	rpcMethod, httpPathPattern := tracker.rpcMethod, tracker.httpPathPattern
+	tracker.mu.Unlock()
+
+	s.accessLogger(r.Context(), AccessLogEntry{
+		Method:          r.Method,
+		Path:            r.URL.Path,
+		RemoteAddr:      r.RemoteAddr,
+		HTTPPathPattern: httpPathPattern,
+		RPCMethod:       rpcMethod,
+		HTTPStatus:      stats.HTTPStatus,
+		GRPCCode:        stats.GRPCCode,
+		BytesRead:       stats.RequestSize,
+		BytesWritten:    stats.ResponseSize,
+		Start:           start,
+		Duration:        time.Since(start),
+	})
+}
@@ -0,0 +1,272 @@
+package runtime_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestWithAccessLogger(t *testing.T) {
+	for _, spec := range []struct {
+		name    string
+		method  string
+		path    string
+		handler func(mux *runtime.ServeMux) runtime.HandlerFunc
+		want    runtime.AccessLogEntry
+	}{
+		{
+			name:   "unary",
+			method: "GET",
+			path:   "/v1/greetings/world",
+			handler: func(mux *runtime.ServeMux) runtime.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+					_, outbound := runtime.MarshalerForRequest(mux, r)
+					ctx, err := runtime.AnnotateContext(r.Context(), mux, r, "/example.Greeter/Get", runtime.WithHTTPPathPattern("/v1/greetings/{name}"))
+					if err != nil {
+						runtime.HTTPError(r.Context(), mux, outbound, w, r, err)
+						return
+					}
+					runtime.ForwardResponseMessage(ctx, mux, outbound, w, r, wrapperspb.String("hello"))
+				}
+			},
+			want: runtime.AccessLogEntry{
+				Method:          "GET",
+				Path:            "/v1/greetings/world",
+				HTTPPathPattern: "/v1/greetings/{name}",
+				RPCMethod:       "/example.Greeter/Get",
+				HTTPStatus:      http.StatusOK,
+				GRPCCode:        codes.OK,
+				BytesWritten:    int64(len(`"hello"`)),
+			},
+		},
+		{
+			name:   "unary error",
+			method: "GET",
+			path:   "/v1/greetings/world",
+			handler: func(mux *runtime.ServeMux) runtime.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+					_, outbound := runtime.MarshalerForRequest(mux, r)
+					ctx, err := runtime.AnnotateContext(r.Context(), mux, r, "/example.Greeter/Get", runtime.WithHTTPPathPattern("/v1/greetings/{name}"))
+					if err != nil {
+						runtime.HTTPError(r.Context(), mux, outbound, w, r, err)
+						return
+					}
+					runtime.HTTPError(ctx, mux, outbound, w, r, status.Error(codes.PermissionDenied, "denied"))
+				}
+			},
+			want: runtime.AccessLogEntry{
+				Method:          "GET",
+				Path:            "/v1/greetings/world",
+				HTTPPathPattern: "/v1/greetings/{name}",
+				RPCMethod:       "/example.Greeter/Get",
+				HTTPStatus:      http.StatusForbidden,
+				GRPCCode:        codes.PermissionDenied,
+			},
+		},
+		{
+			name:   "server streaming error",
+			method: "GET",
+			path:   "/v1/greetings/world",
+			handler: func(mux *runtime.ServeMux) runtime.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+					_, outbound := runtime.MarshalerForRequest(mux, r)
+					ctx, err := runtime.AnnotateContext(r.Context(), mux, r, "/example.Greeter/List", runtime.WithHTTPPathPattern("/v1/greetings/{name}"))
+					if err != nil {
+						runtime.HTTPError(r.Context(), mux, outbound, w, r, err)
+						return
+					}
+					ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{})
+					sent := false
+					runtime.ForwardResponseStream(ctx, mux, outbound, w, r, func() (proto.Message, error) {
+						if sent {
+							return nil, status.Error(codes.Unavailable, "gone")
+						}
+						sent = true
+						return wrapperspb.String("hello"), nil
+					})
+				}
+			},
+			want: runtime.AccessLogEntry{
+				Method:          "GET",
+				Path:            "/v1/greetings/world",
+				HTTPPathPattern: "/v1/greetings/{name}",
+				RPCMethod:       "/example.Greeter/List",
+				HTTPStatus:      http.StatusOK,
+				GRPCCode:        codes.Unavailable,
+			},
+		},
+		{
+			name:   "not found",
+			method: "GET",
+			path:   "/v2/unknown",
+			want: runtime.AccessLogEntry{
+				Method:     "GET",
+				Path:       "/v2/unknown",
+				HTTPStatus: http.StatusNotFound,
+				GRPCCode:   codes.NotFound,
+			},
+		},
+		{
+			name:   "method not allowed",
+			method: "DELETE",
+			path:   "/v1/greetings/world",
+			want: runtime.AccessLogEntry{
+				Method:     "DELETE",
+				Path:       "/v1/greetings/world",
+				HTTPStatus: http.StatusNotImplemented,
+				GRPCCode:   codes.Unimplemented,
+			},
+		},
+		{
+			name:   "health endpoint",
+			method: "GET",
+			path:   "/healthz",
+			want: runtime.AccessLogEntry{
+				Method:          "GET",
+				Path:            "/healthz",
+				HTTPPathPattern: "/healthz",
+				RPCMethod:       grpc_health_v1.Health_Check_FullMethodName,
+				HTTPStatus:      http.StatusServiceUnavailable,
+				GRPCCode:        codes.Unavailable,
+			},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			var entries []runtime.AccessLogEntry
+			mux := runtime.NewServeMux(
+				runtime.WithAccessLogger(func(_ context.Context, entry runtime.AccessLogEntry) {
+					entries = append(entries, entry)
+				}),
+				runtime.WithHealthzEndpoint(&dummyHealthCheckClient{status: grpc_health_v1.HealthCheckResponse_NOT_SERVING}),
+			)
+			handler := func(w http.ResponseWriter, r *http.Request, _ map[string]string) {}
+			if spec.handler != nil {
+				handler = spec.handler(mux)
+			}
+			if err := mux.HandlePath("GET", "/v1/greetings/{name}", handler); err != nil {
+				t.Fatalf("mux.HandlePath() failed with %v; want success", err)
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(spec.method, spec.path, nil)
+			mux.ServeHTTP(w, r)
+
+			if len(entries) != 1 {
+				t.Fatalf("len(entries) = %d; want 1", len(entries))
+			}
+			got := entries[0]
+			if got.Duration <= 0 || got.Start.IsZero() {
+				t.Errorf("entry.Start, entry.Duration = %v, %v; want non-zero", got.Start, got.Duration)
+			}
+			if got.RemoteAddr != r.RemoteAddr {
+				t.Errorf("entry.RemoteAddr = %q; want %q", got.RemoteAddr, r.RemoteAddr)
+			}
+			if got.BytesWritten != int64(w.Body.Len()) {
+				t.Errorf("entry.BytesWritten = %d; want %d", got.BytesWritten, w.Body.Len())
+			}
+			got.Start, got.Duration, got.RemoteAddr = spec.want.Start, spec.want.Duration, spec.want.RemoteAddr
+			if spec.want.BytesWritten == 0 {
+				got.BytesWritten = 0
+			}
+			if got != spec.want {
+				t.Errorf("entry = %+v; want %+v", got, spec.want)
+			}
+		})
+	}
+}
+
+func TestWithAccessLogger_BytesRead(t *testing.T) {
+	var entry runtime.AccessLogEntry
+	mux := runtime.NewServeMux(runtime.WithAccessLogger(func(_ context.Context, e runtime.AccessLogEntry) {
+		entry = e
+	}))
+	err := mux.HandlePath("POST", "/v1/upload", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		if _, err := io.Copy(io.Discard, r.Body); err != nil {
+			t.Errorf("io.Copy() failed with %v; want success", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath() failed with %v; want success", err)
+	}
+
+	body := "some request body"
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/v1/upload", strings.NewReader(body)))
+
+	if got, want := entry.BytesRead, int64(len(body)); got != want {
+		t.Errorf("entry.BytesRead = %d; want %d", got, want)
+	}
+	if got, want := entry.HTTPPathPattern, "/v1/upload"; got != want {
+		t.Errorf("entry.HTTPPathPattern = %q; want %q", got, want)
+	}
+}
+
+func TestWithAccessLogger_BasePathMethodOverride(t *testing.T) {
+	var entry runtime.AccessLogEntry
+	mux := runtime.NewServeMux(
+		runtime.WithBasePath("/api"),
+		runtime.WithAccessLogger(func(_ context.Context, e runtime.AccessLogEntry) { entry = e }),
+	)
+	if err := mux.HandlePath("GET", "/v1/greetings/{name}", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {}); err != nil {
+		t.Fatalf("mux.HandlePath() failed with %v; want success", err)
+	}
+
+	r := httptest.NewRequest("POST", "/api/v1/greetings/world", strings.NewReader("lang=en"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-HTTP-Method-Override", "GET")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+
+	if got, want := w.Code, http.StatusOK; got != want {
+		t.Fatalf("w.Code = %d; want %d", got, want)
+	}
+	if got, want := entry.Method, "GET"; got != want {
+		t.Errorf("entry.Method = %q; want %q", got, want)
+	}
+	if got, want := entry.Path, "/api/v1/greetings/world"; got != want {
+		t.Errorf("entry.Path = %q; want %q", got, want)
+	}
+	if got, want := entry.HTTPPathPattern, "/api/v1/greetings/{name=*}"; got != want {
+		t.Errorf("entry.HTTPPathPattern = %q; want %q", got, want)
+	}
+}
+
+func TestSlogAccessLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	log := runtime.SlogAccessLogger(logger)
+
+	log(context.Background(), runtime.AccessLogEntry{
+		Method:          "GET",
+		Path:            "/v1/greetings/world",
+		HTTPPathPattern: "/v1/greetings/{name}",
+		RPCMethod:       "/example.Greeter/Get",
+		HTTPStatus:      http.StatusInternalServerError,
+		GRPCCode:        codes.Internal,
+	})
+
+	got := buf.String()
+	for _, want := range []string{
+		"level=ERROR",
+		`msg="request completed"`,
+		"http_path_pattern=/v1/greetings/{name}",
+		"rpc_method=/example.Greeter/Get",
+		"http_status=500",
+		"grpc_code=Internal",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("log output %q does not contain %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,589 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// HTTPClient calls the methods of a gateway over HTTP, as described by their
+// google.api.http bindings. It is used by the typed clients generated with the
+// generate_http_client option of protoc-gen-grpc-gateway:
+//
+//	client := pb.NewEchoServiceHTTPClient(runtime.NewHTTPClient("https://example.com"))
+//	resp, err := client.Echo(ctx, &pb.SimpleMessage{Id: "foo"})
+//
+// Path parameters are expanded from the request message, the other fields of
+// the request which are not sent in the body are encoded as query parameters
+// the way DefaultQueryParser expects, and errors returned by the gateway are
+// converted back to status errors. The outgoing metadata of the context is
+// sent as Grpc-Metadata-* headers, with the values of "-bin" keys encoded in
+// base64, and its deadline as the Grpc-Timeout header.
+type HTTPClient struct {
+	baseURL   string
+	client    *http.Client
+	marshaler Marshaler
+	envelope  StreamEnvelope
+}
+
+// HTTPClientOption is an option of NewHTTPClient.
+type HTTPClientOption func(*HTTPClient)
+
+// WithHTTPClient returns an HTTPClientOption which sends the requests with
+// client, instead of http.DefaultClient.
+func WithHTTPClient(client *http.Client) HTTPClientOption {
+	return func(c *HTTPClient) {
+		c.client = client
+	}
+}
+
+// WithHTTPClientMarshaler returns an HTTPClientOption which encodes requests
+// and decodes responses with marshaler, instead of JSONPb. Streaming methods
+// require a JSON marshaler.
+func WithHTTPClientMarshaler(marshaler Marshaler) HTTPClientOption {
+	return func(c *HTTPClient) {
+		c.marshaler = marshaler
+	}
+}
+
+// WithHTTPClientStreamEnvelope returns an HTTPClientOption which reads the
+// messages of server streams as written by a ServeMux with the same
+// WithStreamEnvelope option.
+func WithHTTPClientStreamEnvelope(envelope StreamEnvelope) HTTPClientOption {
+	return func(c *HTTPClient) {
+		c.envelope = envelope
+	}
+}
+
+// NewHTTPClient returns a new HTTPClient sending requests to the gateway at
+// baseURL, such as "https://example.com" or "https://example.com/api".
+func NewHTTPClient(baseURL string, opts ...HTTPClientOption) *HTTPClient {
+	c := &HTTPClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  http.DefaultClient,
+		marshaler: &JSONPb{
+			UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true},
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// HTTPCall describes a call of a method through one of its HTTP bindings.
+type HTTPCall struct {
+	// Method is the HTTP method of the binding.
+	Method string
+	// PathTemplate is the path template of the binding, such as
+	// "/v1/{name=shelves/*}".
+	PathTemplate string
+	// Request is the request message, from which the path and query
+	// parameters are taken.
+	Request proto.Message
+	// BodyFieldPath is the field path of the body of the binding, "*" if the
+	// body is the whole request, or empty if the binding has no body.
+	BodyFieldPath string
+	// Body is the value of the request sent as the request body.
+	Body any
+	// Response receives the response body. It is a message, or a pointer to
+	// the response field of the binding.
+	Response any
+}
+
+// Invoke calls a unary method, and decodes the response in call.Response.
+func (c *HTTPClient) Invoke(ctx context.Context, call *HTTPCall) error {
+	resp, err := c.do(ctx, call)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return status.FromContextError(err).Err()
+	}
+	if hb, ok := call.Response.(*httpbody.HttpBody); ok {
+		hb.ContentType = resp.Header.Get("Content-Type")
+		hb.Data = data
+		return nil
+	}
+	if err := c.marshaler.Unmarshal(data, call.Response); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode the response: %v", err)
+	}
+	return nil
+}
+
+// NewStream calls a server streaming method, whose messages are read from the
+// returned stream.
+func (c *HTTPClient) NewStream(ctx context.Context, call *HTTPCall) (*HTTPClientStream, error) {
+	if !isJSONContentType(c.marshaler.ContentType(nil)) {
+		return nil, status.Errorf(codes.Unimplemented, "streams of %s messages are not supported", c.marshaler.ContentType(nil))
+	}
+	resp, err := c.do(ctx, call)
+	if err != nil {
+		return nil, err
+	}
+	return &HTTPClientStream{
+		resp:      resp,
+		dec:       json.NewDecoder(recordSeparatorReader{resp.Body}),
+		marshaler: c.marshaler,
+		envelope:  c.envelope,
+	}, nil
+}
+
+// do sends the request of call, and returns the response if it is
+// successful.
+func (c *HTTPClient) do(ctx context.Context, call *HTTPCall) (*http.Response, error) {
+	msg := call.Request.ProtoReflect()
+	path, bound, err := expandPathTemplate(call.PathTemplate, msg)
+	if err != nil {
+		return nil, err
+	}
+	if call.BodyFieldPath != "*" {
+		// Fields which are neither in the path nor in the body are sent as
+		// query parameters.
+		exclude := bound
+		if call.BodyFieldPath != "" {
+			exclude = append(exclude, call.BodyFieldPath)
+		}
+		query := url.Values{}
+		if err := encodeQuery(msg, "", exclude, query); err != nil {
+			return nil, err
+		}
+		if len(query) > 0 {
+			path += "?" + query.Encode()
+		}
+	}
+	var body io.Reader
+	if call.BodyFieldPath != "" {
+		data, err := c.marshaler.Marshal(call.Body)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to encode the request: %v", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, call.Method, c.baseURL+path, body)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create the request: %v", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", c.marshaler.ContentType(call.Body))
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	for k, vs := range md {
+		for _, v := range vs {
+			if strings.HasSuffix(k, "-bin") {
+				// Binary values are decoded by the gateway.
+				v = base64.StdEncoding.EncodeToString([]byte(v))
+			}
+			req.Header.Add(MetadataHeaderPrefix+k, v)
+		}
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout := time.Until(deadline).Milliseconds()
+		if timeout <= 0 {
+			return nil, status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error())
+		}
+		req.Header.Set(metadataGrpcTimeout, strconv.FormatInt(timeout, 10)+"m")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, status.FromContextError(ctxErr).Err()
+		}
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	return nil, c.responseError(resp.StatusCode, data)
+}
+
+// responseError converts the body of an error response, written by the error
+// handler of the gateway, to a status error. If the body is not a status, the
+// code is derived from the HTTP status.
+func (c *HTTPClient) responseError(code int, data []byte) error {
+	var st spb.Status
+	if err := c.marshaler.Unmarshal(data, &st); err == nil && st.GetCode() != int32(codes.OK) {
+		return status.ErrorProto(&st)
+	}
+	msg := strings.TrimSpace(string(data))
+	if msg == "" {
+		msg = http.StatusText(code)
+	}
+	return status.Error(codeFromHTTPStatus(code), msg)
+}
+
+// codeFromHTTPStatus is the inverse of HTTPStatusFromCode, for the codes
+// mapped to a distinct HTTP status.
+func codeFromHTTPStatus(code int) codes.Code {
+	switch code {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case 499:
+		return codes.Canceled
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	return codes.Unknown
+}
+
+// HTTPClientStream reads the messages of a server stream, written as JSON
+// records by the gateway in any of the StreamFraming formats.
+type HTTPClientStream struct {
+	resp      *http.Response
+	dec       *json.Decoder
+	marshaler Marshaler
+	envelope  StreamEnvelope
+}
+
+// Header returns the headers of the response.
+func (s *HTTPClientStream) Header() http.Header {
+	return s.resp.Header
+}
+
+// RecvMsg reads the next message of the stream into m, a message or a
+// pointer to the response field of the binding. It returns io.EOF at the end
+// of the stream, or the error which ended it.
+//
+// For streams of HttpBody messages, the gateway writes the chunks back to
+// back as a single body, and RecvMsg returns its data as it is read, so the
+// messages may not match the chunks sent by the method.
+func (s *HTTPClientStream) RecvMsg(m any) error {
+	if hb, ok := m.(*httpbody.HttpBody); ok {
+		data, err := s.readChunk()
+		if len(data) > 0 || err == nil {
+			hb.ContentType = s.resp.Header.Get("Content-Type")
+			hb.Data = data
+			return nil
+		}
+		if errors.Is(err, io.EOF) {
+			return s.trailerError()
+		}
+		if ctxErr := s.resp.Request.Context().Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+		return status.Errorf(codes.Internal, "failed to read the stream: %v", err)
+	}
+
+	var record json.RawMessage
+	if err := s.dec.Decode(&record); errors.Is(err, io.EOF) {
+		return s.trailerError()
+	} else if err != nil {
+		if ctxErr := s.resp.Request.Context().Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+		return status.Errorf(codes.Internal, "failed to read the stream: %v", err)
+	}
+
+	if s.envelope == StreamEnvelopeNone {
+		var typed struct {
+			Type string `json:"@type"`
+		}
+		if err := json.Unmarshal(record, &typed); err == nil && strings.HasSuffix(typed.Type, "/google.rpc.Status") {
+			return s.recordError(record)
+		}
+		if err := s.marshaler.Unmarshal(record, m); err != nil {
+			return status.Errorf(codes.Internal, "failed to decode a message of the stream: %v", err)
+		}
+		return nil
+	}
+	var env struct {
+		Result json.RawMessage `json:"result"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(record, &env); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode a message of the stream: %v", err)
+	}
+	if env.Error != nil {
+		return s.recordError(env.Error)
+	}
+	if err := s.marshaler.Unmarshal(env.Result, m); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode a message of the stream: %v", err)
+	}
+	return nil
+}
+
+// readChunk returns the data of the body read next.
+func (s *HTTPClientStream) readChunk() ([]byte, error) {
+	buf := make([]byte, 32*1024)
+	n, err := s.resp.Body.Read(buf)
+	for n == 0 && err == nil {
+		n, err = s.resp.Body.Read(buf)
+	}
+	return buf[:n], err
+}
+
+// Close closes the stream. The call is cancelled if the stream has not ended.
+func (s *HTTPClientStream) Close() error {
+	return s.resp.Body.Close()
+}
+
+func (s *HTTPClientStream) recordError(data []byte) error {
+	var st spb.Status
+	if err := (&JSONPb{UnmarshalOptions: protojson.UnmarshalOptions{DiscardUnknown: true}}).Unmarshal(data, &st); err != nil {
+		return status.Errorf(codes.Internal, "failed to decode the error of the stream: %v", err)
+	}
+	return status.ErrorProto(&st)
+}
+
+// trailerError returns the error sent in the trailers of a stream written with
+// WithStreamErrorTrailers, or io.EOF.
+func (s *HTTPClientStream) trailerError() error {
+	code := s.resp.Trailer.Get("Grpc-Status")
+	if code == "" || code == "0" {
+		return io.EOF
+	}
+	c, err := strconv.Atoi(code)
+	if err != nil {
+		return status.Errorf(codes.Internal, "invalid Grpc-Status trailer: %q", code)
+	}
+	msg, _ := url.PathUnescape(s.resp.Trailer.Get("Grpc-Message"))
+	return status.Error(codes.Code(c), msg)
+}
+
+// recordSeparatorReader drops the record separators prefixing the records of
+// JSON text sequences, which are never part of JSON values.
+type recordSeparatorReader struct {
+	r io.Reader
+}
+
+func (r recordSeparatorReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	m := 0
+	for _, b := range p[:n] {
+		if b != jsonSeqRecordSeparator {
+			p[m] = b
+			m++
+		}
+	}
+	return m, err
+}
+
+// expandPathTemplate expands the variables of the path template tmpl with
+// the fields of msg, and returns the path with the field paths of the
+// variables.
+func expandPathTemplate(tmpl string, msg protoreflect.Message) (string, []string, error) {
+	var sb strings.Builder
+	var fieldPaths []string
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			sb.WriteString(tmpl)
+			return sb.String(), fieldPaths, nil
+		}
+		end := strings.IndexByte(tmpl[start:], '}')
+		if end < 0 {
+			return "", nil, status.Errorf(codes.Internal, "malformed path template %q", tmpl)
+		}
+		end += start
+		sb.WriteString(tmpl[:start])
+
+		fieldPath, pattern, _ := strings.Cut(tmpl[start+1:end], "=")
+		values, err := fieldStrings(msg, fieldPath)
+		if err != nil {
+			return "", nil, err
+		}
+		value := strings.Join(values, ",")
+		if value == "" {
+			return "", nil, status.Errorf(codes.InvalidArgument, "missing value for the path parameter %q", fieldPath)
+		}
+		if pattern == "" || pattern == "*" {
+			sb.WriteString(url.PathEscape(value))
+		} else {
+			// Variables matching several segments keep their slashes.
+			segments := strings.Split(value, "/")
+			for i, segment := range segments {
+				segments[i] = url.PathEscape(segment)
+			}
+			sb.WriteString(strings.Join(segments, "/"))
+		}
+		fieldPaths = append(fieldPaths, fieldPath)
+		tmpl = tmpl[end+1:]
+	}
+}
+
+// fieldStrings returns the string representations of the values of the field
+// designated by the dot-separated fieldPath within msg.
+func fieldStrings(msg protoreflect.Message, fieldPath string) ([]string, error) {
+	names := strings.Split(fieldPath, ".")
+	for i, name := range names {
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil, status.Errorf(codes.Internal, "no field %q in %s", name, msg.Descriptor().FullName())
+		}
+		if i < len(names)-1 {
+			if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+				return nil, status.Errorf(codes.Internal, "field %q of %s is not a message", name, msg.Descriptor().FullName())
+			}
+			msg = msg.Get(fd).Message()
+			continue
+		}
+		if fd.IsList() {
+			list := msg.Get(fd).List()
+			values := make([]string, 0, list.Len())
+			for j := 0; j < list.Len(); j++ {
+				s, err := formatField(fd, list.Get(j))
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, s)
+			}
+			return values, nil
+		}
+		if !msg.Has(fd) && fd.Message() != nil {
+			return nil, nil
+		}
+		s, err := formatField(fd, msg.Get(fd))
+		if err != nil {
+			return nil, err
+		}
+		return []string{s}, nil
+	}
+	return nil, nil
+}
+
+// encodeQuery adds the populated fields of msg to query, except those which
+// are, or are within, a field of exclude. Keys are prefixed with prefix.
+func encodeQuery(msg protoreflect.Message, prefix string, exclude []string, query url.Values) error {
+	var err error
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		key := prefix + fd.TextName()
+		for _, e := range exclude {
+			if key == e || strings.HasPrefix(key, e+".") {
+				return true
+			}
+		}
+		switch {
+		case fd.IsList():
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				var s string
+				if s, err = formatField(fd, list.Get(i)); err != nil {
+					return false
+				}
+				query.Add(key, s)
+			}
+		case fd.IsMap():
+			v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				var s string
+				if s, err = formatField(fd.MapValue(), v); err != nil {
+					return false
+				}
+				query.Add(key+"["+k.String()+"]", s)
+				return true
+			})
+		case fd.Message() != nil && !isQueryMessage(fd.Message()):
+			err = encodeQuery(v.Message(), key+".", exclude, query)
+		default:
+			var s string
+			if s, err = formatField(fd, v); err == nil {
+				query.Add(key, s)
+			}
+		}
+		return err == nil
+	})
+	return err
+}
+
+// isQueryMessage reports whether messages of md are parsed from a single query
+// parameter by DefaultQueryParser.
+func isQueryMessage(md protoreflect.MessageDescriptor) bool {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp", "google.protobuf.Duration", "google.protobuf.FieldMask",
+		"google.protobuf.Value", "google.protobuf.Struct":
+		return true
+	}
+	return isWrapperMessage(md)
+}
+
+func isWrapperMessage(md protoreflect.MessageDescriptor) bool {
+	return md.ParentFile().Package() == "google.protobuf" && strings.HasSuffix(string(md.Name()), "Value") &&
+		md.Fields().Len() == 1 && md.Fields().Get(0).Name() == "value"
+}
+
+// formatField returns the string representation of v, a value of fd, as
+// parsed by DefaultQueryParser.
+func formatField(fd protoreflect.FieldDescriptor, v protoreflect.Value) (string, error) {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name()), nil
+		}
+		return strconv.Itoa(int(v.Enum())), nil
+	case protoreflect.FloatKind:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32), nil
+	case protoreflect.DoubleKind:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), nil
+	case protoreflect.BytesKind:
+		return base64.StdEncoding.EncodeToString(v.Bytes()), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return formatMessage(v.Message())
+	}
+	return v.String(), nil
+}
+
+func formatMessage(msg protoreflect.Message) (string, error) {
+	md := msg.Descriptor()
+	switch m := msg.Interface().(type) {
+	case *timestamppb.Timestamp:
+		return m.AsTime().Format(time.RFC3339Nano), nil
+	case *durationpb.Duration:
+		return m.AsDuration().String(), nil
+	case *fieldmaskpb.FieldMask:
+		return strings.Join(m.GetPaths(), ","), nil
+	}
+	switch {
+	case md.FullName() == "google.protobuf.Value" || md.FullName() == "google.protobuf.Struct":
+		data, err := protojson.Marshal(msg.Interface())
+		if err != nil {
+			return "", status.Errorf(codes.Internal, "failed to encode %s: %v", md.FullName(), err)
+		}
+		return string(data), nil
+	case isWrapperMessage(md):
+		fd := md.Fields().Get(0)
+		return formatField(fd, msg.Get(fd))
+	}
+	return "", status.Errorf(codes.Internal, "message %s cannot be encoded as a parameter", md.FullName())
+}
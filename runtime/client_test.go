@@ -0,0 +1,367 @@
+package runtime_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// newEchoGateway returns a ServeMux echoing the requests of GET
+// /v1/{string_value}/{nested.string_value=**}, whose query parameters are
+// parsed by DefaultQueryParser and whose "foo" metadata is appended to
+// repeated_value, and of POST /v1/body, whose body is the nested field. GET
+// /v1/stream streams two messages, then fails.
+func newEchoGateway(t *testing.T, opts ...runtime.ServeMuxOption) *runtime.ServeMux {
+	t.Helper()
+	mux := runtime.NewServeMux(opts...)
+	marshaler := &runtime.JSONPb{}
+	err := mux.HandlePath(http.MethodGet, "/v1/{string_value}/{nested.string_value=**}", func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		msg := &examplepb.Proto3Message{}
+		if err := runtime.PopulateQueryParameters(msg, r.URL.Query(), &utilities.DoubleArray{}); err != nil {
+			runtime.HTTPError(r.Context(), mux, marshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		for k, v := range pathParams {
+			if err := runtime.PopulateFieldFromPath(msg, k, v); err != nil {
+				runtime.HTTPError(r.Context(), mux, marshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+				return
+			}
+		}
+		if msg.GetStringValue() == "missing" {
+			runtime.HTTPError(r.Context(), mux, marshaler, w, r, status.Error(codes.NotFound, "no such message"))
+			return
+		}
+		if md := r.Header.Get("Grpc-Metadata-Foo"); md != "" {
+			msg.RepeatedValue = append(msg.RepeatedValue, md)
+		}
+		runtime.ForwardResponseMessage(runtime.NewServerMetadataContext(r.Context(), runtime.ServerMetadata{}), mux, marshaler, w, r, msg)
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath() failed with %v; want success", err)
+	}
+	err = mux.HandlePath(http.MethodPost, "/v1/body", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		msg := &examplepb.Proto3Message{Nested: &examplepb.Proto3Message{}}
+		if err := marshaler.NewDecoder(r.Body).Decode(msg.Nested); err != nil {
+			runtime.HTTPError(r.Context(), mux, marshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		if err := runtime.PopulateQueryParameters(msg, r.URL.Query(), &utilities.DoubleArray{}); err != nil {
+			runtime.HTTPError(r.Context(), mux, marshaler, w, r, status.Error(codes.InvalidArgument, err.Error()))
+			return
+		}
+		runtime.ForwardResponseMessage(runtime.NewServerMetadataContext(r.Context(), runtime.ServerMetadata{}), mux, marshaler, w, r, msg)
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath() failed with %v; want success", err)
+	}
+	err = mux.HandlePath(http.MethodGet, "/v1/stream", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		var sent int
+		runtime.ForwardResponseStream(runtime.NewServerMetadataContext(r.Context(), runtime.ServerMetadata{}), mux, marshaler, w, r, func() (proto.Message, error) {
+			sent++
+			if sent > 2 {
+				return nil, status.Error(codes.ResourceExhausted, "no more messages")
+			}
+			return &examplepb.Proto3Message{Int32Value: int32(sent)}, nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath() failed with %v; want success", err)
+	}
+	return mux
+}
+
+func TestHTTPClientInvoke(t *testing.T) {
+	server := httptest.NewServer(newEchoGateway(t))
+	defer server.Close()
+	client := runtime.NewHTTPClient(server.URL + "/")
+
+	req := &examplepb.Proto3Message{
+		StringValue: "foo bar",
+		Nested: &examplepb.Proto3Message{
+			StringValue: "a/b c/d",
+			Int64Value:  -1,
+		},
+		FloatValue:         1.5,
+		DoubleValue:        2.25,
+		Uint64Value:        1<<64 - 1,
+		BoolValue:          true,
+		BytesValue:         []byte{0xfb, 0xff},
+		RepeatedValue:      []string{"a", "b"},
+		EnumValue:          examplepb.EnumValue_Y,
+		RepeatedEnum:       []examplepb.EnumValue{examplepb.EnumValue_Z, examplepb.EnumValue_X},
+		TimestampValue:     timestamppb.New(time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)),
+		DurationValue:      durationpb.New(90 * time.Second),
+		FieldmaskValue:     &fieldmaskpb.FieldMask{Paths: []string{"a", "b.c"}},
+		WrapperStringValue: wrapperspb.String("wrapped"),
+		MapValue:           map[string]string{"k": "v", "k 2": "v&2"},
+		MapValue3:          map[int32]string{7: "seven"},
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "foo", "from metadata")
+	resp := &examplepb.Proto3Message{}
+	err := client.Invoke(ctx, &runtime.HTTPCall{
+		Method:       http.MethodGet,
+		PathTemplate: "/v1/{string_value}/{nested.string_value=**}",
+		Request:      req,
+		Response:     resp,
+	})
+	if err != nil {
+		t.Fatalf("client.Invoke() failed with %v; want success", err)
+	}
+	want := proto.Clone(req).(*examplepb.Proto3Message)
+	want.RepeatedValue = append(want.RepeatedValue, "from metadata")
+	if !proto.Equal(resp, want) {
+		t.Errorf("resp = %v; want %v", resp, want)
+	}
+
+	req = &examplepb.Proto3Message{
+		StringValue: "query",
+		Nested:      &examplepb.Proto3Message{StringValue: "body", RepeatedValue: []string{"x"}},
+	}
+	resp = &examplepb.Proto3Message{}
+	err = client.Invoke(context.Background(), &runtime.HTTPCall{
+		Method:        http.MethodPost,
+		PathTemplate:  "/v1/body",
+		Request:       req,
+		BodyFieldPath: "nested",
+		Body:          req.GetNested(),
+		Response:      resp,
+	})
+	if err != nil {
+		t.Fatalf("client.Invoke() failed with %v; want success", err)
+	}
+	if !proto.Equal(resp, req) {
+		t.Errorf("resp = %v; want %v", resp, req)
+	}
+}
+
+func TestHTTPClientInvokeError(t *testing.T) {
+	server := httptest.NewServer(newEchoGateway(t))
+	defer server.Close()
+	client := runtime.NewHTTPClient(server.URL)
+
+	for _, spec := range []struct {
+		name     string
+		tmpl     string
+		req      *examplepb.Proto3Message
+		wantCode codes.Code
+		wantMsg  string
+	}{
+		{
+			name:     "status",
+			tmpl:     "/v1/{string_value}/{nested.string_value=**}",
+			req:      &examplepb.Proto3Message{StringValue: "missing", Nested: &examplepb.Proto3Message{StringValue: "x"}},
+			wantCode: codes.NotFound,
+			wantMsg:  "no such message",
+		},
+		{
+			name:     "routing error",
+			tmpl:     "/v2/{string_value}",
+			req:      &examplepb.Proto3Message{StringValue: "foo"},
+			wantCode: codes.NotFound,
+			wantMsg:  "Not Found",
+		},
+		{
+			name:     "missing path parameter",
+			tmpl:     "/v1/{string_value}/{nested.string_value=**}",
+			req:      &examplepb.Proto3Message{StringValue: "foo"},
+			wantCode: codes.InvalidArgument,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			err := client.Invoke(context.Background(), &runtime.HTTPCall{
+				Method:       http.MethodGet,
+				PathTemplate: spec.tmpl,
+				Request:      spec.req,
+				Response:     &examplepb.Proto3Message{},
+			})
+			st := status.Convert(err)
+			if st.Code() != spec.wantCode {
+				t.Errorf("client.Invoke() failed with %v; want code %v", err, spec.wantCode)
+			}
+			if spec.wantMsg != "" && st.Message() != spec.wantMsg {
+				t.Errorf("client.Invoke() failed with message %q; want %q", st.Message(), spec.wantMsg)
+			}
+		})
+	}
+}
+
+func TestHTTPClientStream(t *testing.T) {
+	for _, spec := range []struct {
+		name      string
+		muxOpts   []runtime.ServeMuxOption
+		clientOpt []runtime.HTTPClientOption
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:    "json-seq",
+			muxOpts: []runtime.ServeMuxOption{runtime.WithStreamFraming(runtime.StreamFramingJSONSeq)},
+		},
+		{
+			name:      "no envelope",
+			muxOpts:   []runtime.ServeMuxOption{runtime.WithStreamEnvelope(runtime.StreamEnvelopeNone)},
+			clientOpt: []runtime.HTTPClientOption{runtime.WithHTTPClientStreamEnvelope(runtime.StreamEnvelopeNone)},
+		},
+		{
+			name:    "error trailers",
+			muxOpts: []runtime.ServeMuxOption{runtime.WithStreamFraming(runtime.StreamFramingNDJSON), runtime.WithStreamErrorTrailers()},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			server := httptest.NewServer(newEchoGateway(t, spec.muxOpts...))
+			defer server.Close()
+			client := runtime.NewHTTPClient(server.URL, spec.clientOpt...)
+
+			stream, err := client.NewStream(context.Background(), &runtime.HTTPCall{
+				Method:       http.MethodGet,
+				PathTemplate: "/v1/stream",
+				Request:      &examplepb.Proto3Message{},
+			})
+			if err != nil {
+				t.Fatalf("client.NewStream() failed with %v; want success", err)
+			}
+			defer stream.Close()
+			for i := int32(1); i <= 2; i++ {
+				msg := &examplepb.Proto3Message{}
+				if err := stream.RecvMsg(msg); err != nil {
+					t.Fatalf("stream.RecvMsg() failed with %v; want success", err)
+				}
+				if msg.GetInt32Value() != i {
+					t.Errorf("msg.Int32Value = %d; want %d", msg.GetInt32Value(), i)
+				}
+			}
+			err = stream.RecvMsg(&examplepb.Proto3Message{})
+			if got, want := status.Code(err), codes.ResourceExhausted; got != want {
+				t.Errorf("stream.RecvMsg() failed with %v; want %v", err, want)
+			}
+			if err == io.EOF {
+				t.Errorf("stream.RecvMsg() returned io.EOF; want the error of the stream")
+			}
+		})
+	}
+}
+
+func TestHTTPClientStreamHTTPBody(t *testing.T) {
+	for _, spec := range []struct {
+		name    string
+		muxOpts []runtime.ServeMuxOption
+		header  metadata.MD
+	}{
+		{
+			name: "chunked",
+		},
+		{
+			name:    "json-seq",
+			muxOpts: []runtime.ServeMuxOption{runtime.WithStreamFraming(runtime.StreamFramingJSONSeq)},
+		},
+		{
+			name:   "content length",
+			header: metadata.Pairs(runtime.MetadataContentLength, "8"),
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			mux := runtime.NewServeMux(spec.muxOpts...)
+			err := mux.HandlePath(http.MethodGet, "/v1/download", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+				chunks := []string{"a\nb", "", "c\n", "\x00\x0a\xff"}
+				ctx := runtime.NewServerMetadataContext(r.Context(), runtime.ServerMetadata{HeaderMD: spec.header})
+				runtime.ForwardResponseStream(ctx, mux, &runtime.HTTPBodyMarshaler{Marshaler: &runtime.JSONPb{}}, w, r, func() (proto.Message, error) {
+					if len(chunks) == 0 {
+						return nil, io.EOF
+					}
+					chunk := chunks[0]
+					chunks = chunks[1:]
+					return &httpbody.HttpBody{ContentType: "application/octet-stream", Data: []byte(chunk)}, nil
+				})
+			})
+			if err != nil {
+				t.Fatalf("mux.HandlePath() failed with %v; want success", err)
+			}
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			stream, err := runtime.NewHTTPClient(server.URL).NewStream(context.Background(), &runtime.HTTPCall{
+				Method:       http.MethodGet,
+				PathTemplate: "/v1/download",
+				Request:      &examplepb.Proto3Message{},
+			})
+			if err != nil {
+				t.Fatalf("client.NewStream() failed with %v; want success", err)
+			}
+			defer stream.Close()
+			var got []byte
+			for {
+				var body httpbody.HttpBody
+				err := stream.RecvMsg(&body)
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("stream.RecvMsg() failed with %v; want success", err)
+				}
+				if got, want := body.GetContentType(), "application/octet-stream"; got != want {
+					t.Errorf("body.ContentType = %q; want %q", got, want)
+				}
+				// Reads may split the body anywhere.
+				got = append(got, body.GetData()...)
+			}
+			if want := "a\nbc\n\x00\n\xff"; string(got) != want {
+				t.Errorf("body = %q; want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestHTTPClientInvokeBinaryMetadata(t *testing.T) {
+	mux := runtime.NewServeMux()
+	err := mux.HandlePath(http.MethodGet, "/v1/metadata", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r, "/example.Echo/Metadata")
+		if err != nil {
+			runtime.HTTPError(r.Context(), mux, &runtime.JSONPb{}, w, r, err)
+			return
+		}
+		md, _ := metadata.FromOutgoingContext(ctx)
+		msg := &examplepb.Proto3Message{BytesValue: []byte(strings.Join(md.Get("foo-bin"), ""))}
+		runtime.ForwardResponseMessage(runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{}), mux, &runtime.JSONPb{}, w, r, msg)
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath() failed with %v; want success", err)
+	}
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	want := []byte{0, 0xff, '\n'}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "foo-bin", string(want))
+	resp := &examplepb.Proto3Message{}
+	err = runtime.NewHTTPClient(server.URL).Invoke(ctx, &runtime.HTTPCall{
+		Method:       http.MethodGet,
+		PathTemplate: "/v1/metadata",
+		Request:      &examplepb.Proto3Message{},
+		Response:     resp,
+	})
+	if err != nil {
+		t.Fatalf("client.Invoke() failed with %v; want success", err)
+	}
+	if got := resp.GetBytesValue(); !bytes.Equal(got, want) {
+		t.Errorf("foo-bin metadata = %q; want %q", got, want)
+	}
+}
@@ -73,7 +73,8 @@ AnnotateContext adds context information such as metadata from the request.
 
 At a minimum, the RemoteAddr is included in the fashion of "X-Forwarded-For",
 except that the forwarded destination is not another HTTP service but rather
-a gRPC service.
+a gRPC service. See WithTrustedProxies for restricting which forwarding
+headers are honored.
 */
 func AnnotateContext(ctx context.Context, mux *ServeMux, req *http.Request, rpcMethodName string, options ...AnnotateContextOption) (context.Context, error) {
 	ctx, md, err := annotateContext(ctx, mux, req, rpcMethodName, options...)
@@ -138,14 +139,18 @@ func annotateContext(ctx context.Context, mux *ServeMux, req *http.Request, rpcM
 	for _, o := range options {
 		ctx = o(ctx)
 	}
-	timeout := DefaultContextTimeout
-	if tm := req.Header.Get(metadataGrpcTimeout); tm != "" {
-		var err error
-		timeout, err = timeoutDecode(tm)
-		if err != nil {
-			return nil, nil, status.Errorf(codes.InvalidArgument, "invalid grpc-timeout: %s", tm)
+	if prefix, ok := pathPrefix(req.Context()); ok {
+		if pattern, ok := HTTPPathPattern(ctx); ok {
+			ctx = withHTTPPathPattern(ctx, prefix+pattern)
 		}
 	}
+	if mux.requestValidator != nil {
+		ctx = withRequestValidator(ctx, mux.requestValidator)
+	}
+	timeout, err := mux.requestTimeout(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
 	var pairs []string
 	for key, vals := range req.Header {
 		key = textproto.CanonicalMIMEHeaderKey(key)
@@ -153,6 +158,16 @@ func annotateContext(ctx context.Context, mux *ServeMux, req *http.Request, rpcM
 		case xForwardedFor, xForwardedHost:
 			// Handled separately below
 			continue
+		case forwarded, xForwardedProto, xRealIP:
+			if mux.trustedProxies != nil {
+				// Handled separately below
+				continue
+			}
+		case requestTimeout, xRequestTimeout:
+			if _, ok := mux.timeoutPolicy(ctx); ok {
+				// Handled above
+				continue
+			}
 		}
 
 		for _, val := range vals {
@@ -182,26 +197,46 @@ func annotateContext(ctx context.Context, mux *ServeMux, req *http.Request, rpcM
 			}
 		}
 	}
-	if host := req.Header.Get(xForwardedHost); host != "" {
-		pairs = append(pairs, strings.ToLower(xForwardedHost), host)
-	} else if req.Host != "" {
-		pairs = append(pairs, strings.ToLower(xForwardedHost), req.Host)
-	}
+	if mux.trustedProxies != nil {
+		client := mux.resolveForwardedClient(req)
+		if client.host != "" {
+			pairs = append(pairs, strings.ToLower(xForwardedHost), client.host)
+		}
+		if len(client.trusted) > 0 {
+			pairs = append(pairs, strings.ToLower(xForwardedFor), strings.Join(client.trusted, ", "))
+			pairs = append(pairs, MetadataClientAddress, client.addr)
+			pairs = append(pairs, MetadataForwardedChain, strings.Join(client.chain, ", "))
+		}
+		pairs = append(pairs, MetadataClientScheme, client.scheme)
+	} else {
+		if host := req.Header.Get(xForwardedHost); host != "" {
+			pairs = append(pairs, strings.ToLower(xForwardedHost), host)
+		} else if req.Host != "" {
+			pairs = append(pairs, strings.ToLower(xForwardedHost), req.Host)
+		}
 
-	xff := req.Header.Values(xForwardedFor)
-	if addr := req.RemoteAddr; addr != "" {
-		if remoteIP, _, err := net.SplitHostPort(addr); err == nil {
-			xff = append(xff, remoteIP)
+		xff := req.Header.Values(xForwardedFor)
+		if addr := req.RemoteAddr; addr != "" {
+			if remoteIP, _, err := net.SplitHostPort(addr); err == nil {
+				xff = append(xff, remoteIP)
+			}
+		}
+		if len(xff) > 0 {
+			pairs = append(pairs, strings.ToLower(xForwardedFor), strings.Join(xff, ", "))
 		}
-	}
-	if len(xff) > 0 {
-		pairs = append(pairs, strings.ToLower(xForwardedFor), strings.Join(xff, ", "))
 	}
 
 	if timeout != 0 {
-		ctx, _ = context.WithTimeout(ctx, timeout)
+		ctx, _ = context.WithTimeout(withRequestTimeout(ctx, timeout), timeout)
 	}
 	md := metadata.Pairs(pairs...)
+	if tracker := requestTrackerFromContext(ctx); tracker != nil {
+		pattern, _ := HTTPPathPattern(ctx)
+		tracker.setRoute(rpcMethodName, pattern)
+		if tracker.inst != nil {
+			md = metadata.Join(md, tracker.inst.Annotate(ctx, rpcMethodName, pattern))
+		}
+	}
 	for _, mda := range mux.metadataAnnotators {
 		md = metadata.Join(md, mda(ctx, req))
 	}
@@ -349,11 +384,13 @@ func isPermanentHTTPHeader(hdr string) bool {
 		"If-Match",
 		"If-Modified-Since",
 		"If-None-Match",
+		"If-Range",
 		"If-Schedule-Tag-Match",
 		"If-Unmodified-Since",
 		"Max-Forwards",
 		"Origin",
 		"Pragma",
+		"Range",
 		"Referer",
 		"User-Agent",
 		"Via",
@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -211,6 +213,199 @@ func TestAnnotateContext_AppendsToExistingXForwardedHeaders(t *testing.T) {
 	}
 }
 
+func TestAnnotateContext_TrustedProxies(t *testing.T) {
+	for _, spec := range []struct {
+		name       string
+		trusted    []string
+		header     runtime.ForwardedHeader
+		headers    map[string][]string
+		remoteAddr string
+		want       map[string]string
+	}{
+		{
+			name:       "untrusted peer ignores forwarding headers",
+			trusted:    []string{"10.0.0.0/8"},
+			headers:    map[string][]string{"X-Forwarded-For": {"203.0.113.7"}, "X-Forwarded-Host": {"evil.example.com"}, "X-Forwarded-Proto": {"https"}},
+			remoteAddr: "192.0.2.100:12345",
+			want: map[string]string{
+				"x-forwarded-for":              "192.0.2.100",
+				"x-forwarded-host":             "bar.foo.example.com",
+				runtime.MetadataClientAddress:  "192.0.2.100",
+				runtime.MetadataClientScheme:   "http",
+				runtime.MetadataForwardedChain: "203.0.113.7, 192.0.2.100",
+			},
+		},
+		{
+			name:       "spoofed hops before the client are dropped",
+			trusted:    []string{"10.0.0.0/8", "192.0.2.200"},
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.1, 203.0.113.7", "10.1.2.3"}, "X-Forwarded-Host": {"qux.example.com"}, "X-Forwarded-Proto": {"https"}},
+			remoteAddr: "192.0.2.200:12345",
+			want: map[string]string{
+				"x-forwarded-for":              "203.0.113.7, 10.1.2.3, 192.0.2.200",
+				"x-forwarded-host":             "qux.example.com",
+				runtime.MetadataClientAddress:  "203.0.113.7",
+				runtime.MetadataClientScheme:   "https",
+				runtime.MetadataForwardedChain: "198.51.100.1, 203.0.113.7, 10.1.2.3, 192.0.2.200",
+			},
+		},
+		{
+			name:    "client forwarded header is ignored",
+			trusted: []string{"10.0.0.0/8"},
+			headers: map[string][]string{
+				"Forwarded":       {"for=6.6.6.6;proto=https;host=evil.example.com"},
+				"X-Real-Ip":       {"6.6.6.6"},
+				"X-Forwarded-For": {"203.0.113.7"},
+			},
+			remoteAddr: "10.0.0.3:443",
+			want: map[string]string{
+				"x-forwarded-for":              "203.0.113.7, 10.0.0.3",
+				"x-forwarded-host":             "bar.foo.example.com",
+				runtime.MetadataClientAddress:  "203.0.113.7",
+				runtime.MetadataClientScheme:   "http",
+				runtime.MetadataForwardedChain: "203.0.113.7, 10.0.0.3",
+			},
+		},
+		{
+			name:    "forwarded header",
+			trusted: []string{"10.0.0.0/8", "2001:db8::/32"},
+			header:  runtime.ForwardedHeaderForwarded,
+			headers: map[string][]string{
+				"Forwarded":       {`for=198.51.100.1;proto=http, for="[2001:db8:cafe::17]:4711";proto=https;host="api.example.com"`, "for=10.0.0.2"},
+				"X-Forwarded-For": {"203.0.113.7"},
+			},
+			remoteAddr: "10.0.0.3:443",
+			want: map[string]string{
+				"x-forwarded-for":              "198.51.100.1, 2001:db8:cafe::17, 10.0.0.2, 10.0.0.3",
+				"x-forwarded-host":             "bar.foo.example.com",
+				runtime.MetadataClientAddress:  "198.51.100.1",
+				runtime.MetadataClientScheme:   "http",
+				runtime.MetadataForwardedChain: "198.51.100.1, 2001:db8:cafe::17, 10.0.0.2, 10.0.0.3",
+			},
+		},
+		{
+			name:    "forwarded header host and proto of the client hop",
+			trusted: []string{"10.0.0.0/8"},
+			header:  runtime.ForwardedHeaderForwarded,
+			headers: map[string][]string{
+				"Forwarded": {`for=203.0.113.7;proto=https;host="api.example.com", for=10.0.0.2`},
+			},
+			remoteAddr: "10.0.0.3:443",
+			want: map[string]string{
+				"x-forwarded-for":              "203.0.113.7, 10.0.0.2, 10.0.0.3",
+				"x-forwarded-host":             "api.example.com",
+				runtime.MetadataClientAddress:  "203.0.113.7",
+				runtime.MetadataClientScheme:   "https",
+				runtime.MetadataForwardedChain: "203.0.113.7, 10.0.0.2, 10.0.0.3",
+			},
+		},
+		{
+			name:       "unsupported x-forwarded-proto is ignored",
+			trusted:    []string{"10.0.0.0/8"},
+			headers:    map[string][]string{"X-Forwarded-For": {"203.0.113.7"}, "X-Forwarded-Proto": {"javascript"}},
+			remoteAddr: "10.0.0.3:443",
+			want: map[string]string{
+				"x-forwarded-for":              "203.0.113.7, 10.0.0.3",
+				runtime.MetadataClientAddress:  "203.0.113.7",
+				runtime.MetadataClientScheme:   "http",
+				runtime.MetadataForwardedChain: "203.0.113.7, 10.0.0.3",
+			},
+		},
+		{
+			name:    "unsupported forwarded proto is ignored",
+			trusted: []string{"10.0.0.0/8"},
+			header:  runtime.ForwardedHeaderForwarded,
+			headers: map[string][]string{
+				"Forwarded": {`for=203.0.113.7;proto="http://evil", for=10.0.0.2`},
+			},
+			remoteAddr: "10.0.0.3:443",
+			want: map[string]string{
+				"x-forwarded-for":              "203.0.113.7, 10.0.0.2, 10.0.0.3",
+				runtime.MetadataClientAddress:  "203.0.113.7",
+				runtime.MetadataClientScheme:   "http",
+				runtime.MetadataForwardedChain: "203.0.113.7, 10.0.0.2, 10.0.0.3",
+			},
+		},
+		{
+			name:       "obfuscated node stops the walk",
+			trusted:    []string{"10.0.0.0/8"},
+			header:     runtime.ForwardedHeaderForwarded,
+			headers:    map[string][]string{"Forwarded": {`for=203.0.113.7, for=_hidden, for=10.0.0.2`}},
+			remoteAddr: "10.0.0.3:443",
+			want: map[string]string{
+				"x-forwarded-for":              "_hidden, 10.0.0.2, 10.0.0.3",
+				"x-forwarded-host":             "bar.foo.example.com",
+				runtime.MetadataClientAddress:  "_hidden",
+				runtime.MetadataClientScheme:   "http",
+				runtime.MetadataForwardedChain: "203.0.113.7, _hidden, 10.0.0.2, 10.0.0.3",
+			},
+		},
+		{
+			name:       "x-real-ip from trusted proxy",
+			trusted:    []string{"10.0.0.3"},
+			header:     runtime.ForwardedHeaderXRealIP,
+			headers:    map[string][]string{"X-Real-Ip": {"203.0.113.7"}},
+			remoteAddr: "10.0.0.3:443",
+			want: map[string]string{
+				"x-forwarded-for":              "203.0.113.7, 10.0.0.3",
+				"x-forwarded-host":             "bar.foo.example.com",
+				runtime.MetadataClientAddress:  "203.0.113.7",
+				runtime.MetadataClientScheme:   "http",
+				runtime.MetadataForwardedChain: "203.0.113.7, 10.0.0.3",
+			},
+		},
+		{
+			name:       "invalid entries are ignored",
+			trusted:    []string{"not-a-cidr"},
+			headers:    map[string][]string{"X-Forwarded-For": {"203.0.113.7"}},
+			remoteAddr: "10.0.0.3:443",
+			want: map[string]string{
+				"x-forwarded-for":              "10.0.0.3",
+				"x-forwarded-host":             "bar.foo.example.com",
+				runtime.MetadataClientAddress:  "10.0.0.3",
+				runtime.MetadataClientScheme:   "http",
+				runtime.MetadataForwardedChain: "203.0.113.7, 10.0.0.3",
+			},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			ctx := context.Background()
+			request, err := http.NewRequestWithContext(ctx, "GET", "http://bar.foo.example.com", nil)
+			if err != nil {
+				t.Fatalf("http.NewRequestWithContext(ctx, %q, %q, nil) failed with %v; want success", "GET", "http://bar.foo.example.com", err)
+			}
+			for key, vals := range spec.headers {
+				for _, val := range vals {
+					request.Header.Add(key, val)
+				}
+			}
+			request.RemoteAddr = spec.remoteAddr
+
+			serveMux := runtime.NewServeMux(
+				runtime.WithTrustedProxies(spec.trusted...),
+				runtime.WithForwardedHeader(spec.header),
+				runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+					return key, true
+				}),
+			)
+			annotated, err := runtime.AnnotateContext(ctx, serveMux, request, "/example.Example/Example")
+			if err != nil {
+				t.Fatalf("runtime.AnnotateContext(ctx, %#v) failed with %v; want success", request, err)
+			}
+			md, _ := metadata.FromOutgoingContext(annotated)
+			for key, want := range spec.want {
+				if got := md[key]; !reflect.DeepEqual(got, []string{want}) {
+					t.Errorf("md[%q] = %v; want %v", key, got, []string{want})
+				}
+			}
+			for _, key := range []string{"forwarded", "x-forwarded-proto", "x-real-ip"} {
+				if got, ok := md[key]; ok {
+					t.Errorf("md[%q] = %v; want no value", key, got)
+				}
+			}
+		})
+	}
+}
+
 func TestAnnotateContext_SupportsTimeouts(t *testing.T) {
 	ctx := context.Background()
 	expectedRPCName := "/example.Example/Example"
@@ -292,6 +487,142 @@ func TestAnnotateContext_SupportsTimeouts(t *testing.T) {
 		}
 	}
 }
+func TestAnnotateContext_SupportsTimeoutPolicy(t *testing.T) {
+	const acceptableError = 50 * time.Millisecond
+	const reportRPCName = "/example.Example/Report"
+	serveMux := runtime.NewServeMux(
+		runtime.WithTimeoutPolicy(runtime.TimeoutPolicy{
+			Default: 5 * time.Second,
+			Max:     30 * time.Second,
+			Min:     time.Second,
+		}),
+		runtime.WithRouteTimeoutPolicy(reportRPCName, runtime.TimeoutPolicy{
+			Default: 2 * time.Minute,
+			Max:     5 * time.Minute,
+		}),
+	)
+	for _, spec := range []struct {
+		name    string
+		rpc     string
+		headers map[string]string
+		want    time.Duration
+	}{
+		{
+			name: "default",
+			rpc:  "/example.Example/Example",
+			want: 5 * time.Second,
+		},
+		{
+			name:    "grpc-timeout within bounds",
+			rpc:     "/example.Example/Example",
+			headers: map[string]string{"Grpc-Timeout": "10S"},
+			want:    10 * time.Second,
+		},
+		{
+			name:    "grpc-timeout capped",
+			rpc:     "/example.Example/Example",
+			headers: map[string]string{"Grpc-Timeout": "1H"},
+			want:    30 * time.Second,
+		},
+		{
+			name:    "grpc-timeout raised",
+			rpc:     "/example.Example/Example",
+			headers: map[string]string{"Grpc-Timeout": "10m"},
+			want:    time.Second,
+		},
+		{
+			name:    "request-timeout seconds",
+			rpc:     "/example.Example/Example",
+			headers: map[string]string{"Request-Timeout": "2.5"},
+			want:    2500 * time.Millisecond,
+		},
+		{
+			name:    "x-request-timeout duration",
+			rpc:     "/example.Example/Example",
+			headers: map[string]string{"X-Request-Timeout": "1500ms"},
+			want:    1500 * time.Millisecond,
+		},
+		{
+			name:    "grpc-timeout takes precedence",
+			rpc:     "/example.Example/Example",
+			headers: map[string]string{"Grpc-Timeout": "3S", "Request-Timeout": "20"},
+			want:    3 * time.Second,
+		},
+		{
+			name: "route default",
+			rpc:  reportRPCName,
+			want: 2 * time.Minute,
+		},
+		{
+			name:    "route cap",
+			rpc:     reportRPCName,
+			headers: map[string]string{"Request-Timeout": "3600"},
+			want:    5 * time.Minute,
+		},
+		{
+			name:    "route inherits min",
+			rpc:     reportRPCName,
+			headers: map[string]string{"Request-Timeout": "0.1"},
+			want:    time.Second,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			ctx := context.Background()
+			request, err := http.NewRequestWithContext(ctx, "GET", "http://example.com", nil)
+			if err != nil {
+				t.Fatalf(`http.NewRequestWithContext(ctx, "GET", "http://example.com", nil) failed with %v; want success`, err)
+			}
+			for key, val := range spec.headers {
+				request.Header.Set(key, val)
+			}
+			annotated, err := runtime.AnnotateContext(ctx, serveMux, request, spec.rpc)
+			if err != nil {
+				t.Fatalf("runtime.AnnotateContext(ctx, %#v) failed with %v; want success", request, err)
+			}
+			deadline, ok := annotated.Deadline()
+			if !ok {
+				t.Fatalf("annotated.Deadline() = _, false; want _, true")
+			}
+			if got, want := time.Until(deadline), spec.want; got-want > acceptableError || got-want < -acceptableError {
+				t.Errorf("time.Until(deadline) = %v; want %v; with error %v", got, want, acceptableError)
+			}
+			if got, ok := runtime.RequestTimeout(annotated); !ok || got != spec.want {
+				t.Errorf("runtime.RequestTimeout(annotated) = %v, %t; want %v, true", got, ok, spec.want)
+			}
+			md, _ := metadata.FromOutgoingContext(annotated)
+			for _, key := range []string{"request-timeout", "x-request-timeout"} {
+				if got, ok := md[key]; ok {
+					t.Errorf("md[%q] = %v; want no value", key, got)
+				}
+			}
+		})
+	}
+}
+
+func TestAnnotateContext_TimeoutPolicyCapsUnboundedRequests(t *testing.T) {
+	defer func(timeout time.Duration) { runtime.DefaultContextTimeout = timeout }(runtime.DefaultContextTimeout)
+	runtime.DefaultContextTimeout = 0
+
+	ctx := context.Background()
+	request, err := http.NewRequestWithContext(ctx, "GET", "http://example.com", nil)
+	if err != nil {
+		t.Fatalf(`http.NewRequestWithContext(ctx, "GET", "http://example.com", nil) failed with %v; want success`, err)
+	}
+	serveMux := runtime.NewServeMux(runtime.WithTimeoutPolicy(runtime.TimeoutPolicy{Max: time.Minute}))
+	annotated, err := runtime.AnnotateContext(ctx, serveMux, request, "/example.Example/Example")
+	if err != nil {
+		t.Fatalf("runtime.AnnotateContext(ctx, %#v) failed with %v; want success", request, err)
+	}
+	if got, ok := runtime.RequestTimeout(annotated); !ok || got != time.Minute {
+		t.Errorf("runtime.RequestTimeout(annotated) = %v, %t; want %v, true", got, ok, time.Minute)
+	}
+
+	request.Header.Set("Request-Timeout", "soon")
+	if _, err := runtime.AnnotateContext(ctx, serveMux, request, "/example.Example/Example"); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("runtime.AnnotateContext(ctx, %#v) failed with %v; want %v", request, err, codes.InvalidArgument)
+	}
+}
+
 func TestAnnotateContext_SupportsCustomAnnotators(t *testing.T) {
 	ctx := context.Background()
 	md1 := func(context.Context, *http.Request) metadata.MD { return metadata.New(map[string]string{"foo": "bar"}) }
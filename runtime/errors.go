@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/grpclog"
@@ -85,7 +86,9 @@ func HTTPError(ctx context.Context, mux *ServeMux, marshaler Marshaler, w http.R
 func HTTPStreamError(ctx context.Context, mux *ServeMux, marshaler Marshaler, w http.ResponseWriter, r *http.Request, err error) {
 	st := mux.streamErrorHandler(ctx, err)
 	msg := errorChunk(st)
+	start := time.Now()
 	buf, err := marshaler.Marshal(msg)
+	requestTrackerFromContext(ctx).marshaled(start)
 	if err != nil {
 		grpclog.Errorf("Failed to marshal an error: %v", err)
 		return
@@ -104,6 +107,9 @@ func HTTPStreamError(ctx context.Context, mux *ServeMux, marshaler Marshaler, w
 // are insufficient for.
 // If otherwise, it replies with http.StatusInternalServerError.
 //
+// Deadline errors, including a bare context.DeadlineExceeded, are replied to with
+// http.StatusGatewayTimeout and a message stating the timeout the gateway applied.
+//
 // The response body written by this function is a Status message marshaled by the Marshaler.
 func DefaultHTTPErrorHandler(ctx context.Context, mux *ServeMux, marshaler Marshaler, w http.ResponseWriter, r *http.Request, err error) {
 	// return Internal when Marshal failed
@@ -115,7 +121,14 @@ func DefaultHTTPErrorHandler(ctx context.Context, mux *ServeMux, marshaler Marsh
 		err = customStatus.Err
 	}
 
+	if _, ok := status.FromError(err); !ok && errors.Is(err, context.DeadlineExceeded) {
+		err = status.FromContextError(err).Err()
+	}
+
 	s := status.Convert(err)
+	if s.Code() == codes.DeadlineExceeded {
+		s = deadlineExceededStatus(ctx, s)
+	}
 
 	w.Header().Del("Trailer")
 	w.Header().Del("Transfer-Encoding")
@@ -137,7 +150,9 @@ func DefaultHTTPErrorHandler(ctx context.Context, mux *ServeMux, marshaler Marsh
 		w.Header().Set("WWW-Authenticate", s.Message())
 	}
 
+	start := time.Now()
 	buf, merr := marshaler.Marshal(respRw)
+	requestTrackerFromContext(ctx).marshaled(start)
 	if merr != nil {
 		grpclog.Errorf("Failed to marshal error message %q: %v", s, merr)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -149,7 +164,7 @@ func DefaultHTTPErrorHandler(ctx context.Context, mux *ServeMux, marshaler Marsh
 
 	md, ok := ServerMetadataFromContext(ctx)
 	if ok {
-		handleForwardResponseServerMetadata(w, mux, md)
+		handleForwardResponseServerMetadata(w, mux, md, false)
 
 		// RFC 7230 https://tools.ietf.org/html/rfc7230#section-4.1.2
 		// Unless the request includes a TE header field indicating "trailers"
@@ -189,16 +204,45 @@ func DefaultStreamErrorHandler(_ context.Context, err error) *status.Status {
 //	NotFound -> grpc.NotFound
 //	StatusBadRequest -> grpc.InvalidArgument
 //	MethodNotAllowed -> grpc.Unimplemented
+//	NotAcceptable, UnsupportedMediaType -> grpc.InvalidArgument, keeping the HTTP status
 //	Other -> grpc.Internal, method is not expecting to be called for anything else
 func DefaultRoutingErrorHandler(ctx context.Context, mux *ServeMux, marshaler Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
-	sterr := status.Error(codes.Internal, "Unexpected routing error")
+	var sterr error = status.Error(codes.Internal, "Unexpected routing error")
+	switch code := routingErrorCode(httpStatus); httpStatus {
+	case http.StatusNotAcceptable, http.StatusUnsupportedMediaType:
+		sterr = &HTTPStatusError{
+			HTTPStatus: httpStatus,
+			Err:        status.Error(code, http.StatusText(httpStatus)),
+		}
+	default:
+		if code != codes.Internal {
+			sterr = status.Error(code, http.StatusText(httpStatus))
+		}
+	}
+	mux.errorHandler(ctx, mux, marshaler, w, r, sterr)
+}
+
+// routingErrorCode maps the HTTP status of a routing error to a gRPC status code.
+func routingErrorCode(httpStatus int) codes.Code {
 	switch httpStatus {
-	case http.StatusBadRequest:
-		sterr = status.Error(codes.InvalidArgument, http.StatusText(httpStatus))
+	case http.StatusBadRequest, http.StatusNotAcceptable, http.StatusUnsupportedMediaType:
+		return codes.InvalidArgument
 	case http.StatusMethodNotAllowed:
-		sterr = status.Error(codes.Unimplemented, http.StatusText(httpStatus))
+		return codes.Unimplemented
 	case http.StatusNotFound:
-		sterr = status.Error(codes.NotFound, http.StatusText(httpStatus))
+		return codes.NotFound
 	}
-	mux.errorHandler(ctx, mux, marshaler, w, r, sterr)
+	return codes.Internal
+}
+
+// errorCode returns the gRPC status code DefaultHTTPErrorHandler replies with for err.
+func errorCode(err error) codes.Code {
+	var customStatus *HTTPStatusError
+	if errors.As(err, &customStatus) {
+		err = customStatus.Err
+	}
+	if _, ok := status.FromError(err); !ok && errors.Is(err, context.DeadlineExceeded) {
+		return codes.DeadlineExceeded
+	}
+	return status.Code(err)
 }
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -134,6 +136,39 @@ func TestDefaultHTTPError(t *testing.T) {
 	}
 }
 
+func TestDefaultHTTPError_DeadlineExceeded(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithTimeoutPolicy(runtime.TimeoutPolicy{Default: 3 * time.Second}))
+	req := httptest.NewRequest("GET", "/", nil)
+	ctx, err := runtime.AnnotateContext(context.Background(), mux, req, "/example.Example/Example")
+	if err != nil {
+		t.Fatalf("runtime.AnnotateContext(ctx, %#v) failed with %v; want success", req, err)
+	}
+
+	for _, err := range []error{
+		context.DeadlineExceeded,
+		fmt.Errorf("calling backend: %w", context.DeadlineExceeded),
+		status.Error(codes.DeadlineExceeded, "context deadline exceeded"),
+	} {
+		w := httptest.NewRecorder()
+		marshaler := &runtime.JSONPb{}
+		runtime.HTTPError(ctx, mux, marshaler, w, req, err)
+
+		if got, want := w.Code, http.StatusGatewayTimeout; got != want {
+			t.Errorf("w.Code = %d; want %d; on err=%v", got, want, err)
+		}
+		var st statuspb.Status
+		if err := marshaler.Unmarshal(w.Body.Bytes(), &st); err != nil {
+			t.Fatalf("marshaler.Unmarshal(%q, &body) failed with %v; want success", w.Body.Bytes(), err)
+		}
+		if got, want := codes.Code(st.Code), codes.DeadlineExceeded; got != want {
+			t.Errorf("st.Code = %v; want %v; on err=%v", got, want, err)
+		}
+		if got, want := st.Message, "request did not complete within the 3s deadline"; !strings.HasPrefix(got, want) {
+			t.Errorf("st.Message = %q; want prefix %q; on err=%v", got, want, err)
+		}
+	}
+}
+
 func TestHTTPStreamError(t *testing.T) {
 	ctx := context.Background()
 
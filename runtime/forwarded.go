@@ -0,0 +1,310 @@
+package runtime
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc/grpclog"
+)
+
+// MetadataClientAddress is the gRPC metadata key holding the address of the
+// originating client, as resolved from the trusted proxy chain. It is only set
+// when the ServeMux is configured with WithTrustedProxies.
+const MetadataClientAddress = MetadataPrefix + "client-address"
+
+// MetadataClientScheme is the gRPC metadata key holding the scheme ("http" or
+// "https") used by the originating client. It is only set when the ServeMux is
+// configured with WithTrustedProxies.
+const MetadataClientScheme = MetadataPrefix + "client-scheme"
+
+// MetadataForwardedChain is the gRPC metadata key holding the full, unfiltered
+// list of hops the request claims to have traversed, including hops that were
+// not trusted. It is only set when the ServeMux is configured with
+// WithTrustedProxies and is meant for auditing, not for access decisions.
+const MetadataForwardedChain = MetadataPrefix + "forwarded-chain"
+
+// ForwardedHeader is the header from which a ServeMux configured with
+// WithTrustedProxies reads the chain of proxies. Only the header set by the
+// trusted proxies may be read: any other one is under the control of the
+// client.
+type ForwardedHeader int
+
+const (
+	// ForwardedHeaderXForwardedFor reads the chain from X-Forwarded-For, and
+	// the scheme and host from X-Forwarded-Proto and X-Forwarded-Host. This is
+	// the default.
+	ForwardedHeaderXForwardedFor ForwardedHeader = iota
+
+	// ForwardedHeaderForwarded reads the chain, the scheme and the host from
+	// the RFC 7239 Forwarded header.
+	ForwardedHeaderForwarded
+
+	// ForwardedHeaderXRealIP reads the client address from X-Real-IP, and the
+	// scheme and host from X-Forwarded-Proto and X-Forwarded-Host.
+	ForwardedHeaderXRealIP
+)
+
+const forwarded = "Forwarded"
+const xForwardedProto = "X-Forwarded-Proto"
+const xRealIP = "X-Real-Ip"
+
+// forwardedHop is a single node in the chain of proxies a request passed through.
+type forwardedHop struct {
+	// node is the normalized node identifier: an IP address without port, or
+	// the raw identifier for "unknown" and obfuscated nodes.
+	node  string
+	addr  netip.Addr
+	proto string
+	host  string
+}
+
+// forwardedClient is the result of resolving a request's proxy chain.
+type forwardedClient struct {
+	addr   string
+	scheme string
+	host   string
+	// trusted is the part of the chain starting at the client, followed by
+	// every trusted proxy that handled the request.
+	trusted []string
+	// chain is every hop the request claims to have traversed.
+	chain []string
+}
+
+// parseTrustedProxies parses a list of CIDRs or plain IP addresses. Invalid
+// entries are logged and skipped.
+func parseTrustedProxies(cidrs []string) []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if !strings.Contains(cidr, "/") {
+			addr, err := netip.ParseAddr(cidr)
+			if err != nil {
+				grpclog.Errorf("Trusted proxy %q is not a valid IP address or CIDR; skipping", cidr)
+				continue
+			}
+			addr = addr.Unmap()
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			grpclog.Errorf("Trusted proxy %q is not a valid IP address or CIDR; skipping", cidr)
+			continue
+		}
+		if prefix.Addr().Is4In6() {
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes
+}
+
+func (s *ServeMux) isTrustedProxy(hop forwardedHop) bool {
+	if !hop.addr.IsValid() {
+		return false
+	}
+	for _, prefix := range s.trustedProxies {
+		if prefix.Contains(hop.addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveForwardedClient walks the proxy chain of req from the nearest hop
+// towards the client, stopping at the first hop which is not a trusted proxy.
+// The chain is only read from the forwarded header of the ServeMux, and is
+// only honored when it was set by a trusted proxy.
+func (s *ServeMux) resolveForwardedClient(req *http.Request) forwardedClient {
+	var hops []forwardedHop
+	switch s.forwardedHeader {
+	case ForwardedHeaderForwarded:
+		hops = parseForwardedHeader(req.Header.Values(forwarded))
+	case ForwardedHeaderXRealIP:
+		if ip := strings.TrimSpace(req.Header.Get(xRealIP)); ip != "" {
+			hops = []forwardedHop{newForwardedHop(ip)}
+		}
+	default:
+		hops = parseXForwardedFor(req.Header.Values(xForwardedFor))
+	}
+	remote := forwardedHop{}
+	if req.RemoteAddr != "" {
+		remote = newForwardedHop(req.RemoteAddr)
+		hops = append(hops, remote)
+	}
+
+	client := forwardedClient{
+		scheme: "http",
+		host:   req.Host,
+	}
+	if req.TLS != nil {
+		client.scheme = "https"
+	}
+	if len(hops) == 0 {
+		return client
+	}
+	for _, hop := range hops {
+		client.chain = append(client.chain, hop.node)
+	}
+
+	i := len(hops) - 1
+	for i > 0 && s.isTrustedProxy(hops[i]) {
+		i--
+	}
+	for _, hop := range hops[i:] {
+		client.trusted = append(client.trusted, hop.node)
+	}
+	client.addr = hops[i].node
+
+	if i == len(hops)-1 {
+		// The request did not come through a trusted proxy, so none of its
+		// forwarding headers can be relied upon.
+		return client
+	}
+	if s.forwardedHeader == ForwardedHeaderForwarded {
+		if scheme, ok := forwardedScheme(hops[i].proto); ok {
+			client.scheme = scheme
+		}
+		if host := hops[i].host; host != "" {
+			client.host = host
+		}
+		return client
+	}
+	if scheme, ok := forwardedScheme(lastListElement(req.Header.Values(xForwardedProto))); ok {
+		client.scheme = scheme
+	}
+	if host := lastListElement(req.Header.Values(xForwardedHost)); host != "" {
+		client.host = host
+	}
+	return client
+}
+
+// forwardedScheme returns the scheme "proto" read from a forwarding header,
+// if it is "http" or "https". Other values are ignored, as the scheme is
+// passed on to the backend.
+func forwardedScheme(proto string) (string, bool) {
+	scheme := strings.ToLower(strings.TrimSpace(proto))
+	return scheme, scheme == "http" || scheme == "https"
+}
+
+// newForwardedHop parses a node identifier as found in X-Forwarded-For,
+// X-Real-IP, the "for" parameter of the Forwarded header or http.Request.RemoteAddr.
+func newForwardedHop(node string) forwardedHop {
+	if addr, err := netip.ParseAddr(node); err == nil {
+		addr = addr.Unmap().WithZone("")
+		return forwardedHop{node: addr.String(), addr: addr}
+	}
+	if host, _, err := net.SplitHostPort(node); err == nil {
+		if addr, err := netip.ParseAddr(host); err == nil {
+			addr = addr.Unmap().WithZone("")
+			return forwardedHop{node: addr.String(), addr: addr}
+		}
+		node = host
+	}
+	// Bracketed IPv6 address without a port.
+	if strings.HasPrefix(node, "[") && strings.HasSuffix(node, "]") {
+		if addr, err := netip.ParseAddr(node[1 : len(node)-1]); err == nil {
+			addr = addr.Unmap().WithZone("")
+			return forwardedHop{node: addr.String(), addr: addr}
+		}
+	}
+	return forwardedHop{node: node}
+}
+
+func parseXForwardedFor(values []string) []forwardedHop {
+	var hops []forwardedHop
+	for _, value := range values {
+		for _, node := range strings.Split(value, ",") {
+			if node = strings.TrimSpace(node); node == "" {
+				continue
+			}
+			hops = append(hops, newForwardedHop(node))
+		}
+	}
+	return hops
+}
+
+// parseForwardedHeader parses the values of an RFC 7239 Forwarded header.
+// Elements without a "for" parameter are recorded as "unknown" nodes, so they
+// can never be mistaken for a trusted proxy.
+func parseForwardedHeader(values []string) []forwardedHop {
+	var hops []forwardedHop
+	for _, value := range values {
+		for _, element := range splitQuoted(value, ',') {
+			if strings.TrimSpace(element) == "" {
+				continue
+			}
+			hop := forwardedHop{node: "unknown"}
+			for _, pair := range splitQuoted(element, ';') {
+				key, val, ok := strings.Cut(pair, "=")
+				if !ok {
+					continue
+				}
+				val = unquoteForwardedValue(strings.TrimSpace(val))
+				switch strings.ToLower(strings.TrimSpace(key)) {
+				case "for":
+					node := hop
+					hop = newForwardedHop(val)
+					hop.proto, hop.host = node.proto, node.host
+				case "proto":
+					hop.proto = strings.ToLower(val)
+				case "host":
+					hop.host = val
+				}
+			}
+			hops = append(hops, hop)
+		}
+	}
+	return hops
+}
+
+// splitQuoted splits s at every sep which is not part of a quoted-string.
+func splitQuoted(s string, sep byte) []string {
+	var parts []string
+	inQuotes, escaped := false, false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case escaped:
+			escaped = false
+		case c == '\\' && inQuotes:
+			escaped = true
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == sep && !inQuotes:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func unquoteForwardedValue(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+	s = s[1 : len(s)-1]
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// lastListElement returns the last element of a comma-separated header, which
+// is the one appended by the nearest proxy.
+func lastListElement(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	elems := strings.Split(values[len(values)-1], ",")
+	return strings.TrimSpace(elems[len(elems)-1])
+}
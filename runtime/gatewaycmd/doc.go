@@ -0,0 +1,45 @@
+/*
+Package gatewaycmd runs a gateway as a standalone command, configured by a
+YAML file and environment variables instead of handwritten Go.
+
+protoc-gen-grpc-gateway generates the main package of such a command with
+the generate_gateway_main option. It lists the services of the generated
+files:
+
+	func main() {
+		gatewaycmd.Main(
+			gatewaycmd.Service{Name: "library.v1.LibraryService", Register: librarypb.RegisterLibraryServiceHandlerFromEndpoint},
+		)
+	}
+
+The command reads its configuration from the file of the -config flag, or of
+the GATEWAY_CONFIG environment variable:
+
+	address: :8080
+	basePath: /api
+	endpoint: localhost:9090
+	endpoints:
+	  library.v1.LibraryService: library:9090
+	backendTLS:
+	  enabled: true
+	  caFile: /etc/gateway/ca.pem
+	marshaler:
+	  useProtoNames: true
+	headers:
+	  incoming: [x-request-id]
+	  outgoing: [x-request-id]
+	cors:
+	  allowedOrigins: [https://example.com]
+	health:
+	  path: /healthz
+	shutdownTimeout: 10s
+
+References to environment variables, such as ${BACKEND_ENDPOINT}, are
+expanded in the file, while other uses of "$" are kept as is, and the GATEWAY_ADDRESS, GATEWAY_BASE_PATH and
+GATEWAY_ENDPOINT environment variables override the address, base path and
+endpoint of the file.
+
+The command serves the health endpoint, answers CORS requests, and shuts
+down gracefully on SIGINT and SIGTERM.
+*/
+package gatewaycmd
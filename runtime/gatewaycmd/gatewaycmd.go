@@ -0,0 +1,382 @@
+package gatewaycmd
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Config is the configuration of a gateway command.
+type Config struct {
+	// Address is the address the gateway listens on, ":8080" by default.
+	Address string `yaml:"address"`
+	// BasePath is the path the routes are mounted under, see
+	// runtime.WithBasePath.
+	BasePath string `yaml:"basePath"`
+	// Endpoint is the address of the gRPC server of the services missing
+	// from Endpoints.
+	Endpoint string `yaml:"endpoint"`
+	// Endpoints maps the full names of services, such as
+	// "library.v1.LibraryService", to the address of their gRPC server.
+	Endpoints map[string]string `yaml:"endpoints"`
+	// TLS is the certificate the gateway serves HTTPS with, if any.
+	TLS TLSConfig `yaml:"tls"`
+	// BackendTLS configures the connections to the gRPC servers.
+	BackendTLS BackendTLSConfig `yaml:"backendTLS"`
+	// Marshaler configures the JSON encoding of the messages.
+	Marshaler MarshalerConfig `yaml:"marshaler"`
+	// Headers configures the headers forwarded to and from the gRPC servers.
+	Headers HeadersConfig `yaml:"headers"`
+	// CORS configures the cross-origin requests answered by the gateway.
+	CORS CORSConfig `yaml:"cors"`
+	// Health configures the health endpoint.
+	Health HealthConfig `yaml:"health"`
+	// ShutdownTimeout bounds the time the gateway waits for the requests in
+	// flight when shutting down, 10 seconds by default.
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+}
+
+// TLSConfig is the certificate a gateway serves HTTPS with.
+type TLSConfig struct {
+	CertFile string `yaml:"certFile"`
+	KeyFile  string `yaml:"keyFile"`
+}
+
+// BackendTLSConfig configures the TLS connections to the gRPC servers.
+type BackendTLSConfig struct {
+	// Enabled connects to the gRPC servers with TLS, instead of plaintext.
+	Enabled bool `yaml:"enabled"`
+	// CAFile is the PEM file of the certificate authorities of the gRPC
+	// servers, the ones of the system by default.
+	CAFile string `yaml:"caFile"`
+	// ServerName overrides the name the certificates of the gRPC servers are
+	// verified against.
+	ServerName         string `yaml:"serverName"`
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify"`
+}
+
+// MarshalerConfig configures the JSON encoding of the messages, see
+// protojson.MarshalOptions and protojson.UnmarshalOptions.
+type MarshalerConfig struct {
+	UseProtoNames  bool `yaml:"useProtoNames"`
+	UseEnumNumbers bool `yaml:"useEnumNumbers"`
+	// OmitUnpopulated leaves the unpopulated fields out of the responses.
+	OmitUnpopulated bool `yaml:"omitUnpopulated"`
+	// RejectUnknown fails the requests with unknown fields.
+	RejectUnknown bool `yaml:"rejectUnknown"`
+}
+
+// HeadersConfig configures the headers forwarded to and from the gRPC
+// servers, besides the ones forwarded by default.
+type HeadersConfig struct {
+	// Incoming lists the HTTP request headers forwarded as gRPC metadata.
+	Incoming []string `yaml:"incoming"`
+	// Outgoing lists the gRPC response metadata forwarded as HTTP response
+	// headers of the same name, instead of with the Grpc-Metadata- prefix.
+	Outgoing []string `yaml:"outgoing"`
+}
+
+// CORSConfig configures the cross-origin requests answered by a gateway.
+// They are not answered without AllowedOrigins.
+type CORSConfig struct {
+	// AllowedOrigins lists the allowed origins, or "*" for all, which cannot
+	// be combined with AllowCredentials.
+	AllowedOrigins []string `yaml:"allowedOrigins"`
+	// AllowedMethods lists the allowed methods, GET, POST, PUT, PATCH and
+	// DELETE by default.
+	AllowedMethods []string `yaml:"allowedMethods"`
+	// AllowedHeaders lists the allowed request headers, the ones of the
+	// preflight requests by default.
+	AllowedHeaders   []string      `yaml:"allowedHeaders"`
+	AllowCredentials bool          `yaml:"allowCredentials"`
+	MaxAge           time.Duration `yaml:"maxAge"`
+}
+
+// HealthConfig configures the health endpoint of a gateway.
+type HealthConfig struct {
+	// Path is the path of the health endpoint, "/healthz" by default.
+	Path string `yaml:"path"`
+	// Endpoint is the address of the gRPC server implementing the gRPC
+	// Health Checking Protocol, Config.Endpoint by default.
+	Endpoint string `yaml:"endpoint"`
+	// Disabled disables the health endpoint.
+	Disabled bool `yaml:"disabled"`
+}
+
+// Service is a service served by a gateway.
+type Service struct {
+	// Name is the full name of the service, such as
+	// "library.v1.LibraryService".
+	Name string
+	// Register is the generated Register*HandlerFromEndpoint function of the
+	// service.
+	Register func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, registerOpts ...runtime.RegisterOption) error
+}
+
+// envReference matches the ${VAR} references to environment variables of a
+// configuration file. Other uses of "$", such as in regular expressions or
+// passwords, are left as is.
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// LoadConfig reads the configuration of the YAML file "path", if not empty,
+// expanding the ${VAR} references to environment variables, and applies the
+// GATEWAY_ADDRESS, GATEWAY_BASE_PATH and GATEWAY_ENDPOINT environment
+// variables.
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to read config: %w", err)
+		}
+		data = envReference.ReplaceAllFunc(data, func(ref []byte) []byte {
+			return []byte(os.Getenv(string(ref[2 : len(ref)-1])))
+		})
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return Config{}, fmt.Errorf("failed to parse config %s: %w", path, err)
+		}
+	}
+	for env, field := range map[string]*string{
+		"GATEWAY_ADDRESS":   &cfg.Address,
+		"GATEWAY_BASE_PATH": &cfg.BasePath,
+		"GATEWAY_ENDPOINT":  &cfg.Endpoint,
+	} {
+		if v, ok := os.LookupEnv(env); ok {
+			*field = v
+		}
+	}
+	return cfg, nil
+}
+
+// NewHandler returns the handler of a gateway serving "services" with the
+// configuration "cfg", and the ServeMux options "opts". The connections to
+// the gRPC servers are closed when "ctx" is done.
+func NewHandler(ctx context.Context, cfg Config, services []Service, opts ...runtime.ServeMuxOption) (http.Handler, error) {
+	if cfg.CORS.AllowCredentials && slices.Contains(cfg.CORS.AllowedOrigins, "*") {
+		return nil, errors.New(`cors: allowedOrigins "*" cannot be combined with allowCredentials`)
+	}
+	dialOpts, err := cfg.BackendTLS.dialOptions()
+	if err != nil {
+		return nil, err
+	}
+	muxOpts := []runtime.ServeMuxOption{
+		runtime.WithBasePath(cfg.BasePath),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, cfg.Marshaler.marshaler()),
+	}
+	if len(cfg.Headers.Incoming) > 0 {
+		muxOpts = append(muxOpts, runtime.WithIncomingHeaderMatcher(cfg.Headers.incomingMatcher))
+	}
+	if len(cfg.Headers.Outgoing) > 0 {
+		muxOpts = append(muxOpts, runtime.WithOutgoingHeaderMatcher(cfg.Headers.outgoingMatcher))
+	}
+	if endpoint := cmp.Or(cfg.Health.Endpoint, cfg.Endpoint); !cfg.Health.Disabled && endpoint != "" {
+		conn, err := grpc.NewClient(endpoint, dialOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial %s: %w", endpoint, err)
+		}
+		go func() {
+			<-ctx.Done()
+			if err := conn.Close(); err != nil {
+				grpclog.Errorf("Failed to close conn to %s: %v", endpoint, err)
+			}
+		}()
+		muxOpts = append(muxOpts, runtime.WithHealthEndpointAt(grpc_health_v1.NewHealthClient(conn), cmp.Or(cfg.Health.Path, "/healthz")))
+	}
+	mux := runtime.NewServeMux(append(muxOpts, opts...)...)
+
+	for _, svc := range services {
+		endpoint := cmp.Or(cfg.Endpoints[svc.Name], cfg.Endpoint)
+		if endpoint == "" {
+			return nil, fmt.Errorf("no endpoint for service %s", svc.Name)
+		}
+		if err := svc.Register(ctx, mux, endpoint, dialOpts); err != nil {
+			return nil, fmt.Errorf("failed to register service %s: %w", svc.Name, err)
+		}
+	}
+	if len(cfg.CORS.AllowedOrigins) == 0 {
+		return mux, nil
+	}
+	return cfg.CORS.handler(mux), nil
+}
+
+// Run serves "services" with the configuration "cfg" until "ctx" is done,
+// and then shuts the gateway down gracefully.
+func Run(ctx context.Context, cfg Config, services []Service) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	handler, err := NewHandler(ctx, cfg, services)
+	if err != nil {
+		return err
+	}
+	lis, err := net.Listen("tcp", cmp.Or(cfg.Address, ":8080"))
+	if err != nil {
+		return err
+	}
+	return serve(ctx, cfg, &http.Server{Handler: handler}, lis)
+}
+
+func serve(ctx context.Context, cfg Config, srv *http.Server, lis net.Listener) error {
+	errc := make(chan error, 1)
+	go func() {
+		grpclog.Infof("Serving gateway on %s", lis.Addr())
+		if cfg.TLS.CertFile != "" {
+			errc <- srv.ServeTLS(lis, cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		} else {
+			errc <- srv.Serve(lis)
+		}
+	}()
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	timeout := cfg.ShutdownTimeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	grpclog.Infof("Shutting down gateway")
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Main runs a gateway serving "services" with the configuration of the file
+// of the -config flag, or of the GATEWAY_CONFIG environment variable, until
+// it gets SIGINT or SIGTERM. It exits on errors.
+func Main(services ...Service) {
+	configPath := flag.String("config", os.Getenv("GATEWAY_CONFIG"), "path of the YAML configuration file of the gateway")
+	flag.Parse()
+
+	cfg, err := LoadConfig(*configPath)
+	if err != nil {
+		grpclog.Fatal(err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := Run(ctx, cfg, services); err != nil {
+		grpclog.Fatal(err)
+	}
+}
+
+func (c BackendTLSConfig) dialOptions() ([]grpc.DialOption, error) {
+	if !c.Enabled {
+		return []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, nil
+	}
+	tlsConfig := &tls.Config{
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backend CA: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate in backend CA %s", c.CAFile)
+		}
+	}
+	return []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}, nil
+}
+
+func (c MarshalerConfig) marshaler() runtime.Marshaler {
+	return &runtime.HTTPBodyMarshaler{
+		Marshaler: &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   c.UseProtoNames,
+				UseEnumNumbers:  c.UseEnumNumbers,
+				EmitUnpopulated: !c.OmitUnpopulated,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: !c.RejectUnknown,
+			},
+		},
+	}
+}
+
+func (c HeadersConfig) incomingMatcher(key string) (string, bool) {
+	if slices.ContainsFunc(c.Incoming, func(h string) bool { return strings.EqualFold(h, key) }) {
+		return strings.ToLower(key), true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func (c HeadersConfig) outgoingMatcher(key string) (string, bool) {
+	if slices.ContainsFunc(c.Outgoing, func(h string) bool { return strings.EqualFold(h, key) }) {
+		return key, true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// handler returns "h" answering the cross-origin requests allowed by c.
+func (c CORSConfig) handler(h http.Handler) http.Handler {
+	methods := c.AllowedMethods
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !c.allows(origin) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		if slices.Contains(c.AllowedOrigins, "*") {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			w.Header().Add("Vary", "Origin")
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		if c.AllowCredentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+		if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		// Answer the preflight request.
+		w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+		if len(c.AllowedHeaders) > 0 {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(c.AllowedHeaders, ", "))
+		} else if headers := r.Header.Get("Access-Control-Request-Headers"); headers != "" {
+			w.Header().Set("Access-Control-Allow-Headers", headers)
+		}
+		if c.MaxAge > 0 {
+			w.Header().Set("Access-Control-Max-Age", strconv.Itoa(int(c.MaxAge.Seconds())))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (c CORSConfig) allows(origin string) bool {
+	return slices.Contains(c.AllowedOrigins, "*") || slices.Contains(c.AllowedOrigins, origin)
+}
@@ -0,0 +1,212 @@
+package gatewaycmd_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/gatewaycmd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const config = `
+address: ${GATEWAYCMD_TEST_ADDRESS}
+basePath: /api
+endpoint: localhost:9090
+endpoints:
+  library.v1.LibraryService: library:9090
+marshaler:
+  useProtoNames: true
+headers:
+  incoming: [x-request-id]
+  outgoing: [$x-cost]
+cors:
+  allowedOrigins: [https://example.com]
+  maxAge: 1m
+shutdownTimeout: 5s
+`
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gateway.yaml")
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GATEWAYCMD_TEST_ADDRESS", ":8081")
+	t.Setenv("GATEWAY_ENDPOINT", "backend:9090")
+
+	got, err := gatewaycmd.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("gatewaycmd.LoadConfig() failed with %v", err)
+	}
+	want := gatewaycmd.Config{
+		Address:         ":8081",
+		BasePath:        "/api",
+		Endpoint:        "backend:9090",
+		Endpoints:       map[string]string{"library.v1.LibraryService": "library:9090"},
+		Marshaler:       gatewaycmd.MarshalerConfig{UseProtoNames: true},
+		Headers:         gatewaycmd.HeadersConfig{Incoming: []string{"x-request-id"}, Outgoing: []string{"$x-cost"}},
+		CORS:            gatewaycmd.CORSConfig{AllowedOrigins: []string{"https://example.com"}, MaxAge: time.Minute},
+		ShutdownTimeout: 5 * time.Second,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("gatewaycmd.LoadConfig() differs (-want +got):\n%s", diff)
+	}
+
+	if _, err := gatewaycmd.LoadConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Errorf("gatewaycmd.LoadConfig() succeeded with a missing file; want an error")
+	}
+}
+
+// serveHealth serves the gRPC health service, and returns its address.
+func serveHealth(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+func TestNewHandler(t *testing.T) {
+	endpoint := serveHealth(t)
+	endpoints := map[string]string{}
+	service := func(name string) gatewaycmd.Service {
+		return gatewaycmd.Service{
+			Name: name,
+			Register: func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, registerOpts ...runtime.RegisterOption) error {
+				endpoints[name] = endpoint
+				return mux.HandlePath(http.MethodGet, "/v1/ping", func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+					w.WriteHeader(http.StatusOK)
+				})
+			},
+		}
+	}
+	cfg := gatewaycmd.Config{
+		BasePath:  "/api",
+		Endpoint:  endpoint,
+		Endpoints: map[string]string{"library.v1.LibraryService": "library:9090"},
+		CORS:      gatewaycmd.CORSConfig{AllowedOrigins: []string{"https://example.com"}, MaxAge: time.Minute},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	handler, err := gatewaycmd.NewHandler(ctx, cfg, []gatewaycmd.Service{service("library.v1.LibraryService"), service("shop.v1.ShopService")})
+	if err != nil {
+		t.Fatalf("gatewaycmd.NewHandler() failed with %v", err)
+	}
+	want := map[string]string{"library.v1.LibraryService": "library:9090", "shop.v1.ShopService": endpoint}
+	if diff := cmp.Diff(want, endpoints); diff != "" {
+		t.Errorf("endpoints differ (-want +got):\n%s", diff)
+	}
+
+	for _, path := range []string{"/api/healthz", "/api/v1/ping"} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("GET %s status = %d; want %d", path, w.Code, http.StatusOK)
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodOptions, "/api/v1/ping", nil)
+	r.Header.Set("Origin", "https://example.com")
+	r.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	r.Header.Set("Access-Control-Request-Headers", "authorization")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("preflight status = %d; want %d", w.Code, http.StatusNoContent)
+	}
+	for header, want := range map[string]string{
+		"Access-Control-Allow-Origin":  "https://example.com",
+		"Access-Control-Allow-Headers": "authorization",
+		"Access-Control-Max-Age":       "60",
+	} {
+		if got := w.Header().Get(header); got != want {
+			t.Errorf("preflight %s = %q; want %q", header, got, want)
+		}
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/api/v1/ping", nil)
+	r.Header.Set("Origin", "https://attacker.example")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Access-Control-Allow-Origin = %q for a disallowed origin; want none", got)
+	}
+}
+
+func TestNewHandlerCORSWildcard(t *testing.T) {
+	service := gatewaycmd.Service{
+		Name: "library.v1.LibraryService",
+		Register: func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, registerOpts ...runtime.RegisterOption) error {
+			return nil
+		},
+	}
+	cfg := gatewaycmd.Config{
+		Endpoint: "localhost:9090",
+		Health:   gatewaycmd.HealthConfig{Disabled: true},
+		CORS:     gatewaycmd.CORSConfig{AllowedOrigins: []string{"*"}},
+	}
+	handler, err := gatewaycmd.NewHandler(context.Background(), cfg, []gatewaycmd.Service{service})
+	if err != nil {
+		t.Fatalf("gatewaycmd.NewHandler() failed with %v", err)
+	}
+	r := httptest.NewRequest(http.MethodGet, "/v1/ping", nil)
+	r.Header.Set("Origin", "https://example.com")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if got, want := w.Header().Get("Access-Control-Allow-Origin"), "*"; got != want {
+		t.Errorf("Access-Control-Allow-Origin = %q; want %q", got, want)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Access-Control-Allow-Credentials = %q; want none", got)
+	}
+
+	cfg.CORS.AllowCredentials = true
+	if _, err := gatewaycmd.NewHandler(context.Background(), cfg, []gatewaycmd.Service{service}); err == nil {
+		t.Errorf("gatewaycmd.NewHandler() succeeded with any origin and credentials; want an error")
+	}
+}
+
+func TestNewHandlerNoEndpoint(t *testing.T) {
+	service := gatewaycmd.Service{
+		Name: "library.v1.LibraryService",
+		Register: func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption, registerOpts ...runtime.RegisterOption) error {
+			return nil
+		},
+	}
+	if _, err := gatewaycmd.NewHandler(context.Background(), gatewaycmd.Config{}, []gatewaycmd.Service{service}); err == nil {
+		t.Errorf("gatewaycmd.NewHandler() succeeded without endpoint; want an error")
+	}
+}
+
+func TestRunShutdown(t *testing.T) {
+	cfg := gatewaycmd.Config{Address: "127.0.0.1:0", Endpoint: serveHealth(t)}
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		errc <- gatewaycmd.Run(ctx, cfg, nil)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Errorf("gatewaycmd.Run() failed with %v; want a graceful shutdown", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("gatewaycmd.Run() did not return after the context was done")
+	}
+}
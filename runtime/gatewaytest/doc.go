@@ -0,0 +1,39 @@
+/*
+Package gatewaytest stands in for the gRPC servers of a gateway in tests.
+
+A Conn is a grpc.ClientConnInterface answering calls with fixture messages,
+so the generated Register*HandlerClient functions register every binding of
+a service on a runtime.ServeMux without running the real backend:
+
+	conn := gatewaytest.NewConn(gatewaytest.WithExamples())
+	if err := conn.LoadFixturesFile("testdata/fixtures.yaml"); err != nil {
+		return err
+	}
+	mux := runtime.NewServeMux()
+	err := pb.RegisterLibraryServiceHandlerClient(ctx, mux, pb.NewLibraryServiceClient(conn))
+	srv := httptest.NewServer(mux)
+
+The fixtures are keyed by the full name of the methods, their dotted name
+or their name alone, and give the JSON encoded responses, or the error, of
+each method:
+
+	library.v1.LibraryService.GetBook:
+	  response: {name: shelves/1/books/1, title: The Go Programming Language}
+	  header: {x-request-id: "42"}
+	ListBooksStream:
+	  responses:
+	    - {name: shelves/1/books/1}
+	    - {name: shelves/1/books/2}
+	DeleteBook:
+	  error: {code: NOT_FOUND, message: book not found}
+
+The requests are validated with runtime.DefaultRequestValidator, and the
+calls are recorded for assertions:
+
+	for _, call := range conn.Calls() {
+		if call.Method == "/library.v1.LibraryService/CreateBook" {
+			// ...
+		}
+	}
+*/
+package gatewaytest
@@ -0,0 +1,436 @@
+package gatewaytest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	openapiv2 "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	openapiv3 "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv3/options"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Fixture is the answer of a Conn to the calls of a method.
+type Fixture struct {
+	// Response is the JSON encoded response of unary methods.
+	Response json.RawMessage `json:"response,omitempty"`
+	// Responses are the JSON encoded responses streaming methods send, in
+	// order.
+	Responses []json.RawMessage `json:"responses,omitempty"`
+	// Error is the status the calls fail with, instead of responding.
+	Error *FixtureError `json:"error,omitempty"`
+	// Header and Trailer are the header and trailer metadata of the
+	// responses.
+	Header  map[string]string `json:"header,omitempty"`
+	Trailer map[string]string `json:"trailer,omitempty"`
+}
+
+// FixtureError is the status of the calls of a failing Fixture. The code is
+// either a number or a name, such as "NOT_FOUND".
+type FixtureError struct {
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message,omitempty"`
+}
+
+// Call is a call received by a Conn.
+type Call struct {
+	// Method is the full name of the method, such as
+	// "/library.v1.LibraryService/GetBook".
+	Method string
+	// Requests are the request messages: one for unary and server streaming
+	// methods, and all the messages sent by the client for client streaming
+	// and bidirectional methods.
+	Requests []proto.Message
+	// Header is the outgoing metadata of the call, such as the headers the
+	// gateway forwarded.
+	Header metadata.MD
+	// Err is the error the call failed with, such as the violations of an
+	// invalid request, or nil.
+	Err error
+}
+
+// answer is the answer of a Conn to the calls of a method, from a Fixture or
+// set with SetResponse or SetError.
+type answer struct {
+	messages []proto.Message
+	raw      []json.RawMessage
+	err      error
+	header   metadata.MD
+	trailer  metadata.MD
+}
+
+func (a *answer) len() int {
+	if a.messages != nil {
+		return len(a.messages)
+	}
+	return len(a.raw)
+}
+
+// response fills "reply" with the i-th response of a.
+func (a *answer) response(i int, reply proto.Message) error {
+	proto.Reset(reply)
+	if a.messages != nil {
+		msg := a.messages[i]
+		if msg.ProtoReflect().Descriptor().FullName() != reply.ProtoReflect().Descriptor().FullName() {
+			return status.Errorf(codes.Internal, "gatewaytest: the response is a %s, want a %s", msg.ProtoReflect().Descriptor().FullName(), reply.ProtoReflect().Descriptor().FullName())
+		}
+		proto.Merge(reply, msg)
+		return nil
+	}
+	if err := protojson.Unmarshal(a.raw[i], reply); err != nil {
+		return status.Errorf(codes.Internal, "gatewaytest: invalid %s fixture: %v", reply.ProtoReflect().Descriptor().FullName(), err)
+	}
+	return nil
+}
+
+// Conn is a grpc.ClientConnInterface answering calls with fixture messages,
+// and recording them. It is safe for concurrent use.
+type Conn struct {
+	mu        sync.Mutex
+	answers   map[string]*answer
+	calls     []*Call
+	examples  bool
+	validator runtime.RequestValidator
+}
+
+// Option is an option of NewConn.
+type Option func(*Conn)
+
+// WithExamples returns an Option which answers the calls of methods without
+// fixture with the example of their response message, as set by its
+// openapiv2_schema or openapiv3_schema option.
+func WithExamples() Option {
+	return func(c *Conn) {
+		c.examples = true
+	}
+}
+
+// WithRequestValidator returns an Option which validates the requests with
+// "validator" instead of runtime.DefaultRequestValidator, or doesn't
+// validate them if it is nil.
+func WithRequestValidator(validator runtime.RequestValidator) Option {
+	return func(c *Conn) {
+		c.validator = validator
+	}
+}
+
+// NewConn returns a new Conn without fixtures.
+func NewConn(opts ...Option) *Conn {
+	c := &Conn{
+		answers:   make(map[string]*answer),
+		validator: runtime.DefaultRequestValidator,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// SetResponse makes the calls of "method" respond with "responses": the
+// first one for unary methods, and all of them in order for streaming
+// methods. The method is either its full name, its dotted name or its name
+// alone.
+func (c *Conn) SetResponse(method string, responses ...proto.Message) {
+	c.set(method, &answer{messages: responses})
+}
+
+// SetError makes the calls of "method" fail with "err".
+func (c *Conn) SetError(method string, err error) {
+	c.set(method, &answer{err: err})
+}
+
+// SetFixture makes the calls of "method" answer with "fixture".
+func (c *Conn) SetFixture(method string, fixture *Fixture) {
+	a := &answer{
+		header:  metadata.New(fixture.Header),
+		trailer: metadata.New(fixture.Trailer),
+	}
+	switch {
+	case fixture.Error != nil:
+		a.err = status.Error(fixture.Error.Code, fixture.Error.Message)
+	case fixture.Response != nil:
+		a.raw = []json.RawMessage{fixture.Response}
+	default:
+		a.raw = fixture.Responses
+	}
+	c.set(method, a)
+}
+
+func (c *Conn) set(method string, a *answer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.answers[strings.TrimPrefix(method, "/")] = a
+}
+
+// LoadFixtures sets the fixtures of the JSON or YAML document "data",
+// mapping the methods to their Fixture.
+func (c *Conn) LoadFixtures(data []byte) error {
+	// Convert YAML to JSON, so that the responses are read as protobuf JSON.
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse fixtures: %w", err)
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to parse fixtures: %w", err)
+	}
+	var fixtures map[string]*Fixture
+	if err := json.Unmarshal(b, &fixtures); err != nil {
+		return fmt.Errorf("failed to parse fixtures: %w", err)
+	}
+	for method, fixture := range fixtures {
+		if fixture == nil {
+			return fmt.Errorf("no fixture for method %q", method)
+		}
+		c.SetFixture(method, fixture)
+	}
+	return nil
+}
+
+// LoadFixturesFile sets the fixtures of the JSON or YAML file "path".
+func (c *Conn) LoadFixturesFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read fixtures from %q: %w", path, err)
+	}
+	if err := c.LoadFixtures(data); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// Calls returns the calls received so far, in order.
+func (c *Conn) Calls() []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	calls := make([]Call, 0, len(c.calls))
+	for _, call := range c.calls {
+		calls = append(calls, Call{
+			Method:   call.Method,
+			Requests: append([]proto.Message(nil), call.Requests...),
+			Header:   call.Header,
+			Err:      call.Err,
+		})
+	}
+	return calls
+}
+
+// ResetCalls forgets the calls received so far.
+func (c *Conn) ResetCalls() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = nil
+}
+
+// lookup returns the answer of the calls of the full method name "method",
+// matching the fixtures by full name, then dotted name, then name.
+func (c *Conn) lookup(method string, reply proto.Message) *answer {
+	name := strings.TrimPrefix(method, "/")
+	dotted := strings.Replace(name, "/", ".", 1)
+	short := name[strings.LastIndex(name, "/")+1:]
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range []string{name, dotted, short} {
+		if a, ok := c.answers[key]; ok {
+			return a
+		}
+	}
+	if c.examples && reply != nil {
+		if example := responseExample(reply); example != "" {
+			return &answer{raw: []json.RawMessage{json.RawMessage(example)}}
+		}
+	}
+	return nil
+}
+
+// responseExample returns the example of the message type of "reply", set
+// by its openapiv2_schema or openapiv3_schema option.
+func responseExample(reply proto.Message) string {
+	opts := reply.ProtoReflect().Descriptor().Options()
+	if opts == nil {
+		return ""
+	}
+	if proto.HasExtension(opts, openapiv2.E_Openapiv2Schema) {
+		schema, _ := proto.GetExtension(opts, openapiv2.E_Openapiv2Schema).(*openapiv2.Schema)
+		if example := schema.GetExample(); example != "" {
+			return example
+		}
+	}
+	if proto.HasExtension(opts, openapiv3.E_Openapiv3Schema) {
+		schema, _ := proto.GetExtension(opts, openapiv3.E_Openapiv3Schema).(*openapiv3.Schema)
+		return schema.GetExample()
+	}
+	return ""
+}
+
+// record records a new call of "method", and returns it.
+func (c *Conn) record(ctx context.Context, method string) *Call {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	call := &Call{Method: method, Header: md.Copy()}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, call)
+	return call
+}
+
+// receive records and validates the request "args" of "call".
+func (c *Conn) receive(ctx context.Context, call *Call, args any) error {
+	req, ok := args.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "gatewaytest: the request of %s is a %T, not a protobuf message", call.Method, args)
+	}
+	var err error
+	if c.validator != nil {
+		err = c.validator(ctx, req)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	call.Requests = append(call.Requests, proto.Clone(req))
+	if call.Err == nil {
+		call.Err = err
+	}
+	return err
+}
+
+func (c *Conn) fail(call *Call, err error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	call.Err = err
+	return err
+}
+
+func unimplemented(method string) error {
+	return status.Errorf(codes.Unimplemented, "gatewaytest: no fixture for %s", method)
+}
+
+// Invoke answers the unary call of "method" with its fixture.
+func (c *Conn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	call := c.record(ctx, method)
+	if err := c.receive(ctx, call, args); err != nil {
+		return err
+	}
+	msg, ok := reply.(proto.Message)
+	if !ok {
+		return c.fail(call, status.Errorf(codes.Internal, "gatewaytest: the response of %s is a %T, not a protobuf message", method, reply))
+	}
+	a := c.lookup(method, msg)
+	if a == nil {
+		return c.fail(call, unimplemented(method))
+	}
+	for _, opt := range opts {
+		switch opt := opt.(type) {
+		case grpc.HeaderCallOption:
+			*opt.HeaderAddr = a.header.Copy()
+		case grpc.TrailerCallOption:
+			*opt.TrailerAddr = a.trailer.Copy()
+		}
+	}
+	if a.err != nil {
+		return c.fail(call, a.err)
+	}
+	if a.len() == 0 {
+		return c.fail(call, status.Errorf(codes.Internal, "gatewaytest: no response for %s", method))
+	}
+	if err := a.response(0, msg); err != nil {
+		return c.fail(call, err)
+	}
+	return nil
+}
+
+// NewStream returns a stream sending the responses of the fixture of
+// "method" in order.
+func (c *Conn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return &clientStream{
+		conn: c,
+		ctx:  ctx,
+		call: c.record(ctx, method),
+	}, nil
+}
+
+var _ grpc.ClientConnInterface = (*Conn)(nil)
+
+// clientStream is a grpc.ClientStream of a Conn.
+type clientStream struct {
+	conn *Conn
+	ctx  context.Context
+	call *Call
+	// err is the error of an invalid request, returned by RecvMsg.
+	err    error
+	answer *answer
+	next   int
+}
+
+func (s *clientStream) Header() (metadata.MD, error) {
+	return s.lookup().header.Copy(), nil
+}
+
+func (s *clientStream) Trailer() metadata.MD {
+	return s.lookup().trailer.Copy()
+}
+
+func (s *clientStream) CloseSend() error {
+	return nil
+}
+
+func (s *clientStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *clientStream) SendMsg(m any) error {
+	if err := s.conn.receive(s.ctx, s.call, m); err != nil && s.err == nil {
+		s.err = err
+	}
+	return nil
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	if s.err != nil {
+		return s.err
+	}
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return s.conn.fail(s.call, status.Errorf(codes.Internal, "gatewaytest: the response of %s is a %T, not a protobuf message", s.call.Method, m))
+	}
+	a := s.lookupFor(msg)
+	switch {
+	case a == nil:
+		return s.conn.fail(s.call, unimplemented(s.call.Method))
+	case a.err != nil:
+		return s.conn.fail(s.call, a.err)
+	case s.next >= a.len():
+		return io.EOF
+	}
+	s.next++
+	if err := a.response(s.next-1, msg); err != nil {
+		return s.conn.fail(s.call, err)
+	}
+	return nil
+}
+
+// lookup returns the answer of the stream, or an empty one if it has no
+// fixture.
+func (s *clientStream) lookup() *answer {
+	if a := s.lookupFor(nil); a != nil {
+		return a
+	}
+	return &answer{}
+}
+
+func (s *clientStream) lookupFor(reply proto.Message) *answer {
+	if s.answer == nil {
+		s.answer = s.conn.lookup(s.call.Method, reply)
+	}
+	return s.answer
+}
@@ -0,0 +1,223 @@
+package gatewaytest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	openapiv2 "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/gatewaytest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const fixtures = `
+grpc.health.v1.Health.Check:
+  response: {status: SERVING}
+  header: {x-request-id: "42"}
+/grpc.health.v1.Health/Watch:
+  responses:
+    - {status: NOT_SERVING}
+    - {status: SERVING}
+List:
+  error: {code: PERMISSION_DENIED, message: admins only}
+`
+
+func TestConnInvoke(t *testing.T) {
+	conn := gatewaytest.NewConn()
+	if err := conn.LoadFixtures([]byte(fixtures)); err != nil {
+		t.Fatalf("conn.LoadFixtures() failed with %v", err)
+	}
+	client := grpc_health_v1.NewHealthClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer token")
+
+	var header metadata.MD
+	resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "library"}, grpc.Header(&header))
+	if err != nil {
+		t.Fatalf("client.Check() failed with %v", err)
+	}
+	if want := (&grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}); !proto.Equal(resp, want) {
+		t.Errorf("client.Check() = %v; want %v", resp, want)
+	}
+	if got, want := header.Get("x-request-id"), []string{"42"}; !cmp.Equal(got, want) {
+		t.Errorf("header x-request-id = %q; want %q", got, want)
+	}
+
+	_, err = client.List(ctx, &grpc_health_v1.HealthListRequest{})
+	if st := status.Convert(err); st.Code() != codes.PermissionDenied || st.Message() != "admins only" {
+		t.Errorf("client.List() failed with %v; want a PermissionDenied error", err)
+	}
+
+	calls := conn.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("conn.Calls() = %v; want 2 calls", calls)
+	}
+	if got, want := calls[0].Method, grpc_health_v1.Health_Check_FullMethodName; got != want {
+		t.Errorf("calls[0].Method = %q; want %q", got, want)
+	}
+	want := []proto.Message{&grpc_health_v1.HealthCheckRequest{Service: "library"}}
+	if diff := cmp.Diff(want, calls[0].Requests, protocmp.Transform()); diff != "" {
+		t.Errorf("calls[0].Requests differ (-want +got):\n%s", diff)
+	}
+	if got, want := calls[0].Header.Get("authorization"), []string{"Bearer token"}; !cmp.Equal(got, want) {
+		t.Errorf("calls[0].Header authorization = %q; want %q", got, want)
+	}
+	if status.Code(calls[1].Err) != codes.PermissionDenied {
+		t.Errorf("calls[1].Err = %v; want a PermissionDenied error", calls[1].Err)
+	}
+
+	conn.ResetCalls()
+	if calls := conn.Calls(); len(calls) != 0 {
+		t.Errorf("conn.Calls() = %v after conn.ResetCalls(); want none", calls)
+	}
+}
+
+func TestConnStream(t *testing.T) {
+	conn := gatewaytest.NewConn()
+	if err := conn.LoadFixtures([]byte(fixtures)); err != nil {
+		t.Fatalf("conn.LoadFixtures() failed with %v", err)
+	}
+	stream, err := grpc_health_v1.NewHealthClient(conn).Watch(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("client.Watch() failed with %v", err)
+	}
+	var got []grpc_health_v1.HealthCheckResponse_ServingStatus
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("stream.Recv() failed with %v", err)
+		}
+		got = append(got, resp.GetStatus())
+	}
+	want := []grpc_health_v1.HealthCheckResponse_ServingStatus{grpc_health_v1.HealthCheckResponse_NOT_SERVING, grpc_health_v1.HealthCheckResponse_SERVING}
+	if !cmp.Equal(got, want) {
+		t.Errorf("statuses = %v; want %v", got, want)
+	}
+}
+
+func TestConnSetResponse(t *testing.T) {
+	conn := gatewaytest.NewConn()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("client.Check() failed with %v; want an Unimplemented error without fixture", err)
+	}
+
+	conn.SetResponse("Check", &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN})
+	resp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("client.Check() failed with %v", err)
+	}
+	if got, want := resp.GetStatus(), grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN; got != want {
+		t.Errorf("client.Check() = %v; want %v", got, want)
+	}
+
+	conn.SetError("grpc.health.v1.Health.Check", status.Error(codes.Unavailable, "down"))
+	_, err = client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("client.Check() failed with %v; want an Unavailable error", err)
+	}
+}
+
+func TestConnRequestValidator(t *testing.T) {
+	conn := gatewaytest.NewConn(gatewaytest.WithRequestValidator(func(ctx context.Context, req proto.Message) error {
+		if req.(*grpc_health_v1.HealthCheckRequest).GetService() == "" {
+			return status.Error(codes.InvalidArgument, "service is required")
+		}
+		return nil
+	}))
+	conn.SetResponse("Check", &grpc_health_v1.HealthCheckResponse{})
+
+	_, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("client.Check() failed with %v; want an InvalidArgument error", err)
+	}
+	if calls := conn.Calls(); len(calls) != 1 || status.Code(calls[0].Err) != codes.InvalidArgument {
+		t.Errorf("conn.Calls() = %v; want an invalid call", calls)
+	}
+}
+
+func TestConnServeMux(t *testing.T) {
+	conn := gatewaytest.NewConn()
+	if err := conn.LoadFixtures([]byte(fixtures)); err != nil {
+		t.Fatalf("conn.LoadFixtures() failed with %v", err)
+	}
+	mux := runtime.NewServeMux(runtime.WithHealthzEndpoint(grpc_health_v1.NewHealthClient(conn)))
+
+	r := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("GET /healthz status = %d; want %d", w.Code, http.StatusOK)
+	}
+	if body := w.Body.String(); !strings.Contains(body, "SERVING") {
+		t.Errorf("GET /healthz body = %q; want the fixture", body)
+	}
+	if calls := conn.Calls(); len(calls) != 1 || calls[0].Method != grpc_health_v1.Health_Check_FullMethodName {
+		t.Errorf("conn.Calls() = %v; want a call of %s", calls, grpc_health_v1.Health_Check_FullMethodName)
+	}
+}
+
+func TestConnExamples(t *testing.T) {
+	opts := &descriptorpb.MessageOptions{}
+	proto.SetExtension(opts, openapiv2.E_Openapiv2Schema, &openapiv2.Schema{Example: `{"title": "The Go Programming Language"}`})
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("gatewaytest/book.proto"),
+		Package: proto.String("gatewaytest"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Book"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("title"),
+				Number:   proto.Int32(1),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+				JsonName: proto.String("title"),
+			}},
+			Options: opts,
+		}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("protodesc.NewFile() failed with %v", err)
+	}
+	book := fd.Messages().Get(0)
+
+	for _, examples := range []bool{true, false} {
+		var opts []gatewaytest.Option
+		if examples {
+			opts = append(opts, gatewaytest.WithExamples())
+		}
+		conn := gatewaytest.NewConn(opts...)
+		reply := dynamicpb.NewMessage(book)
+		err := conn.Invoke(context.Background(), "/gatewaytest.Library/GetBook", &grpc_health_v1.HealthCheckRequest{}, reply)
+		if !examples {
+			if status.Code(err) != codes.Unimplemented {
+				t.Errorf("conn.Invoke() failed with %v; want an Unimplemented error without examples", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("conn.Invoke() failed with %v", err)
+		}
+		if got, want := reply.Get(book.Fields().ByName("title")).String(), "The Go Programming Language"; got != want {
+			t.Errorf("title = %q; want %q", got, want)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net/textproto"
 	"strconv"
 	"strings"
+	"time"
 
 	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc/codes"
@@ -26,7 +27,9 @@ func ForwardResponseStream(ctx context.Context, mux *ServeMux, marshaler Marshal
 		http.Error(w, "unexpected error", http.StatusInternalServerError)
 		return
 	}
-	handleForwardResponseServerMetadata(w, mux, md)
+	// The metadata sent as the headers of a google.api.HttpBody response is
+	// forwarded once the first message tells the type of the response.
+	forwardServerMetadata(w, mux, md, func(key string) bool { return !isHTTPBodyHeaderMetadata(key) })
 
 	w.Header().Set("Transfer-Encoding", "chunked")
 	if err := handleForwardResponseOptions(ctx, w, nil, opts); err != nil {
@@ -34,75 +37,95 @@ func ForwardResponseStream(ctx context.Context, mux *ServeMux, marshaler Marshal
 		return
 	}
 
-	var delimiter []byte
-	if d, ok := marshaler.(Delimited); ok {
-		delimiter = d.Delimiter()
-	} else {
-		delimiter = []byte("\n")
-	}
+	format := newStreamFormat(mux, marshaler)
 
 	var wroteHeader bool
 	for {
 		resp, err := recv()
+		if !wroteHeader {
+			if _, isHTTPBody := resp.(*httpbody.HttpBody); !isHTTPBody {
+				forwardServerMetadata(w, mux, md, isHTTPBodyHeaderMetadata)
+			}
+		}
 		if errors.Is(err, io.EOF) {
+			if format.trailers {
+				setStatusTrailers(w, status.New(codes.OK, ""))
+			}
 			return
 		}
 		if err != nil {
-			handleForwardResponseStreamError(ctx, wroteHeader, marshaler, w, req, mux, err, delimiter)
+			handleForwardResponseStreamError(ctx, wroteHeader, format, w, req, mux, err)
 			return
 		}
 		if err := handleForwardResponseOptions(ctx, w, resp, opts); err != nil {
-			handleForwardResponseStreamError(ctx, wroteHeader, marshaler, w, req, mux, err, delimiter)
+			handleForwardResponseStreamError(ctx, wroteHeader, format, w, req, mux, err)
 			return
 		}
 
 		respRw, err := mux.forwardResponseRewriter(ctx, resp)
 		if err != nil {
 			grpclog.Errorf("Rewrite error: %v", err)
-			handleForwardResponseStreamError(ctx, wroteHeader, marshaler, w, req, mux, err, delimiter)
+			handleForwardResponseStreamError(ctx, wroteHeader, format, w, req, mux, err)
 			return
 		}
 
+		httpBody, isHTTPBody := respRw.(*httpbody.HttpBody)
 		if !wroteHeader {
-			var contentType string
-			if sct, ok := marshaler.(StreamContentType); ok {
-				contentType = sct.StreamContentType(respRw)
-			} else {
-				contentType = marshaler.ContentType(respRw)
+			if isHTTPBody {
+				// HttpBody chunks are written as they are, whatever the framing.
+				format.framing = StreamFramingDelimited
+			}
+			contentType := format.contentType(respRw)
+			if isHTTPBody && contentType == "" {
+				contentType = "application/octet-stream"
 			}
 			w.Header().Set("Content-Type", contentType)
+			if isHTTPBody {
+				code := handleHTTPBodyHeaders(w, md, true)
+				if w.Header().Get("Content-Length") != "" {
+					w.Header().Del("Transfer-Encoding")
+				}
+				if code != http.StatusOK {
+					w.WriteHeader(code)
+				}
+			}
 		}
 
 		var buf []byte
-		httpBody, isHTTPBody := respRw.(*httpbody.HttpBody)
+		start := time.Now()
 		switch {
 		case respRw == nil:
-			buf, err = marshaler.Marshal(errorChunk(status.New(codes.Internal, "empty response")))
+			buf, err = format.marshalError(status.New(codes.Internal, "empty response"))
 		case isHTTPBody:
 			buf = httpBody.GetData()
 		default:
-			result := map[string]interface{}{"result": respRw}
+			var result interface{} = respRw
 			if rb, ok := respRw.(responseBody); ok {
-				result["result"] = rb.XXX_ResponseBody()
+				result = rb.XXX_ResponseBody()
 			}
-
-			buf, err = marshaler.Marshal(result)
+			buf, err = format.marshalResult(result)
+		}
+		if !isHTTPBody {
+			requestTrackerFromContext(ctx).marshaled(start)
 		}
 
 		if err != nil {
 			grpclog.Errorf("Failed to marshal response chunk: %v", err)
-			handleForwardResponseStreamError(ctx, wroteHeader, marshaler, w, req, mux, err, delimiter)
+			handleForwardResponseStreamError(ctx, wroteHeader, format, w, req, mux, err)
 			return
 		}
-		if _, err := w.Write(buf); err != nil {
+		// The chunks of a google.api.HttpBody stream are parts of a single body.
+		if isHTTPBody {
+			_, err = w.Write(buf)
+		} else {
+			err = format.writeRecord(w, buf)
+		}
+		if err != nil {
 			grpclog.Errorf("Failed to send response chunk: %v", err)
 			return
 		}
 		wroteHeader = true
-		if _, err := w.Write(delimiter); err != nil {
-			grpclog.Errorf("Failed to send delimiter chunk: %v", err)
-			return
-		}
+		requestTrackerFromContext(ctx).messageSent()
 		err = rc.Flush()
 		if err != nil {
 			if errors.Is(err, http.ErrNotSupported) {
@@ -116,8 +139,20 @@ func ForwardResponseStream(ctx context.Context, mux *ServeMux, marshaler Marshal
 	}
 }
 
-func handleForwardResponseServerMetadata(w http.ResponseWriter, mux *ServeMux, md ServerMetadata) {
+// handleForwardResponseServerMetadata forwards the header metadata of md. The
+// metadata sent as the headers of a google.api.HttpBody response is skipped
+// if httpBody is true.
+func handleForwardResponseServerMetadata(w http.ResponseWriter, mux *ServeMux, md ServerMetadata, httpBody bool) {
+	forwardServerMetadata(w, mux, md, func(key string) bool { return !httpBody || !isHTTPBodyHeaderMetadata(key) })
+}
+
+// forwardServerMetadata forwards the header metadata of md whose key satisfies
+// filter.
+func forwardServerMetadata(w http.ResponseWriter, mux *ServeMux, md ServerMetadata, filter func(string) bool) {
 	for k, vs := range md.HeaderMD {
+		if !filter(k) {
+			continue
+		}
 		if h, ok := mux.outgoingHeaderMatcher(k); ok {
 			for _, v := range vs {
 				w.Header().Add(h, v)
@@ -153,8 +188,9 @@ type responseBody interface {
 // ForwardResponseMessage forwards the message "resp" from gRPC server to REST client.
 func ForwardResponseMessage(ctx context.Context, mux *ServeMux, marshaler Marshaler, w http.ResponseWriter, req *http.Request, resp proto.Message, opts ...func(context.Context, http.ResponseWriter, proto.Message) error) {
 	md, ok := ServerMetadataFromContext(ctx)
+	_, isHTTPBody := resp.(*httpbody.HttpBody)
 	if ok {
-		handleForwardResponseServerMetadata(w, mux, md)
+		handleForwardResponseServerMetadata(w, mux, md, isHTTPBody)
 	}
 
 	// RFC 7230 https://tools.ietf.org/html/rfc7230#section-4.1.2
@@ -182,24 +218,67 @@ func ForwardResponseMessage(ctx context.Context, mux *ServeMux, marshaler Marsha
 		HTTPError(ctx, mux, marshaler, w, req, err)
 		return
 	}
-	var buf []byte
+	body := respRw
 	if rb, ok := respRw.(responseBody); ok {
-		buf, err = marshaler.Marshal(rb.XXX_ResponseBody())
+		body = rb.XXX_ResponseBody()
+	}
+	_, isHTTPBody = respRw.(*httpbody.HttpBody)
+
+	// Unless its length is needed, the response is written as it is encoded
+	// by the marshalers able to, rather than held whole in memory.
+	if !isHTTPBody && (doForwardTrailers || !mux.writeContentLength) {
+		ew := &encodeWriter{w: w}
+		start := time.Now()
+		if encoded, err := encodeTo(marshaler, ew, body); encoded {
+			requestTrackerFromContext(ctx).marshaled(start)
+			if err != nil {
+				grpclog.Errorf("Marshal error: %v", err)
+				if !ew.wrote {
+					HTTPError(ctx, mux, marshaler, w, req, err)
+				}
+				return
+			}
+			requestTrackerFromContext(ctx).messageSent()
+			if ok && doForwardTrailers {
+				handleForwardResponseTrailer(w, mux, md)
+			}
+			return
+		}
+	}
+
+	bp := getBuffer()
+	defer putBuffer(bp)
+	start := time.Now()
+	buf, appended, err := marshalAppend(marshaler, *bp, body)
+	if appended {
+		*bp = buf
 	} else {
-		buf, err = marshaler.Marshal(respRw)
+		buf, err = marshaler.Marshal(body)
 	}
+	requestTrackerFromContext(ctx).marshaled(start)
 	if err != nil {
 		grpclog.Errorf("Marshal error: %v", err)
 		HTTPError(ctx, mux, marshaler, w, req, err)
 		return
 	}
 
+	code := http.StatusOK
+	if isHTTPBody && ok {
+		code = handleHTTPBodyHeaders(w, md, false)
+	}
+
 	if !doForwardTrailers && mux.writeContentLength {
 		w.Header().Set("Content-Length", strconv.Itoa(len(buf)))
 	}
 
+	if code != http.StatusOK {
+		w.WriteHeader(code)
+	}
+
 	if _, err = w.Write(buf); err != nil && !errors.Is(err, http.ErrBodyNotAllowed) {
 		grpclog.Errorf("Failed to write response: %v", err)
+	} else {
+		requestTrackerFromContext(ctx).messageSent()
 	}
 
 	if ok && doForwardTrailers {
@@ -207,6 +286,51 @@ func ForwardResponseMessage(ctx context.Context, mux *ServeMux, marshaler Marsha
 	}
 }
 
+// marshalAppend appends the encoding of v to b if marshaler, or the marshaler
+// it wraps, is an AppendMarshaler, and reports whether it did.
+func marshalAppend(marshaler Marshaler, b []byte, v interface{}) ([]byte, bool, error) {
+	switch m := marshaler.(type) {
+	case AppendMarshaler:
+		b, err := m.AppendMarshal(b, v)
+		return b, true, err
+	case *HTTPBodyMarshaler:
+		// The data of an HttpBody is written as is.
+		if _, ok := v.(*httpbody.HttpBody); !ok {
+			return marshalAppend(m.Marshaler, b, v)
+		}
+	case *FormMarshaler:
+		return marshalAppend(m.responseMarshaler(), b, v)
+	}
+	return b, false, nil
+}
+
+// encodeTo writes the encoding of v to w as it is produced if marshaler, or
+// the marshaler it wraps, is able to, and reports whether it did.
+func encodeTo(marshaler Marshaler, w io.Writer, v interface{}) (bool, error) {
+	switch m := marshaler.(type) {
+	case *FastJSONPb:
+		return true, m.writeTo(w, v, nil)
+	case *HTTPBodyMarshaler:
+		if _, ok := v.(*httpbody.HttpBody); !ok {
+			return encodeTo(m.Marshaler, w, v)
+		}
+	case *FormMarshaler:
+		return encodeTo(m.responseMarshaler(), w, v)
+	}
+	return false, nil
+}
+
+// encodeWriter records whether a response was written to.
+type encodeWriter struct {
+	w     io.Writer
+	wrote bool
+}
+
+func (w *encodeWriter) Write(p []byte) (int, error) {
+	w.wrote = true
+	return w.w.Write(p)
+}
+
 func requestAcceptsTrailers(req *http.Request) bool {
 	te := req.Header.Get("TE")
 	return strings.Contains(strings.ToLower(te), "trailers")
@@ -224,28 +348,41 @@ func handleForwardResponseOptions(ctx context.Context, w http.ResponseWriter, re
 	return nil
 }
 
-func handleForwardResponseStreamError(ctx context.Context, wroteHeader bool, marshaler Marshaler, w http.ResponseWriter, req *http.Request, mux *ServeMux, err error, delimiter []byte) {
+func handleForwardResponseStreamError(ctx context.Context, wroteHeader bool, format *streamFormat, w http.ResponseWriter, req *http.Request, mux *ServeMux, err error) {
 	st := mux.streamErrorHandler(ctx, err)
-	msg := errorChunk(st)
 	if !wroteHeader {
-		w.Header().Set("Content-Type", marshaler.ContentType(msg))
+		w.Header().Set("Content-Type", format.contentType(st.Proto()))
 		w.WriteHeader(HTTPStatusFromCode(st.Code()))
 	}
-	buf, err := marshaler.Marshal(msg)
+	if format.trailers {
+		setStatusTrailers(w, st)
+		return
+	}
+	start := time.Now()
+	buf, err := format.marshalError(st)
+	requestTrackerFromContext(ctx).marshaled(start)
 	if err != nil {
 		grpclog.Errorf("Failed to marshal an error: %v", err)
 		return
 	}
-	if _, err := w.Write(buf); err != nil {
+	if err := format.writeRecord(w, buf); err != nil {
 		grpclog.Errorf("Failed to notify error to client: %v", err)
 		return
 	}
-	if _, err := w.Write(delimiter); err != nil {
-		grpclog.Errorf("Failed to send delimiter chunk: %v", err)
-		return
-	}
 }
 
 func errorChunk(st *status.Status) map[string]proto.Message {
 	return map[string]proto.Message{"error": st.Proto()}
 }
+
+// asStreamMarshaler returns the StreamMarshaler implemented by marshaler, or
+// by the Marshaler it wraps.
+func asStreamMarshaler(marshaler Marshaler) (StreamMarshaler, bool) {
+	switch m := marshaler.(type) {
+	case StreamMarshaler:
+		return m, true
+	case *HTTPBodyMarshaler:
+		return asStreamMarshaler(m.Marshaler)
+	}
+	return nil, false
+}
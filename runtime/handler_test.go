@@ -2,18 +2,22 @@ package runtime_test
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	pb "github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -300,6 +304,86 @@ func TestForwardResponseStreamCustomMarshaler(t *testing.T) {
 	}
 }
 
+func TestForwardResponseStreamProtoDelimited(t *testing.T) {
+	// Protobuf streams are length-prefixed whether or not the client asks
+	// for the delimited media type.
+	for _, accept := range []string{runtime.MIMEProtobuf, runtime.MIMEProtobufDelimited} {
+		t.Run(accept, func(t *testing.T) {
+			testForwardResponseStreamProtoDelimited(t, accept)
+		})
+	}
+}
+
+func testForwardResponseStreamProtoDelimited(t *testing.T, accept string) {
+	msgs := []proto.Message{&pb.SimpleMessage{Id: "One"}, &pb.SimpleMessage{Id: "Two"}}
+	st := status.New(codes.OutOfRange, "400")
+	var count int
+	recv := func() (proto.Message, error) {
+		if count == len(msgs) {
+			return nil, st.Err()
+		}
+		count++
+		return msgs[count-1], nil
+	}
+	ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+	req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+	req.Header.Set("Accept", accept)
+	resp := httptest.NewRecorder()
+
+	mux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEProtobuf, &runtime.ProtoMarshaller{}))
+	_, marshaler := runtime.MarshalerForRequest(mux, req)
+	runtime.ForwardResponseStream(ctx, mux, marshaler, resp, req, recv)
+
+	w := resp.Result()
+	if got, want := w.Header.Get("Content-Type"), "application/x-protobuf; delimited=true"; got != want {
+		t.Errorf("Content-Type = %q; want %q", got, want)
+	}
+	body, err := io.ReadAll(w.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response body with %v", err)
+	}
+
+	type chunk struct {
+		num  protowire.Number
+		data string
+	}
+	var got []chunk
+	for len(body) > 0 {
+		size, n := protowire.ConsumeVarint(body)
+		if n < 0 || uint64(len(body)-n) < size {
+			t.Fatalf("invalid frame length in %x", body)
+		}
+		frame := body[n : n+int(size)]
+		body = body[n+int(size):]
+		num, typ, n := protowire.ConsumeTag(frame)
+		if n < 0 || typ != protowire.BytesType {
+			t.Fatalf("invalid frame %x", frame)
+		}
+		data, m := protowire.ConsumeBytes(frame[n:])
+		if m < 0 || n+m != len(frame) {
+			t.Fatalf("invalid frame %x", frame)
+		}
+		got = append(got, chunk{num: num, data: string(data)})
+	}
+
+	var want []chunk
+	for _, msg := range msgs {
+		b, err := proto.Marshal(msg)
+		if err != nil {
+			t.Fatalf("proto.Marshal(%v) failed with %v; want success", msg, err)
+		}
+		want = append(want, chunk{num: 1, data: string(b)})
+	}
+	b, err := proto.Marshal(st.Proto())
+	if err != nil {
+		t.Fatalf("proto.Marshal(%v) failed with %v; want success", st.Proto(), err)
+	}
+	want = append(want, chunk{num: 2, data: string(b)})
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ForwardResponseStream() chunks = %q; want %q", got, want)
+	}
+}
+
 func TestForwardResponseMessage(t *testing.T) {
 	msg := &pb.SimpleMessage{Id: "One"}
 	tests := []struct {
@@ -630,3 +714,275 @@ func TestOutgoingTrailerMatcher(t *testing.T) {
 		})
 	}
 }
+
+func TestForwardResponseStreamHTTPBody(t *testing.T) {
+	chunks := []proto.Message{
+		&httpbody.HttpBody{ContentType: "video/mp4", Data: []byte("0123")},
+		&httpbody.HttpBody{Data: []byte("4567")},
+	}
+	md := runtime.ServerMetadata{
+		HeaderMD: metadata.Pairs(
+			runtime.MetadataContentRange, "bytes 0-7/100",
+			runtime.MetadataContentLength, "8",
+			runtime.MetadataContentDisposition, `attachment; filename="video.mp4"`,
+		),
+	}
+	ctx := runtime.NewServerMetadataContext(context.Background(), md)
+	req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+	resp := httptest.NewRecorder()
+	count := 0
+	recv := func() (proto.Message, error) {
+		if count >= len(chunks) {
+			return nil, io.EOF
+		}
+		count++
+		return chunks[count-1], nil
+	}
+	marshaler := &runtime.HTTPBodyMarshaler{Marshaler: &runtime.JSONPb{}}
+	runtime.ForwardResponseStream(ctx, runtime.NewServeMux(), marshaler, resp, req, recv)
+
+	w := resp.Result()
+	if got, want := w.StatusCode, http.StatusPartialContent; got != want {
+		t.Errorf("StatusCode = %d; want %d", got, want)
+	}
+	for header, want := range map[string]string{
+		"Content-Type":                            "video/mp4",
+		"Content-Range":                           "bytes 0-7/100",
+		"Content-Length":                          "8",
+		"Content-Disposition":                     `attachment; filename="video.mp4"`,
+		"Transfer-Encoding":                       "",
+		"Grpc-Metadata-Grpcgateway-Content-Range": "",
+	} {
+		if got := w.Header.Get(header); got != want {
+			t.Errorf("%s = %q; want %q", header, got, want)
+		}
+	}
+	body, err := io.ReadAll(w.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response body with %v", err)
+	}
+	if got, want := string(body), "01234567"; got != want {
+		t.Errorf("body = %q; want %q", got, want)
+	}
+}
+
+func TestForwardResponseStreamHTTPBodyChunked(t *testing.T) {
+	chunks := []proto.Message{
+		&httpbody.HttpBody{ContentType: "text/csv", Data: []byte("a,b")},
+		&httpbody.HttpBody{Data: []byte("0,1")},
+	}
+	md := runtime.ServerMetadata{
+		HeaderMD: metadata.Pairs(runtime.MetadataETag, `"v1"`),
+	}
+	ctx := runtime.NewServerMetadataContext(context.Background(), md)
+	req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+	resp := httptest.NewRecorder()
+	count := 0
+	recv := func() (proto.Message, error) {
+		if count >= len(chunks) {
+			return nil, io.EOF
+		}
+		count++
+		return chunks[count-1], nil
+	}
+	marshaler := &runtime.HTTPBodyMarshaler{Marshaler: &runtime.JSONPb{}}
+	runtime.ForwardResponseStream(ctx, runtime.NewServeMux(), marshaler, resp, req, recv)
+
+	w := resp.Result()
+	for header, want := range map[string]string{
+		"Content-Type":                   "text/csv",
+		"Etag":                           `"v1"`,
+		"Transfer-Encoding":              "chunked",
+		"Grpc-Metadata-Grpcgateway-Etag": "",
+	} {
+		if got := w.Header.Get(header); got != want {
+			t.Errorf("%s = %q; want %q", header, got, want)
+		}
+	}
+	body, err := io.ReadAll(w.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response body with %v", err)
+	}
+	if got, want := string(body), "a,b0,1"; got != want {
+		t.Errorf("body = %q; want %q", got, want)
+	}
+}
+
+func TestForwardResponseHTTPBodyMetadataOfOtherResponses(t *testing.T) {
+	md := runtime.ServerMetadata{
+		HeaderMD: metadata.Pairs(runtime.MetadataETag, `"v1"`),
+	}
+	ctx := runtime.NewServerMetadataContext(context.Background(), md)
+	msg := &pb.SimpleMessage{Id: "foo"}
+	marshaler := &runtime.HTTPBodyMarshaler{Marshaler: &runtime.JSONPb{}}
+
+	t.Run("message", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		runtime.ForwardResponseMessage(ctx, runtime.NewServeMux(), marshaler, resp, httptest.NewRequest("GET", "http://example.com/foo", nil), msg)
+		w := resp.Result()
+		if got, want := w.Header.Get("Grpc-Metadata-Grpcgateway-Etag"), `"v1"`; got != want {
+			t.Errorf("Grpc-Metadata-Grpcgateway-Etag = %q; want %q", got, want)
+		}
+		if got := w.Header.Get("Etag"); got != "" {
+			t.Errorf("Etag = %q; want none", got)
+		}
+	})
+	t.Run("stream", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		sent := false
+		recv := func() (proto.Message, error) {
+			if sent {
+				return nil, io.EOF
+			}
+			sent = true
+			return msg, nil
+		}
+		runtime.ForwardResponseStream(ctx, runtime.NewServeMux(), marshaler, resp, httptest.NewRequest("GET", "http://example.com/foo", nil), recv)
+		w := resp.Result()
+		if got, want := w.Header.Get("Grpc-Metadata-Grpcgateway-Etag"), `"v1"`; got != want {
+			t.Errorf("Grpc-Metadata-Grpcgateway-Etag = %q; want %q", got, want)
+		}
+		if got := w.Header.Get("Etag"); got != "" {
+			t.Errorf("Etag = %q; want none", got)
+		}
+	})
+}
+
+func TestForwardResponseMessageHTTPBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		md         metadata.MD
+		statusCode int
+	}{{
+		name:       "full content",
+		md:         metadata.Pairs(runtime.MetadataAcceptRanges, "bytes"),
+		statusCode: http.StatusOK,
+	}, {
+		name: "partial content",
+		md: metadata.Pairs(
+			runtime.MetadataAcceptRanges, "bytes",
+			runtime.MetadataContentRange, "bytes 0-3/100",
+		),
+		statusCode: http.StatusPartialContent,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{HeaderMD: tt.md})
+			req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+			resp := httptest.NewRecorder()
+			msg := &httpbody.HttpBody{ContentType: "text/plain", Data: []byte("0123")}
+			marshaler := &runtime.HTTPBodyMarshaler{Marshaler: &runtime.JSONPb{}}
+			runtime.ForwardResponseMessage(ctx, runtime.NewServeMux(), marshaler, resp, req, msg)
+
+			w := resp.Result()
+			if got, want := w.StatusCode, tt.statusCode; got != want {
+				t.Errorf("StatusCode = %d; want %d", got, want)
+			}
+			if got, want := w.Header.Get("Accept-Ranges"), "bytes"; got != want {
+				t.Errorf("Accept-Ranges = %q; want %q", got, want)
+			}
+			if got, want := w.Header.Get("Content-Range"), tt.md.Get(runtime.MetadataContentRange); len(want) > 0 && got != want[0] {
+				t.Errorf("Content-Range = %q; want %q", got, want[0])
+			}
+			body, err := io.ReadAll(w.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body with %v", err)
+			}
+			if got, want := string(body), "0123"; got != want {
+				t.Errorf("body = %q; want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestForwardResponseMessageEncodeError(t *testing.T) {
+	ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+	req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+	resp := httptest.NewRecorder()
+	msg := &pb.SimpleMessage{Id: "\xff"}
+	runtime.ForwardResponseMessage(ctx, runtime.NewServeMux(), &runtime.FastJSONPb{}, resp, req, msg)
+
+	if got, want := resp.Code, http.StatusInternalServerError; got != want {
+		t.Errorf("StatusCode = %d; want %d", got, want)
+	}
+	if body := resp.Body.String(); !strings.Contains(body, "invalid UTF-8") {
+		t.Errorf("body = %q; want the encoding error", body)
+	}
+}
+
+// discardResponseWriter is a minimal http.ResponseWriter for benchmarks.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w *discardResponseWriter) Header() http.Header         { return w.header }
+func (w *discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *discardResponseWriter) WriteHeader(int)             {}
+
+func BenchmarkForwardResponseMessage(b *testing.B) {
+	msg := &pb.ABitOfEverything{}
+	for i := 0; i < 1000; i++ {
+		msg.Nested = append(msg.Nested, &pb.ABitOfEverything_Nested{Name: fmt.Sprintf("item-%d", i), Amount: uint32(i)})
+	}
+	ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+	req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+
+	for _, m := range []struct {
+		name      string
+		marshaler runtime.Marshaler
+	}{
+		// CustomMarshaler is not an AppendMarshaler, so responses are
+		// encoded into a newly allocated buffer.
+		{name: "Marshal", marshaler: &CustomMarshaler{&runtime.JSONPb{}}},
+		{name: "JSONPb", marshaler: &runtime.HTTPBodyMarshaler{Marshaler: &runtime.JSONPb{}}},
+		{name: "FastJSONPb", marshaler: &runtime.HTTPBodyMarshaler{Marshaler: &runtime.FastJSONPb{}}},
+		{name: "ProtoMarshaller", marshaler: &runtime.ProtoMarshaller{}},
+	} {
+		for _, writeContentLength := range []bool{false, true} {
+			mux := runtime.NewServeMux()
+			if writeContentLength {
+				mux = runtime.NewServeMux(runtime.WithWriteContentLength())
+			}
+			b.Run(fmt.Sprintf("%s/contentLength=%t", m.name, writeContentLength), func(b *testing.B) {
+				w := &discardResponseWriter{header: make(http.Header)}
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					runtime.ForwardResponseMessage(ctx, mux, m.marshaler, w, req, msg)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkForwardResponseMessageLarge encodes a response of several MB, larger
+// than the pooled buffers.
+func BenchmarkForwardResponseMessageLarge(b *testing.B) {
+	msg := &pb.ABitOfEverything{}
+	for i := 0; i < 100000; i++ {
+		msg.Nested = append(msg.Nested, &pb.ABitOfEverything_Nested{Name: fmt.Sprintf("item-%d", i), Amount: uint32(i)})
+	}
+	ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+	req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+
+	for _, m := range []struct {
+		name      string
+		marshaler runtime.Marshaler
+	}{
+		{name: "JSONPb", marshaler: &runtime.HTTPBodyMarshaler{Marshaler: &runtime.JSONPb{}}},
+		{name: "FastJSONPb", marshaler: &runtime.HTTPBodyMarshaler{Marshaler: &runtime.FastJSONPb{}}},
+	} {
+		for _, writeContentLength := range []bool{false, true} {
+			mux := runtime.NewServeMux()
+			if writeContentLength {
+				mux = runtime.NewServeMux(runtime.WithWriteContentLength())
+			}
+			b.Run(fmt.Sprintf("%s/contentLength=%t", m.name, writeContentLength), func(b *testing.B) {
+				w := &discardResponseWriter{header: make(http.Header)}
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					runtime.ForwardResponseMessage(ctx, mux, m.marshaler, w, req, msg)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package runtime
+
+import (
+	"net/http"
+	"strings"
+)
+
+// The following metadata keys, set by a backend in the response header metadata
+// of a method returning google.api.HttpBody, are sent as the corresponding HTTP
+// response headers. They are not forwarded as Grpc-Metadata- headers.
+//
+// The Range and If-Range headers of the request are forwarded to the backend as
+// the "grpcgateway-range" and "grpcgateway-if-range" metadata keys.
+const (
+	// MetadataContentRange sets the Content-Range of a partial response, which is
+	// sent with the status http.StatusPartialContent.
+	MetadataContentRange = MetadataPrefix + "content-range"
+	// MetadataContentLength sets the Content-Length of a streamed response, which
+	// is otherwise sent with chunked transfer encoding.
+	MetadataContentLength = MetadataPrefix + "content-length"
+	// MetadataContentDisposition sets the Content-Disposition of the response,
+	// for example to suggest a filename for a download:
+	//
+	//	mime.FormatMediaType("attachment", map[string]string{"filename": name})
+	MetadataContentDisposition = MetadataPrefix + "content-disposition"
+	// MetadataAcceptRanges sets the Accept-Ranges of the response.
+	MetadataAcceptRanges = MetadataPrefix + "accept-ranges"
+	// MetadataETag sets the ETag of the response, which If-Range may refer to.
+	MetadataETag = MetadataPrefix + "etag"
+	// MetadataLastModified sets the Last-Modified of the response, which
+	// If-Range may refer to.
+	MetadataLastModified = MetadataPrefix + "last-modified"
+)
+
+// httpBodyHeaders maps the metadata keys above to their HTTP headers.
+var httpBodyHeaders = map[string]string{
+	MetadataContentRange:       "Content-Range",
+	MetadataContentLength:      "Content-Length",
+	MetadataContentDisposition: "Content-Disposition",
+	MetadataAcceptRanges:       "Accept-Ranges",
+	MetadataETag:               "ETag",
+	MetadataLastModified:       "Last-Modified",
+}
+
+func isHTTPBodyHeaderMetadata(key string) bool {
+	_, ok := httpBodyHeaders[strings.ToLower(key)]
+	return ok
+}
+
+// handleHTTPBodyHeaders sets the headers of a google.api.HttpBody response from
+// the metadata set by the backend, and returns the status code of the response.
+// Content-Length is only set if withLength is true.
+func handleHTTPBodyHeaders(w http.ResponseWriter, md ServerMetadata, withLength bool) int {
+	code := http.StatusOK
+	for key, header := range httpBodyHeaders {
+		vs := md.HeaderMD.Get(key)
+		if len(vs) == 0 || (key == MetadataContentLength && !withLength) {
+			continue
+		}
+		w.Header().Set(header, vs[0])
+		if key == MetadataContentRange {
+			code = http.StatusPartialContent
+		}
+	}
+	return code
+}
@@ -0,0 +1,520 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// InProcessChannel is a gRPC channel calling the services registered on it in
+// the same process, without a network connection or serialization. It is both
+// a grpc.ServiceRegistrar, on which the generated Register*Server functions
+// register service implementations, and a grpc.ClientConnInterface, from which
+// the generated New*Client functions create clients.
+//
+// Unlike the generated Register*HandlerServer functions, a gateway registered
+// with Register*HandlerClient on a client of an InProcessChannel supports
+// streaming methods, and runs the server interceptors:
+//
+//	ch := runtime.NewInProcessChannel(
+//		runtime.WithInProcessUnaryInterceptors(authUnaryInterceptor),
+//		runtime.WithInProcessStreamInterceptors(authStreamInterceptor),
+//	)
+//	pb.RegisterEchoServiceServer(ch, &echoServer{})
+//	err := pb.RegisterEchoServiceHandlerClient(ctx, mux, pb.NewEchoServiceClient(ch))
+//
+// Calls behave as over a network: the outgoing metadata of the client is the
+// incoming metadata of the server, header and trailer metadata set by the
+// server are returned to the client, and errors which are not a status are
+// converted to codes.Unknown. Messages are copied between the client and the
+// server, so they must be protobuf messages.
+type InProcessChannel struct {
+	mu                 sync.RWMutex
+	services           map[string]*inProcessService
+	unaryInterceptors  []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+}
+
+type inProcessService struct {
+	impl    any
+	methods map[string]*grpc.MethodDesc
+	streams map[string]*grpc.StreamDesc
+}
+
+// InProcessOption is an option of NewInProcessChannel.
+type InProcessOption func(*InProcessChannel)
+
+// WithInProcessUnaryInterceptors returns an InProcessOption which runs the
+// interceptors, in order, around the calls of unary methods.
+func WithInProcessUnaryInterceptors(interceptors ...grpc.UnaryServerInterceptor) InProcessOption {
+	return func(ch *InProcessChannel) {
+		ch.unaryInterceptors = append(ch.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithInProcessStreamInterceptors returns an InProcessOption which runs the
+// interceptors, in order, around the calls of streaming methods.
+func WithInProcessStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) InProcessOption {
+	return func(ch *InProcessChannel) {
+		ch.streamInterceptors = append(ch.streamInterceptors, interceptors...)
+	}
+}
+
+// NewInProcessChannel returns a new InProcessChannel with no services.
+func NewInProcessChannel(opts ...InProcessOption) *InProcessChannel {
+	ch := &InProcessChannel{services: make(map[string]*inProcessService)}
+	for _, opt := range opts {
+		opt(ch)
+	}
+	return ch
+}
+
+var (
+	_ grpc.ServiceRegistrar      = (*InProcessChannel)(nil)
+	_ grpc.ClientConnInterface   = (*InProcessChannel)(nil)
+	_ grpc.ServerTransportStream = (*inProcessTransportStream)(nil)
+)
+
+// RegisterService registers a service and its implementation. It panics if
+// impl does not implement the service, or if the service is already
+// registered, as grpc.Server does.
+func (ch *InProcessChannel) RegisterService(desc *grpc.ServiceDesc, impl any) {
+	if impl != nil {
+		ht := reflect.TypeOf(desc.HandlerType).Elem()
+		if st := reflect.TypeOf(impl); !st.Implements(ht) {
+			panic(fmt.Sprintf("runtime: InProcessChannel.RegisterService found the handler of type %v that does not satisfy %v", st, ht))
+		}
+	}
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	if _, ok := ch.services[desc.ServiceName]; ok {
+		panic(fmt.Sprintf("runtime: InProcessChannel.RegisterService found duplicate service registration for %q", desc.ServiceName))
+	}
+	svc := &inProcessService{
+		impl:    impl,
+		methods: make(map[string]*grpc.MethodDesc, len(desc.Methods)),
+		streams: make(map[string]*grpc.StreamDesc, len(desc.Streams)),
+	}
+	for i := range desc.Methods {
+		svc.methods[desc.Methods[i].MethodName] = &desc.Methods[i]
+	}
+	for i := range desc.Streams {
+		svc.streams[desc.Streams[i].StreamName] = &desc.Streams[i]
+	}
+	ch.services[desc.ServiceName] = svc
+}
+
+// lookup returns the service serving method, of the form
+// "/package.Service/Method", and the name of the method.
+func (ch *InProcessChannel) lookup(method string) (*inProcessService, string, error) {
+	service, name, ok := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	if !ok {
+		return nil, "", status.Errorf(codes.Unimplemented, "malformed method name: %q", method)
+	}
+	ch.mu.RLock()
+	svc, ok := ch.services[service]
+	ch.mu.RUnlock()
+	if !ok {
+		return nil, "", status.Errorf(codes.Unimplemented, "unknown service %v", service)
+	}
+	return svc, name, nil
+}
+
+// Invoke calls a unary method.
+func (ch *InProcessChannel) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	svc, name, err := ch.lookup(method)
+	if err != nil {
+		return err
+	}
+	md, ok := svc.methods[name]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "unknown method %v", method)
+	}
+
+	ts := &inProcessTransportStream{method: method}
+	serverCtx := inProcessServerContext(ctx, ts)
+	dec := func(v any) error {
+		return copyMessage(v, args)
+	}
+	resp, err := md.Handler(svc.impl, serverCtx, dec, chainUnaryInterceptors(ch.unaryInterceptors))
+	err = toStatusError(err)
+	if err == nil {
+		err = copyMessage(reply, resp)
+	}
+	header, trailer := ts.metadata()
+	applyCallOptions(opts, header, trailer)
+	return err
+}
+
+// NewStream starts a call of a streaming method. The method runs in its own
+// goroutine until it returns, or the context is done.
+func (ch *InProcessChannel) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	svc, name, err := ch.lookup(method)
+	if err != nil {
+		return nil, err
+	}
+	sd, ok := svc.streams[name]
+	if !ok {
+		// Unary methods may be called through a stream too.
+		md, ok := svc.methods[name]
+		if !ok {
+			return nil, status.Errorf(codes.Unimplemented, "unknown method %v", method)
+		}
+		sd = &grpc.StreamDesc{
+			StreamName: name,
+			Handler: func(srv any, stream grpc.ServerStream) error {
+				resp, err := md.Handler(srv, stream.Context(), stream.RecvMsg, chainUnaryInterceptors(ch.unaryInterceptors))
+				if err != nil {
+					return err
+				}
+				return stream.SendMsg(resp)
+			},
+		}
+	}
+
+	s := &inProcessStream{
+		clientCtx:  ctx,
+		requests:   make(chan any),
+		reqClosed:  make(chan struct{}),
+		responses:  make(chan any),
+		done:       make(chan struct{}),
+		headerSent: make(chan struct{}),
+		opts:       opts,
+	}
+	s.ts = &inProcessTransportStream{method: method, onSendHeader: func() { close(s.headerSent) }}
+	s.serverCtx, s.cancel = context.WithCancel(inProcessServerContext(ctx, s.ts))
+
+	ss := (*inProcessServerStream)(s)
+	if _, isStream := svc.streams[name]; !isStream || len(ch.streamInterceptors) == 0 {
+		// Unary methods called through a stream run the unary interceptors
+		// only.
+		go s.serve(func() error { return sd.Handler(svc.impl, ss) })
+		return s, nil
+	}
+	info := &grpc.StreamServerInfo{
+		FullMethod:     method,
+		IsClientStream: sd.ClientStreams,
+		IsServerStream: sd.ServerStreams,
+	}
+	interceptor := chainStreamInterceptors(ch.streamInterceptors)
+	go s.serve(func() error { return interceptor(svc.impl, ss, info, sd.Handler) })
+	return s, nil
+}
+
+// inProcessAddr is the address of the peers of an InProcessChannel.
+type inProcessAddr struct{}
+
+func (inProcessAddr) Network() string { return "inprocess" }
+func (inProcessAddr) String() string  { return "inprocess" }
+
+// inProcessServerContext returns the context of a method called with ctx,
+// which carries the outgoing metadata of ctx as incoming metadata.
+func inProcessServerContext(ctx context.Context, ts *inProcessTransportStream) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	ctx = metadata.NewIncomingContext(ctx, md.Copy())
+	ctx = metadata.NewOutgoingContext(ctx, nil)
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: inProcessAddr{}, LocalAddr: inProcessAddr{}})
+	return grpc.NewContextWithServerTransportStream(ctx, ts)
+}
+
+// inProcessTransportStream collects the metadata set by a method with
+// grpc.SetHeader, grpc.SendHeader and grpc.SetTrailer.
+type inProcessTransportStream struct {
+	method       string
+	onSendHeader func()
+
+	mu         sync.Mutex
+	header     metadata.MD
+	headerSent bool
+	trailer    metadata.MD
+}
+
+func (ts *inProcessTransportStream) Method() string {
+	return ts.method
+}
+
+func (ts *inProcessTransportStream) SetHeader(md metadata.MD) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.headerSent {
+		return status.Error(codes.Internal, "transport: the stream is done or WriteHeader was already called")
+	}
+	ts.header = metadata.Join(ts.header, md)
+	return nil
+}
+
+func (ts *inProcessTransportStream) SendHeader(md metadata.MD) error {
+	if err := ts.SetHeader(md); err != nil {
+		return err
+	}
+	ts.sendHeader()
+	return nil
+}
+
+// sendHeader marks the header as sent, if it is not already.
+func (ts *inProcessTransportStream) sendHeader() {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.headerSent {
+		return
+	}
+	ts.headerSent = true
+	if ts.onSendHeader != nil {
+		ts.onSendHeader()
+	}
+}
+
+func (ts *inProcessTransportStream) SetTrailer(md metadata.MD) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.trailer = metadata.Join(ts.trailer, md)
+	return nil
+}
+
+func (ts *inProcessTransportStream) metadata() (header, trailer metadata.MD) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.header.Copy(), ts.trailer.Copy()
+}
+
+// inProcessStream is the client side of a call of a streaming method, and
+// inProcessServerStream its server side. Messages are passed through
+// unbuffered channels, so that each side is blocked until the other receives.
+type inProcessStream struct {
+	clientCtx context.Context
+	serverCtx context.Context
+	cancel    context.CancelFunc
+	ts        *inProcessTransportStream
+	opts      []grpc.CallOption
+
+	requests  chan any
+	reqClosed chan struct{}
+	closeOnce sync.Once
+	responses chan any
+
+	headerSent chan struct{}
+	// done is closed once the method returns, with its error.
+	done chan struct{}
+	err  error
+}
+
+func (s *inProcessStream) serve(handler func() error) {
+	err := toStatusError(handler())
+	s.cancel()
+	s.ts.sendHeader()
+	header, trailer := s.ts.metadata()
+	applyCallOptions(s.opts, header, trailer)
+	s.err = err
+	close(s.done)
+}
+
+func (s *inProcessStream) Header() (metadata.MD, error) {
+	select {
+	case <-s.headerSent:
+	case <-s.done:
+	case <-s.clientCtx.Done():
+		return nil, status.FromContextError(s.clientCtx.Err()).Err()
+	}
+	header, _ := s.ts.metadata()
+	return header, nil
+}
+
+func (s *inProcessStream) Trailer() metadata.MD {
+	select {
+	case <-s.done:
+		_, trailer := s.ts.metadata()
+		return trailer
+	default:
+		return nil
+	}
+}
+
+func (s *inProcessStream) CloseSend() error {
+	s.closeOnce.Do(func() { close(s.reqClosed) })
+	return nil
+}
+
+func (s *inProcessStream) Context() context.Context {
+	return s.clientCtx
+}
+
+// SendMsg sends a request. It returns io.EOF if the method has returned, with
+// the error returned by RecvMsg.
+func (s *inProcessStream) SendMsg(m any) error {
+	msg, err := cloneMessage(m)
+	if err != nil {
+		return err
+	}
+	select {
+	case s.requests <- msg:
+		return nil
+	case <-s.done:
+		return io.EOF
+	case <-s.clientCtx.Done():
+		return status.FromContextError(s.clientCtx.Err()).Err()
+	}
+}
+
+// RecvMsg receives a response. It returns io.EOF once the method has returned
+// without error.
+func (s *inProcessStream) RecvMsg(m any) error {
+	select {
+	case resp := <-s.responses:
+		return copyMessage(m, resp)
+	case <-s.done:
+		if s.err != nil {
+			return s.err
+		}
+		return io.EOF
+	case <-s.clientCtx.Done():
+		return status.FromContextError(s.clientCtx.Err()).Err()
+	}
+}
+
+type inProcessServerStream inProcessStream
+
+func (s *inProcessServerStream) SetHeader(md metadata.MD) error {
+	return s.ts.SetHeader(md)
+}
+
+func (s *inProcessServerStream) SendHeader(md metadata.MD) error {
+	return s.ts.SendHeader(md)
+}
+
+func (s *inProcessServerStream) SetTrailer(md metadata.MD) {
+	_ = s.ts.SetTrailer(md)
+}
+
+func (s *inProcessServerStream) Context() context.Context {
+	return s.serverCtx
+}
+
+func (s *inProcessServerStream) SendMsg(m any) error {
+	msg, err := cloneMessage(m)
+	if err != nil {
+		return err
+	}
+	s.ts.sendHeader()
+	select {
+	case s.responses <- msg:
+		return nil
+	case <-s.serverCtx.Done():
+		return status.FromContextError(s.serverCtx.Err()).Err()
+	}
+}
+
+// RecvMsg receives a request. It returns io.EOF once the client has called
+// CloseSend.
+func (s *inProcessServerStream) RecvMsg(m any) error {
+	select {
+	case req := <-s.requests:
+		return copyMessage(m, req)
+	case <-s.reqClosed:
+		return io.EOF
+	case <-s.serverCtx.Done():
+		return status.FromContextError(s.serverCtx.Err()).Err()
+	}
+}
+
+// applyCallOptions sets the header and trailer metadata requested by the
+// grpc.Header and grpc.Trailer call options.
+func applyCallOptions(opts []grpc.CallOption, header, trailer metadata.MD) {
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case grpc.HeaderCallOption:
+			*o.HeaderAddr = header
+		case grpc.TrailerCallOption:
+			*o.TrailerAddr = trailer
+		}
+	}
+}
+
+// toStatusError converts err as a gRPC server does for the errors of methods.
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+func cloneMessage(m any) (proto.Message, error) {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "message of type %T is not a protobuf message", m)
+	}
+	return proto.Clone(msg), nil
+}
+
+// copyMessage replaces the contents of dst with a copy of src.
+func copyMessage(dst, src any) error {
+	dstMsg, ok := dst.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "message of type %T is not a protobuf message", dst)
+	}
+	srcMsg, ok := src.(proto.Message)
+	if !ok {
+		return status.Errorf(codes.Internal, "message of type %T is not a protobuf message", src)
+	}
+	if reflect.TypeOf(dstMsg) != reflect.TypeOf(srcMsg) {
+		// Messages of different Go types, such as dynamic messages, are
+		// copied through the wire format.
+		data, err := proto.Marshal(srcMsg)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to copy message: %v", err)
+		}
+		return proto.Unmarshal(data, dstMsg)
+	}
+	proto.Reset(dstMsg)
+	proto.Merge(dstMsg, srcMsg)
+	return nil
+}
+
+// chainUnaryInterceptors returns an interceptor running interceptors in
+// order, or nil if there are none.
+func chainUnaryInterceptors(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	if len(interceptors) == 0 {
+		return nil
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		next := handler
+		for i := len(interceptors) - 1; i > 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return interceptors[0](ctx, req, info, next)
+	}
+}
+
+// chainStreamInterceptors returns an interceptor running interceptors in
+// order.
+func chainStreamInterceptors(interceptors []grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		next := handler
+		for i := len(interceptors) - 1; i > 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(srv any, ss grpc.ServerStream) error {
+				return interceptor(srv, ss, info, inner)
+			}
+		}
+		return interceptors[0](srv, ss, info, next)
+	}
+}
@@ -0,0 +1,293 @@
+package runtime_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// echoServer is the handler type of echoServiceDesc.
+type echoServer interface{}
+
+// echoServiceDesc describes a service with a bidirectional streaming method,
+// which responds to each string with its upper case, and a client streaming
+// method, which responds with the concatenation of the strings.
+var echoServiceDesc = grpc.ServiceDesc{
+	ServiceName: "example.Echo",
+	HandlerType: (*echoServer)(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Upper",
+			ServerStreams: true,
+			ClientStreams: true,
+			Handler: func(_ any, stream grpc.ServerStream) error {
+				for {
+					var msg wrapperspb.StringValue
+					if err := stream.RecvMsg(&msg); err == io.EOF {
+						return nil
+					} else if err != nil {
+						return err
+					}
+					if msg.GetValue() == "" {
+						return errors.New("empty string")
+					}
+					if err := stream.SendMsg(wrapperspb.String(strings.ToUpper(msg.GetValue()))); err != nil {
+						return err
+					}
+				}
+			},
+		},
+		{
+			StreamName:    "Concat",
+			ClientStreams: true,
+			Handler: func(_ any, stream grpc.ServerStream) error {
+				var sb strings.Builder
+				for {
+					var msg wrapperspb.StringValue
+					if err := stream.RecvMsg(&msg); err == io.EOF {
+						return stream.SendMsg(wrapperspb.String(sb.String()))
+					} else if err != nil {
+						return err
+					}
+					sb.WriteString(msg.GetValue())
+				}
+			},
+		},
+	},
+}
+
+func TestInProcessChannelUnary(t *testing.T) {
+	var infos []string
+	ch := runtime.NewInProcessChannel(runtime.WithInProcessUnaryInterceptors(
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			infos = append(infos, "first "+info.FullMethod)
+			md, _ := metadata.FromIncomingContext(ctx)
+			if foo := md.Get("foo"); len(foo) > 0 {
+				if err := grpc.SetHeader(ctx, metadata.Pairs("x-echo", foo[0])); err != nil {
+					return nil, err
+				}
+				if err := grpc.SetTrailer(ctx, metadata.Pairs("x-echo", foo[0])); err != nil {
+					return nil, err
+				}
+			}
+			return handler(ctx, req)
+		},
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			infos = append(infos, "second "+info.FullMethod)
+			return handler(ctx, req)
+		},
+	))
+	hs := health.NewServer()
+	hs.SetServingStatus("foo", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(ch, hs)
+	client := healthpb.NewHealthClient(ch)
+
+	req := &healthpb.HealthCheckRequest{Service: "foo"}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "foo", "bar")
+	var header, trailer metadata.MD
+	resp, err := client.Check(ctx, req, grpc.Header(&header), grpc.Trailer(&trailer))
+	if err != nil {
+		t.Fatalf("client.Check(ctx, %v) failed with %v; want success", req, err)
+	}
+	if got, want := resp.GetStatus(), healthpb.HealthCheckResponse_SERVING; got != want {
+		t.Errorf("resp.Status = %v; want %v", got, want)
+	}
+	wantInfos := []string{"first /grpc.health.v1.Health/Check", "second /grpc.health.v1.Health/Check"}
+	if len(infos) != len(wantInfos) || infos[0] != wantInfos[0] || infos[1] != wantInfos[1] {
+		t.Errorf("interceptors = %q; want %q", infos, wantInfos)
+	}
+	if got, want := header.Get("x-echo"), []string{"bar"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("header x-echo = %q; want %q", got, want)
+	}
+	if got, want := trailer.Get("x-echo"), []string{"bar"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("trailer x-echo = %q; want %q", got, want)
+	}
+
+	req = &healthpb.HealthCheckRequest{Service: "unknown"}
+	if _, err := client.Check(ctx, req); status.Code(err) != codes.NotFound {
+		t.Errorf("client.Check(ctx, %v) failed with %v; want %v", req, err, codes.NotFound)
+	}
+	if _, err := client.List(ctx, &healthpb.HealthListRequest{}); err != nil {
+		t.Errorf("client.List(ctx) failed with %v; want success", err)
+	}
+}
+
+func TestInProcessChannelUnimplemented(t *testing.T) {
+	ch := runtime.NewInProcessChannel()
+	err := ch.Invoke(context.Background(), "/grpc.health.v1.Health/Check", &healthpb.HealthCheckRequest{}, &healthpb.HealthCheckResponse{})
+	if got, want := status.Code(err), codes.Unimplemented; got != want {
+		t.Errorf("ch.Invoke() failed with %v; want %v", err, want)
+	}
+	healthpb.RegisterHealthServer(ch, health.NewServer())
+	err = ch.Invoke(context.Background(), "/grpc.health.v1.Health/Unknown", &healthpb.HealthCheckRequest{}, &healthpb.HealthCheckResponse{})
+	if got, want := status.Code(err), codes.Unimplemented; got != want {
+		t.Errorf("ch.Invoke() failed with %v; want %v", err, want)
+	}
+}
+
+func TestInProcessChannelServerStream(t *testing.T) {
+	var streamInfo *grpc.StreamServerInfo
+	ch := runtime.NewInProcessChannel(runtime.WithInProcessStreamInterceptors(
+		func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			streamInfo = info
+			if err := ss.SendHeader(metadata.Pairs("x-stream", "watch")); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		},
+	))
+	hs := health.NewServer()
+	hs.SetServingStatus("foo", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(ch, hs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := healthpb.NewHealthClient(ch).Watch(ctx, &healthpb.HealthCheckRequest{Service: "foo"})
+	if err != nil {
+		t.Fatalf("client.Watch() failed with %v; want success", err)
+	}
+	header, err := stream.Header()
+	if err != nil {
+		t.Fatalf("stream.Header() failed with %v; want success", err)
+	}
+	if got, want := header.Get("x-stream"), []string{"watch"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("header x-stream = %q; want %q", got, want)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("stream.Recv() failed with %v; want success", err)
+	}
+	if got, want := resp.GetStatus(), healthpb.HealthCheckResponse_SERVING; got != want {
+		t.Errorf("resp.Status = %v; want %v", got, want)
+	}
+	hs.SetServingStatus("foo", healthpb.HealthCheckResponse_NOT_SERVING)
+	if resp, err = stream.Recv(); err != nil {
+		t.Fatalf("stream.Recv() failed with %v; want success", err)
+	}
+	if got, want := resp.GetStatus(), healthpb.HealthCheckResponse_NOT_SERVING; got != want {
+		t.Errorf("resp.Status = %v; want %v", got, want)
+	}
+	if streamInfo == nil || streamInfo.FullMethod != "/grpc.health.v1.Health/Watch" || streamInfo.IsClientStream || !streamInfo.IsServerStream {
+		t.Errorf("stream info = %+v; want a server stream of /grpc.health.v1.Health/Watch", streamInfo)
+	}
+
+	cancel()
+	if _, err := stream.Recv(); status.Code(err) != codes.Canceled {
+		t.Errorf("stream.Recv() failed with %v; want %v", err, codes.Canceled)
+	}
+}
+
+func TestInProcessChannelBidiStream(t *testing.T) {
+	ch := runtime.NewInProcessChannel()
+	ch.RegisterService(&echoServiceDesc, struct{}{})
+	desc := &echoServiceDesc.Streams[0]
+
+	stream, err := ch.NewStream(context.Background(), desc, "/example.Echo/Upper")
+	if err != nil {
+		t.Fatalf("ch.NewStream() failed with %v; want success", err)
+	}
+	for _, s := range []string{"foo", "bar"} {
+		if err := stream.SendMsg(wrapperspb.String(s)); err != nil {
+			t.Fatalf("stream.SendMsg(%q) failed with %v; want success", s, err)
+		}
+		var resp wrapperspb.StringValue
+		if err := stream.RecvMsg(&resp); err != nil {
+			t.Fatalf("stream.RecvMsg() failed with %v; want success", err)
+		}
+		if got, want := resp.GetValue(), strings.ToUpper(s); got != want {
+			t.Errorf("resp = %q; want %q", got, want)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("stream.CloseSend() failed with %v; want success", err)
+	}
+	if err := stream.RecvMsg(&wrapperspb.StringValue{}); err != io.EOF {
+		t.Errorf("stream.RecvMsg() failed with %v; want %v", err, io.EOF)
+	}
+
+	stream, err = ch.NewStream(context.Background(), desc, "/example.Echo/Upper")
+	if err != nil {
+		t.Fatalf("ch.NewStream() failed with %v; want success", err)
+	}
+	if err := stream.SendMsg(wrapperspb.String("")); err != nil {
+		t.Fatalf("stream.SendMsg(%q) failed with %v; want success", "", err)
+	}
+	err = stream.RecvMsg(&wrapperspb.StringValue{})
+	if got, want := status.Code(err), codes.Unknown; got != want {
+		t.Errorf("stream.RecvMsg() failed with %v; want %v", err, want)
+	}
+	if err := stream.SendMsg(wrapperspb.String("foo")); err != io.EOF {
+		t.Errorf("stream.SendMsg() after the end of the call failed with %v; want %v", err, io.EOF)
+	}
+}
+
+func TestInProcessChannelClientStream(t *testing.T) {
+	ch := runtime.NewInProcessChannel()
+	ch.RegisterService(&echoServiceDesc, struct{}{})
+
+	stream, err := ch.NewStream(context.Background(), &echoServiceDesc.Streams[1], "/example.Echo/Concat")
+	if err != nil {
+		t.Fatalf("ch.NewStream() failed with %v; want success", err)
+	}
+	for _, s := range []string{"foo", "bar", "baz"} {
+		if err := stream.SendMsg(wrapperspb.String(s)); err != nil {
+			t.Fatalf("stream.SendMsg(%q) failed with %v; want success", s, err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("stream.CloseSend() failed with %v; want success", err)
+	}
+	var resp wrapperspb.StringValue
+	if err := stream.RecvMsg(&resp); err != nil {
+		t.Fatalf("stream.RecvMsg() failed with %v; want success", err)
+	}
+	if got, want := resp.GetValue(), "foobarbaz"; got != want {
+		t.Errorf("resp = %q; want %q", got, want)
+	}
+}
+
+func TestInProcessChannelWithRPCProtocols(t *testing.T) {
+	ch := runtime.NewInProcessChannel()
+	hs := health.NewServer()
+	hs.SetServingStatus("foo", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(ch, hs)
+	mux := runtime.NewServeMux(runtime.WithRPCProtocols(runtime.RPCProtocolConnect))
+	registerHealthRPC(mux, ch)
+
+	body := rpcRequestFrame(t, &healthpb.HealthCheckRequest{Service: "foo"})
+	req := httptest.NewRequest(http.MethodPost, "/grpc.health.v1.Health/Watch", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/connect+proto")
+	req.Header.Set("Connect-Timeout-Ms", "100")
+	resp := httptest.NewRecorder()
+	mux.ServeHTTP(resp, req)
+
+	frames := rpcFrames(t, resp.Body.Bytes())
+	if len(frames) != 2 {
+		t.Fatalf("frames = %q; want a message and the end of the stream", frames)
+	}
+	var msg healthpb.HealthCheckResponse
+	if err := proto.Unmarshal([]byte(frames[0].data), &msg); err != nil {
+		t.Fatalf("proto.Unmarshal(%q) failed with %v; want success", frames[0].data, err)
+	}
+	if got, want := msg.GetStatus(), healthpb.HealthCheckResponse_SERVING; got != want {
+		t.Errorf("msg.Status = %v; want %v", got, want)
+	}
+	if got, want := frames[1].data, `{"error":{"code":"deadline_exceeded"`; !strings.HasPrefix(got, want) {
+		t.Errorf("end of stream = %s; want prefix %s", got, want)
+	}
+}
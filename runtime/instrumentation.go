@@ -0,0 +1,253 @@
+package runtime
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Instrumentation observes the requests routed by a ServeMux, for example to
+// record traces and metrics. See WithInstrumentation.
+type Instrumentation interface {
+	// StartRequest is called once a request has been matched to a route, before
+	// its handler runs. The returned context is used for the rest of the request.
+	StartRequest(ctx context.Context, r *http.Request) (context.Context, RequestInstrumentation)
+}
+
+// RequestInstrumentation observes a single request. Its methods are called from
+// the goroutine serving the request.
+type RequestInstrumentation interface {
+	// Annotate is called by AnnotateContext and AnnotateIncomingContext once the
+	// RPC method and HTTP path pattern of the request are known. The returned
+	// metadata, such as a propagated trace context, is added to the gRPC
+	// metadata of the call.
+	Annotate(ctx context.Context, rpcMethodName, httpPathPattern string) metadata.MD
+	// End is called after the handler of the request has returned.
+	End(ctx context.Context, stats RequestStats)
+}
+
+// RequestStats holds the measurements collected by the ServeMux for a single
+// request.
+type RequestStats struct {
+	// HTTPStatus is the status code written to the response.
+	HTTPStatus int
+	// RequestSize is the number of bytes read from the request body.
+	RequestSize int64
+	// ResponseSize is the number of bytes written to the response body.
+	ResponseSize int64
+	// MessagesReceived is the number of messages decoded from the request body.
+	MessagesReceived int
+	// MessagesSent is the number of messages forwarded in the response.
+	MessagesSent int
+	// UnmarshalDuration is the total time spent decoding request messages.
+	UnmarshalDuration time.Duration
+	// MarshalDuration is the total time spent encoding response messages,
+	// including error responses.
+	MarshalDuration time.Duration
+	// GRPCCode is the gRPC status code the request completed with.
+	GRPCCode codes.Code
+}
+
+// WithInstrumentation returns a ServeMuxOption which reports every routed request
+// to inst. Requests which do not match any route are not reported.
+func WithInstrumentation(inst Instrumentation) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.instrumentation = inst
+	}
+}
+
+type requestTrackerKey struct{}
+
+// requestTracker accumulates the RequestStats of a request, and the route it
+// was matched to.
+type requestTracker struct {
+	inst RequestInstrumentation
+	rw   *instrumentedResponseWriter
+
+	mu              sync.Mutex
+	stats           RequestStats
+	codeSet         bool
+	rpcMethod       string
+	httpPathPattern string
+}
+
+func requestTrackerFromContext(ctx context.Context) *requestTracker {
+	t, _ := ctx.Value(requestTrackerKey{}).(*requestTracker)
+	return t
+}
+
+// trackRequest returns w and r instrumented to collect the RequestStats of the
+// request. If r is already tracked, w and r are returned as is.
+func trackRequest(w http.ResponseWriter, r *http.Request) (http.ResponseWriter, *http.Request, *requestTracker) {
+	if t := requestTrackerFromContext(r.Context()); t != nil {
+		return w, r, t
+	}
+	t := &requestTracker{}
+	r = r.WithContext(context.WithValue(r.Context(), requestTrackerKey{}, t))
+	if r.Body != nil && r.Body != http.NoBody {
+		r.Body = &countingReadCloser{ReadCloser: r.Body, tracker: t}
+	}
+	t.rw = &instrumentedResponseWriter{ResponseWriter: w, tracker: t}
+	return t.rw, r, t
+}
+
+func (t *requestTracker) add(fn func(*RequestStats)) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	fn(&t.stats)
+	t.mu.Unlock()
+}
+
+func (t *requestTracker) messageSent() {
+	t.add(func(s *RequestStats) { s.MessagesSent++ })
+}
+
+// setCode records the gRPC status code the request completed with. The first
+// recorded code wins, as later errors are usually caused by the first one.
+func (t *requestTracker) setCode(code codes.Code) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	if !t.codeSet {
+		t.stats.GRPCCode, t.codeSet = code, true
+	}
+	t.mu.Unlock()
+}
+
+func (t *requestTracker) setRoute(rpcMethod, httpPathPattern string) {
+	t.mu.Lock()
+	if rpcMethod != "" {
+		t.rpcMethod = rpcMethod
+	}
+	if httpPathPattern != "" {
+		t.httpPathPattern = httpPathPattern
+	}
+	t.mu.Unlock()
+}
+
+// snapshot returns the RequestStats collected so far.
+func (t *requestTracker) snapshot() RequestStats {
+	t.mu.Lock()
+	stats := t.stats
+	t.mu.Unlock()
+	stats.HTTPStatus = t.rw.status
+	if stats.HTTPStatus == 0 {
+		stats.HTTPStatus = http.StatusOK
+	}
+	return stats
+}
+
+// instrumentRequest wraps the handler call of a routed request.
+func (s *ServeMux) instrumentRequest(w http.ResponseWriter, r *http.Request, handle func(http.ResponseWriter, *http.Request)) {
+	w, r, tracker := trackRequest(w, r)
+	ctx, inst := s.instrumentation.StartRequest(r.Context(), r)
+	tracker.inst = inst
+
+	handle(w, r.WithContext(ctx))
+
+	inst.End(ctx, tracker.snapshot())
+}
+
+// trackErrors wraps the error handlers of the ServeMux to record the gRPC
+// status code of failed requests.
+func (s *ServeMux) trackErrors() {
+	errorHandler := s.errorHandler
+	s.errorHandler = func(ctx context.Context, mux *ServeMux, marshaler Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+		requestTrackerFromContext(ctx).setCode(errorCode(err))
+		errorHandler(ctx, mux, marshaler, w, r, err)
+	}
+	streamErrorHandler := s.streamErrorHandler
+	s.streamErrorHandler = func(ctx context.Context, err error) *status.Status {
+		st := streamErrorHandler(ctx, err)
+		requestTrackerFromContext(ctx).setCode(st.Code())
+		return st
+	}
+	routingErrorHandler := s.routingErrorHandler
+	s.routingErrorHandler = func(ctx context.Context, mux *ServeMux, marshaler Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
+		requestTrackerFromContext(ctx).setCode(routingErrorCode(httpStatus))
+		routingErrorHandler(ctx, mux, marshaler, w, r, httpStatus)
+	}
+}
+
+type countingReadCloser struct {
+	io.ReadCloser
+	tracker *requestTracker
+}
+
+func (c *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.tracker.add(func(s *RequestStats) { s.RequestSize += int64(n) })
+	return n, err
+}
+
+// instrumentedResponseWriter records the status and size of a response.
+type instrumentedResponseWriter struct {
+	http.ResponseWriter
+	tracker *requestTracker
+	status  int
+}
+
+func (w *instrumentedResponseWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *instrumentedResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.tracker.add(func(s *RequestStats) { s.ResponseSize += int64(n) })
+	return n, err
+}
+
+func (w *instrumentedResponseWriter) Flush() {
+	_ = http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap allows http.ResponseController to reach the underlying ResponseWriter.
+func (w *instrumentedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// marshaled records the time spent encoding a response message since start.
+func (t *requestTracker) marshaled(start time.Time) {
+	t.add(func(s *RequestStats) { s.MarshalDuration += time.Since(start) })
+}
+
+// unmarshaled records the time spent decoding a request message since start,
+// and counts the message if it was decoded.
+func (t *requestTracker) unmarshaled(start time.Time, err error) {
+	t.add(func(s *RequestStats) {
+		s.UnmarshalDuration += time.Since(start)
+		if err == nil {
+			s.MessagesReceived++
+		}
+	})
+}
+
+// DecodeRequest decodes the next message of a request body from "dec" into
+// "v". The time it took and the decoded message are reported to the
+// Instrumentation of the ServeMux which annotated "ctx", if any. It is called
+// by the generated handlers.
+func DecodeRequest(ctx context.Context, dec Decoder, v interface{}) error {
+	tracker := requestTrackerFromContext(ctx)
+	if tracker == nil {
+		return dec.Decode(v)
+	}
+	start := time.Now()
+	err := dec.Decode(v)
+	tracker.unmarshaled(start, err)
+	return err
+}
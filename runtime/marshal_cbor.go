@@ -0,0 +1,351 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// MIMECBOR is the MIME type of CBOR bodies.
+	MIMECBOR = "application/cbor"
+	// MIMECBORSeq is the MIME type of CBOR sequences, as sent by
+	// ForwardResponseStream with a CBORMarshaler.
+	MIMECBORSeq = "application/cbor-seq"
+)
+
+// CBOR major types.
+const (
+	cborUint byte = iota
+	cborNegInt
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+const cborBreak = 0xff
+
+var errCBORBreak = errors.New("cbor: unexpected break")
+
+// CBORMarshaler is a Marshaler which marshals/unmarshals into/from CBOR
+// (RFC 8949). Values are mapped as by JSONPb, with the same options: messages
+// are encoded as maps keyed by their protojson field names, and well-known
+// types take their protojson representation. 64-bit integers and bytes fields
+// are however encoded as CBOR integers and byte strings.
+//
+// Streams are encoded as CBOR sequences (RFC 8742), whose items need no
+// delimiter. The decoder also accepts the protojson representation of 64-bit
+// integers and bytes fields.
+type CBORMarshaler struct {
+	protojson.MarshalOptions
+	protojson.UnmarshalOptions
+}
+
+// ContentType always returns "application/cbor".
+func (*CBORMarshaler) ContentType(_ interface{}) string {
+	return MIMECBOR
+}
+
+// StreamContentType always returns "application/cbor-seq".
+func (*CBORMarshaler) StreamContentType(_ interface{}) string {
+	return MIMECBORSeq
+}
+
+// Marshal marshals "v" into CBOR.
+func (c *CBORMarshaler) Marshal(v interface{}) ([]byte, error) {
+	return c.AppendMarshal(nil, v)
+}
+
+// AppendMarshal appends the CBOR encoding of "v" to "b".
+func (c *CBORMarshaler) AppendMarshal(b []byte, v interface{}) ([]byte, error) {
+	return marshalDataModel(b, c.MarshalOptions, v, cborFormat{})
+}
+
+// Unmarshal unmarshals CBOR "data" into "v".
+func (c *CBORMarshaler) Unmarshal(data []byte, v interface{}) error {
+	return c.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// NewDecoder returns a Decoder which reads a CBOR sequence from "r".
+func (c *CBORMarshaler) NewDecoder(r io.Reader) Decoder {
+	return &dataModelDecoder{r: bufio.NewReader(r), readValue: readCBOR, opts: c.UnmarshalOptions}
+}
+
+// NewEncoder returns an Encoder which writes a CBOR sequence into "w".
+func (c *CBORMarshaler) NewEncoder(w io.Writer) Encoder {
+	return EncoderFunc(func(v interface{}) error {
+		buf, err := c.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(buf)
+		return err
+	})
+}
+
+// Delimiter returns an empty delimiter, as the items of a CBOR sequence are
+// self-delimiting.
+func (*CBORMarshaler) Delimiter() []byte {
+	return []byte{}
+}
+
+func appendCBORHead(b []byte, major byte, n uint64) []byte {
+	major <<= 5
+	switch {
+	case n < 24:
+		return append(b, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, major|27), n)
+}
+
+// cborFormat appends values in CBOR.
+type cborFormat struct{}
+
+func (cborFormat) appendNull(b []byte) []byte {
+	return append(b, cborSimple<<5|22)
+}
+
+func (cborFormat) appendBool(b []byte, v bool) []byte {
+	if v {
+		return append(b, cborSimple<<5|21)
+	}
+	return append(b, cborSimple<<5|20)
+}
+
+func (cborFormat) appendInt(b []byte, n int64) []byte {
+	if n < 0 {
+		return appendCBORHead(b, cborNegInt, uint64(-1-n))
+	}
+	return appendCBORHead(b, cborUint, uint64(n))
+}
+
+func (cborFormat) appendUint(b []byte, n uint64) []byte {
+	return appendCBORHead(b, cborUint, n)
+}
+
+func (cborFormat) appendFloat(b []byte, f float64) []byte {
+	if isFloat32(f) {
+		return binary.BigEndian.AppendUint32(append(b, cborSimple<<5|26), math.Float32bits(float32(f)))
+	}
+	return binary.BigEndian.AppendUint64(append(b, cborSimple<<5|27), math.Float64bits(f))
+}
+
+func (cborFormat) appendString(b []byte, s string) []byte {
+	b = appendCBORHead(b, cborText, uint64(len(s)))
+	return append(b, s...)
+}
+
+func (cborFormat) appendBytes(b []byte, data []byte) []byte {
+	b = appendCBORHead(b, cborBytes, uint64(len(data)))
+	return append(b, data...)
+}
+
+func (cborFormat) appendArrayHeader(b []byte, n int) []byte {
+	return appendCBORHead(b, cborArray, uint64(n))
+}
+
+func (cborFormat) appendMapHeader(b []byte, n int) []byte {
+	return appendCBORHead(b, cborMap, uint64(n))
+}
+
+// readCBORArgument reads the argument of an item whose initial byte has the
+// additional information info. indefinite is set for info 31.
+func readCBORArgument(r *bufio.Reader, info byte) (n uint64, indefinite bool, err error) {
+	var buf [8]byte
+	switch {
+	case info < 24:
+		return uint64(info), false, nil
+	case info == 24:
+		b, err := r.ReadByte()
+		return uint64(b), false, err
+	case info == 25:
+		_, err := io.ReadFull(r, buf[:2])
+		return uint64(binary.BigEndian.Uint16(buf[:2])), false, err
+	case info == 26:
+		_, err := io.ReadFull(r, buf[:4])
+		return uint64(binary.BigEndian.Uint32(buf[:4])), false, err
+	case info == 27:
+		_, err := io.ReadFull(r, buf[:8])
+		return binary.BigEndian.Uint64(buf[:8]), false, err
+	case info == 31:
+		return 0, true, nil
+	}
+	return 0, false, fmt.Errorf("cbor: invalid additional information %d", info)
+}
+
+// readCBOR reads a CBOR data item from r. Tags are ignored.
+func readCBOR(r *bufio.Reader, depth int) (interface{}, error) {
+	if depth > maxDataModelDepth {
+		return nil, errors.New("cbor: exceeded max recursion depth")
+	}
+	initial, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	major, info := initial>>5, initial&0x1f
+	if initial == cborBreak {
+		return nil, errCBORBreak
+	}
+	if major == cborSimple {
+		return readCBORSimple(r, info)
+	}
+	n, indefinite, err := readCBORArgument(r, info)
+	if err != nil {
+		return nil, err
+	}
+	if indefinite && (major < cborBytes || major > cborMap) {
+		return nil, fmt.Errorf("cbor: indefinite length for major type %d", major)
+	}
+
+	switch major {
+	case cborUint:
+		if n <= math.MaxInt64 {
+			return int64(n), nil
+		}
+		return n, nil
+	case cborNegInt:
+		if n > math.MaxInt64 {
+			return nil, errors.New("cbor: negative integer overflows int64")
+		}
+		return -1 - int64(n), nil
+	case cborBytes, cborText:
+		data, err := readCBORString(r, major, n, indefinite)
+		if err != nil || major == cborBytes {
+			return data, err
+		}
+		return string(data), nil
+	case cborArray:
+		list := make([]interface{}, 0, dataModelCap(n))
+		for i := uint64(0); indefinite || i < n; i++ {
+			elem, err := readCBOR(r, depth+1)
+			if indefinite && errors.Is(err, errCBORBreak) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, elem)
+		}
+		return list, nil
+	case cborMap:
+		obj := make(jsonObject, 0, dataModelCap(n))
+		for i := uint64(0); indefinite || i < n; i++ {
+			key, err := readCBOR(r, depth+1)
+			if indefinite && errors.Is(err, errCBORBreak) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			name, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("cbor: map key of type %T is not a text string", key)
+			}
+			value, err := readCBOR(r, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, jsonMember{key: name, value: value})
+		}
+		return obj, nil
+	case cborTag:
+		return readCBOR(r, depth+1)
+	}
+	panic("unreachable")
+}
+
+// readCBORString reads the contents of a byte or text string of length n, or
+// the chunks of an indefinite-length string.
+func readCBORString(r *bufio.Reader, major byte, n uint64, indefinite bool) ([]byte, error) {
+	if !indefinite {
+		return readSizedBytes(r, n)
+	}
+	var data []byte
+	for {
+		initial, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if initial == cborBreak {
+			return data, nil
+		}
+		if initial>>5 != major || initial&0x1f == 31 {
+			return nil, errors.New("cbor: invalid chunk of indefinite-length string")
+		}
+		n, _, err := readCBORArgument(r, initial&0x1f)
+		if err != nil {
+			return nil, err
+		}
+		chunk, err := readSizedBytes(r, n)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, chunk...)
+	}
+}
+
+func readCBORSimple(r *bufio.Reader, info byte) (interface{}, error) {
+	var buf [8]byte
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		if _, err := io.ReadFull(r, buf[:2]); err != nil {
+			return nil, err
+		}
+		return float16ToFloat64(binary.BigEndian.Uint16(buf[:2])), nil
+	case 26:
+		if _, err := io.ReadFull(r, buf[:4]); err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(buf[:4]))), nil
+	case 27:
+		if _, err := io.ReadFull(r, buf[:8]); err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(buf[:8])), nil
+	}
+	return nil, fmt.Errorf("cbor: unsupported simple value %d", info)
+}
+
+// float16ToFloat64 converts an IEEE 754 half-precision number.
+func float16ToFloat64(h uint16) float64 {
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if h&0x8000 != 0 {
+		f = -f
+	}
+	return f
+}
@@ -0,0 +1,156 @@
+package runtime_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"google.golang.org/protobuf/proto"
+)
+
+// testDataModelRoundTrip checks that the messages of the FastJSONPb fixtures
+// survive a round trip through m, by comparing their JSON encodings.
+func testDataModelRoundTrip(t *testing.T, m runtime.Marshaler) {
+	for i, v := range fastJSONPbFixtures(t) {
+		msg, ok := v.(proto.Message)
+		if !ok || msg == nil || !msg.ProtoReflect().IsValid() {
+			continue
+		}
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			buf, err := m.Marshal(msg)
+			if err != nil {
+				t.Fatalf("m.Marshal(%v) failed with %v; want success", msg, err)
+			}
+			got := msg.ProtoReflect().New().Interface()
+			if err := m.Unmarshal(buf, got); err != nil {
+				t.Fatalf("m.Unmarshal(%x) failed with %v; want success", buf, err)
+			}
+
+			jsonpb := &runtime.JSONPb{}
+			wantJSON, err := jsonpb.Marshal(msg)
+			if err != nil {
+				t.Fatalf("jsonpb.Marshal(%v) failed with %v; want success", msg, err)
+			}
+			gotJSON, err := jsonpb.Marshal(got)
+			if err != nil {
+				t.Fatalf("jsonpb.Marshal(%v) failed with %v; want success", got, err)
+			}
+			if got, want := compactJSON(t, gotJSON), compactJSON(t, wantJSON); got != want {
+				t.Errorf("round trip = %s; want %s", got, want)
+			}
+		})
+	}
+}
+
+// testDataModelStream checks that a sequence of messages encoded by m is
+// decoded until io.EOF.
+func testDataModelStream(t *testing.T, m runtime.Marshaler) {
+	var buf bytes.Buffer
+	enc := m.NewEncoder(&buf)
+	want := []string{"foo", "", "bar"}
+	for _, id := range want {
+		if err := enc.Encode(&examplepb.SimpleMessage{Id: id}); err != nil {
+			t.Fatalf("enc.Encode() failed with %v; want success", err)
+		}
+	}
+
+	dec := m.NewDecoder(&buf)
+	var got []string
+	for {
+		var msg examplepb.SimpleMessage
+		err := dec.Decode(&msg)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("dec.Decode() failed with %v; want success", err)
+		}
+		got = append(got, msg.Id)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("decoded ids = %q; want %q", got, want)
+	}
+}
+
+func TestCBORMarshaler(t *testing.T) {
+	m := &runtime.CBORMarshaler{}
+	msg := &examplepb.Proto3Message{
+		Int64Value:  -2,
+		StringValue: "foo",
+		BytesValue:  []byte{1},
+		FloatValue:  1.5,
+	}
+	got, err := m.Marshal(msg)
+	if err != nil {
+		t.Fatalf("m.Marshal(%v) failed with %v; want success", msg, err)
+	}
+	want := []byte{
+		// map(4)
+		0xa4,
+		0x6a, 'f', 'l', 'o', 'a', 't', 'V', 'a', 'l', 'u', 'e', 0xfa, 0x3f, 0xc0, 0, 0, // "floatValue": 1.5
+		0x6a, 'i', 'n', 't', '6', '4', 'V', 'a', 'l', 'u', 'e', 0x21, // "int64Value": -2
+		0x6b, 's', 't', 'r', 'i', 'n', 'g', 'V', 'a', 'l', 'u', 'e', 0x63, 'f', 'o', 'o', // "stringValue": "foo"
+		0x6a, 'b', 'y', 't', 'e', 's', 'V', 'a', 'l', 'u', 'e', 0x41, 1, // "bytesValue": h'01'
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("m.Marshal(%v) = %x; want %x", msg, got, want)
+	}
+}
+
+func TestCBORMarshaler_NativeTypes(t *testing.T) {
+	m := &runtime.CBORMarshaler{}
+	data := []byte{
+		// indefinite map
+		0xbf,
+		0x6a, 'i', 'n', 't', '6', '4', 'V', 'a', 'l', 'u', 'e', 0x3b, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, // "int64Value": -2^63
+		0x6a, 'b', 'y', 't', 'e', 's', 'V', 'a', 'l', 'u', 'e', 0x5f, 0x41, 1, 0x41, 2, 0xff, // "bytesValue": (_ h'01', h'02')
+		0x6b, 'd', 'o', 'u', 'b', 'l', 'e', 'V', 'a', 'l', 'u', 'e', 0xf9, 0x3e, 0x00, // "doubleValue": 1.5 as float16
+		0x6b, 's', 't', 'r', 'i', 'n', 'g', 'V', 'a', 'l', 'u', 'e', 0xc0, 0x63, 'f', 'o', 'o', // "stringValue": 0("foo")
+		0xff,
+	}
+	var got examplepb.Proto3Message
+	if err := m.Unmarshal(data, &got); err != nil {
+		t.Fatalf("m.Unmarshal(%x) failed with %v; want success", data, err)
+	}
+	want := &examplepb.Proto3Message{
+		Int64Value:  -1 << 63,
+		BytesValue:  []byte{1, 2},
+		DoubleValue: 1.5,
+		StringValue: "foo",
+	}
+	if !proto.Equal(&got, want) {
+		t.Errorf("m.Unmarshal(%x) = %v; want %v", data, &got, want)
+	}
+}
+
+func TestCBORMarshaler_RoundTrip(t *testing.T) {
+	testDataModelRoundTrip(t, &runtime.CBORMarshaler{})
+}
+
+func TestCBORMarshaler_Stream(t *testing.T) {
+	testDataModelStream(t, &runtime.CBORMarshaler{})
+}
+
+func TestCBORMarshaler_Errors(t *testing.T) {
+	for _, spec := range []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{name: "truncated", data: []byte{0xa1, 0x62, 'i', 'd', 0x63, 'f'}, want: io.ErrUnexpectedEOF},
+		{name: "integer key", data: []byte{0xa1, 0x01, 0x01}},
+		{name: "unexpected break", data: []byte{0x81, 0xff}},
+		{name: "invalid UTF-8", data: []byte{0xa1, 0x62, 'i', 'd', 0x61, 0xff}},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			err := (&runtime.CBORMarshaler{}).Unmarshal(spec.data, new(examplepb.SimpleMessage))
+			if err == nil || spec.want != nil && !errors.Is(err, spec.want) {
+				t.Errorf("Unmarshal(%x) failed with %v; want %v", spec.data, err, spec.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,459 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// The binary marshalers, CBORMarshaler and MsgPackMarshaler, map messages as
+// protojson does: with the protojson field names, and honoring the same
+// options. 64-bit integers and bytes fields are however encoded as native
+// integers and byte strings. The well-known types other than the wrappers and
+// Empty take their protojson representation, which is transcoded from JSON.
+//
+// Values of the JSON data model are represented as nil, bool, string,
+// json.Number, []interface{} and jsonObject. Decoding a binary format also
+// yields []byte, int64, uint64 and float64 values.
+
+// maxDataModelDepth is the maximum nesting depth of a decoded value, matching
+// the default recursion limit of protojson.
+const maxDataModelDepth = 10000
+
+// jsonObject is a JSON object whose members are kept in order, so that fields
+// are encoded in the order protojson writes them.
+type jsonObject []jsonMember
+
+type jsonMember struct {
+	key   string
+	value interface{}
+}
+
+// dataModelFormat appends the values of the data model to a buffer in a
+// binary format.
+type dataModelFormat interface {
+	appendNull(b []byte) []byte
+	appendBool(b []byte, v bool) []byte
+	appendInt(b []byte, n int64) []byte
+	appendUint(b []byte, n uint64) []byte
+	appendFloat(b []byte, f float64) []byte
+	appendString(b []byte, s string) []byte
+	appendBytes(b []byte, data []byte) []byte
+	// appendArrayHeader and appendMapHeader append the header of an array of
+	// n elements, and of a map of n entries.
+	appendArrayHeader(b []byte, n int) []byte
+	appendMapHeader(b []byte, n int) []byte
+}
+
+// marshalDataModel appends the encoding of v in format to b.
+func marshalDataModel(b []byte, opts protojson.MarshalOptions, v interface{}, format dataModelFormat) ([]byte, error) {
+	opts.Multiline, opts.Indent = false, ""
+	e := &dataModelEncoder{format: format, opts: &opts}
+	return e.appendValue(b, v)
+}
+
+// dataModelEncoder walks messages to append them in a binary format.
+type dataModelEncoder struct {
+	format dataModelFormat
+	opts   *protojson.MarshalOptions
+}
+
+func (e *dataModelEncoder) appendValue(b []byte, v interface{}) ([]byte, error) {
+	if p, ok := v.(proto.Message); ok {
+		return e.appendProto(b, p)
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice && !rv.IsNil() && rv.Type().Elem().Implements(protoMessageType) {
+		b = e.format.appendArrayHeader(b, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			var err error
+			if b, err = e.appendProto(b, rv.Index(i).Interface().(proto.Message)); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return e.appendJSON(b, v)
+}
+
+func (e *dataModelEncoder) appendProto(b []byte, p proto.Message) ([]byte, error) {
+	if p == nil {
+		return e.format.appendMapHeader(b, 0), nil
+	}
+	if !e.opts.AllowPartial {
+		if err := proto.CheckInitialized(p); err != nil {
+			return nil, err
+		}
+	}
+	return e.appendMessage(b, p.ProtoReflect())
+}
+
+// appendJSON appends v as transcoded from its JSON encoding.
+func (e *dataModelEncoder) appendJSON(b []byte, v interface{}) ([]byte, error) {
+	opts := *e.opts
+	opts.AllowPartial = true
+	jsonBuf := getBuffer()
+	defer putBuffer(jsonBuf)
+	data, err := (&FastJSONPb{JSONPb: JSONPb{MarshalOptions: opts}}).AppendMarshal((*jsonBuf)[:0], v)
+	if err != nil {
+		return nil, err
+	}
+	*jsonBuf = data
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	value, err := readJSONValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	return appendDataModelValue(b, e.format, value), nil
+}
+
+func (e *dataModelEncoder) appendMessage(b []byte, m protoreflect.Message) ([]byte, error) {
+	me := messageEncoderFor(m.Descriptor())
+	if me.wellKnown {
+		switch name := m.Descriptor().Name(); {
+		case name == "Empty":
+			return e.format.appendMapHeader(b, 0), nil
+		case isWrapperType(name):
+			fd := m.Descriptor().Fields().ByNumber(1)
+			return e.appendSingular(b, m.Get(fd), fd)
+		}
+		return e.appendJSON(b, m.Interface())
+	}
+
+	var exts []protoreflect.FieldDescriptor
+	if me.extendable {
+		m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			if fd.IsExtension() {
+				exts = append(exts, fd)
+			}
+			return true
+		})
+		slices.SortFunc(exts, func(x, y protoreflect.FieldDescriptor) int {
+			return strings.Compare(string(x.FullName()), string(y.FullName()))
+		})
+	}
+	n := len(exts)
+	for i := range me.fields {
+		if _, ok := me.fields[i].value(m, e.opts); ok {
+			n++
+		}
+	}
+
+	b = e.format.appendMapHeader(b, n)
+	for i := range me.fields {
+		f := &me.fields[i]
+		v, ok := f.value(m, e.opts)
+		if !ok {
+			continue
+		}
+		var err error
+		if b, err = e.appendField(b, f.fd, v); err != nil {
+			return nil, err
+		}
+	}
+	for _, fd := range exts {
+		var err error
+		if b, err = e.appendField(b, fd, m.Get(fd)); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// appendField appends the name and value of a field.
+func (e *dataModelEncoder) appendField(b []byte, fd protoreflect.FieldDescriptor, v protoreflect.Value) ([]byte, error) {
+	if e.opts.UseProtoNames {
+		b = e.format.appendString(b, fd.TextName())
+	} else {
+		b = e.format.appendString(b, fd.JSONName())
+	}
+	switch {
+	case fd.IsList():
+		list := v.List()
+		b = e.format.appendArrayHeader(b, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			var err error
+			if b, err = e.appendSingular(b, list.Get(i), fd); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case fd.IsMap():
+		mp := v.Map()
+		b = e.format.appendMapHeader(b, mp.Len())
+		for _, k := range sortedMapKeys(mp, fd) {
+			if !utf8.ValidString(k.String()) {
+				return nil, fmt.Errorf("field %v contains invalid UTF-8", fd.FullName())
+			}
+			b = e.format.appendString(b, k.String())
+			var err error
+			if b, err = e.appendSingular(b, mp.Get(k), fd.MapValue()); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return e.appendSingular(b, v, fd)
+}
+
+func (e *dataModelEncoder) appendSingular(b []byte, v protoreflect.Value, fd protoreflect.FieldDescriptor) ([]byte, error) {
+	if !v.IsValid() {
+		return e.format.appendNull(b), nil
+	}
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return e.format.appendBool(b, v.Bool()), nil
+	case protoreflect.StringKind:
+		if !utf8.ValidString(v.String()) {
+			return nil, fmt.Errorf("field %v contains invalid UTF-8", fd.FullName())
+		}
+		return e.format.appendString(b, v.String()), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return e.format.appendInt(b, v.Int()), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return e.format.appendUint(b, v.Uint()), nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return e.format.appendFloat(b, v.Float()), nil
+	case protoreflect.BytesKind:
+		return e.format.appendBytes(b, v.Bytes()), nil
+	case protoreflect.EnumKind:
+		if fd.Enum().FullName() == "google.protobuf.NullValue" {
+			return e.format.appendNull(b), nil
+		}
+		desc := fd.Enum().Values().ByNumber(v.Enum())
+		if e.opts.UseEnumNumbers || desc == nil {
+			return e.format.appendInt(b, int64(v.Enum())), nil
+		}
+		return e.format.appendString(b, string(desc.Name())), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return e.appendMessage(b, v.Message())
+	}
+	return nil, fmt.Errorf("%v has unknown kind: %v", fd.FullName(), fd.Kind())
+}
+
+func isWrapperType(name protoreflect.Name) bool {
+	switch name {
+	case "BoolValue", "Int32Value", "Int64Value", "UInt32Value", "UInt64Value",
+		"FloatValue", "DoubleValue", "StringValue", "BytesValue":
+		return true
+	}
+	return false
+}
+
+// appendDataModelValue appends a value of the JSON data model in format.
+func appendDataModelValue(b []byte, format dataModelFormat, v interface{}) []byte {
+	switch v := v.(type) {
+	case nil:
+		return format.appendNull(b)
+	case bool:
+		return format.appendBool(b, v)
+	case string:
+		return format.appendString(b, v)
+	case json.Number:
+		switch n := numberValue(v).(type) {
+		case int64:
+			return format.appendInt(b, n)
+		case uint64:
+			return format.appendUint(b, n)
+		case float64:
+			return format.appendFloat(b, n)
+		}
+	case []interface{}:
+		b = format.appendArrayHeader(b, len(v))
+		for _, elem := range v {
+			b = appendDataModelValue(b, format, elem)
+		}
+		return b
+	case jsonObject:
+		b = format.appendMapHeader(b, len(v))
+		for _, m := range v {
+			b = format.appendString(b, m.key)
+			b = appendDataModelValue(b, format, m.value)
+		}
+		return b
+	}
+	panic(fmt.Sprintf("unexpected value of type %T", v))
+}
+
+func readJSONValue(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('['):
+		var list []interface{}
+		for dec.More() {
+			value, err := readJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err := dec.Token()
+		return list, err
+	case json.Delim('{'):
+		var obj jsonObject
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := readJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, jsonMember{key: key.(string), value: value})
+		}
+		_, err := dec.Token()
+		return obj, err
+	}
+	return tok, nil
+}
+
+// numberValue converts n to an int64, a uint64 or a float64.
+func numberValue(n json.Number) interface{} {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return i
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return u
+	}
+	f, _ := strconv.ParseFloat(string(n), 64)
+	return f
+}
+
+// appendJSONValue appends the JSON encoding of a decoded value to b. Binary
+// strings are encoded in base64, as protojson expects for bytes fields.
+func appendJSONValue(b []byte, v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case nil:
+		return append(b, "null"...), nil
+	case bool:
+		return strconv.AppendBool(b, v), nil
+	case string:
+		b, ok := appendJSONString(b, v)
+		if !ok {
+			return nil, errors.New("invalid UTF-8 in string")
+		}
+		return b, nil
+	case []byte:
+		b = append(b, '"')
+		b = base64.StdEncoding.AppendEncode(b, v)
+		return append(b, '"'), nil
+	case int64:
+		return strconv.AppendInt(b, v, 10), nil
+	case uint64:
+		return strconv.AppendUint(b, v, 10), nil
+	case float64:
+		return appendJSONFloat(b, v, 64), nil
+	case []interface{}:
+		b = append(b, '[')
+		for i, elem := range v {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			var err error
+			if b, err = appendJSONValue(b, elem); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, ']'), nil
+	case jsonObject:
+		b = append(b, '{')
+		for i, m := range v {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			var err error
+			if b, err = appendJSONValue(b, m.key); err != nil {
+				return nil, err
+			}
+			b = append(b, ':')
+			if b, err = appendJSONValue(b, m.value); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, '}'), nil
+	}
+	return nil, fmt.Errorf("unexpected value of type %T", v)
+}
+
+// isFloat32 reports whether f is represented exactly as a float32.
+func isFloat32(f float64) bool {
+	return float64(float32(f)) == f
+}
+
+// dataModelDecoder is a Decoder reading a sequence of values of a binary
+// format, which are unmarshaled as their JSON encoding by protojson.
+type dataModelDecoder struct {
+	r         *bufio.Reader
+	readValue func(r *bufio.Reader, depth int) (interface{}, error)
+	opts      protojson.UnmarshalOptions
+}
+
+// Decode decodes the next value into v. It returns io.EOF once the input is
+// consumed, and io.ErrUnexpectedEOF if it ends within a value.
+func (d *dataModelDecoder) Decode(v interface{}) error {
+	if _, err := d.r.Peek(1); err != nil {
+		return err
+	}
+	value, err := d.readValue(d.r, 0)
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	data, err := appendJSONValue((*buf)[:0], value)
+	if err != nil {
+		return err
+	}
+	*buf = data
+	return unmarshalJSONPb(data, d.opts, v)
+}
+
+// readSizedBytes reads n bytes from r. Large reads are buffered as data
+// arrives, so that a forged length does not allocate memory up front.
+func readSizedBytes(r *bufio.Reader, n uint64) ([]byte, error) {
+	const chunkSize = 64 << 10
+	if n <= chunkSize {
+		b := make([]byte, n)
+		_, err := io.ReadFull(r, b)
+		return b, err
+	}
+	if n > math.MaxInt64 {
+		return nil, fmt.Errorf("length %d too large", n)
+	}
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, r, int64(n)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// dataModelCap bounds the capacity preallocated for a list or object of the
+// given length.
+func dataModelCap(n uint64) int {
+	return int(min(n, 1024))
+}
@@ -0,0 +1,322 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// MIMEMultipartForm is the MIME type of multipart form bodies.
+	MIMEMultipartForm = "multipart/form-data"
+	// MIMEURLEncodedForm is the MIME type of URL-encoded form bodies.
+	MIMEURLEncodedForm = "application/x-www-form-urlencoded"
+
+	// maxFormValueSize bounds the size of a URL-encoded body and of the value
+	// of a multipart form field, as net/http does for ParseForm.
+	maxFormValueSize = 10 << 20
+	// defaultMaxFileSize is the default value of FormMarshaler.MaxFileSize,
+	// matching the memory limit net/http uses for ParseMultipartForm.
+	defaultMaxFileSize = 32 << 20
+
+	httpBodyFullName = "google.api.HttpBody"
+)
+
+// FormMarshaler is a Marshaler which decodes "multipart/form-data" and
+// "application/x-www-form-urlencoded" request bodies into request messages.
+// Responses are encoded with the embedded Marshaler, or with the default
+// marshaler of the ServeMux if none is set. Register it for both MIME types:
+//
+//	formMarshaler := &runtime.FormMarshaler{Marshaler: &runtime.JSONPb{}}
+//	mux := runtime.NewServeMux(
+//		runtime.WithMarshalerOption(runtime.MIMEMultipartForm, formMarshaler),
+//		runtime.WithMarshalerOption(runtime.MIMEURLEncodedForm, formMarshaler),
+//	)
+//
+// Form field names are field paths, resolved like query parameters and
+// PopulateFieldFromPath: "name", "parent.child" and "labels[key]" are all
+// supported, and repeated fields take every value given for them.
+//
+// A file part of a multipart form sets a bytes field, or a google.api.HttpBody
+// field whose content type is taken from the part. For repeated fields every
+// file is appended. Text fields should precede the files of a form, as they
+// are set on the message being decoded when they are read.
+type FormMarshaler struct {
+	Marshaler
+
+	// ChunkSize, if positive, splits the files sent to client-streaming
+	// methods into chunks of at most ChunkSize bytes, each in its own message,
+	// so that they are streamed to the method rather than held in memory. The
+	// first message holds the fields read so far along with the first chunk
+	// of the file, each subsequent message only the next chunk, in the same
+	// field, and the last one the fields following the file.
+	//
+	// The files sent to other methods, which only receive one message, are
+	// never split, and are limited by MaxFileSize. Methods are known to be
+	// client-streaming if their handler was registered with
+	// ServeMux.HandleBinding, as the generated Register*Handler functions do,
+	// and their descriptor is in protoregistry.GlobalFiles.
+	ChunkSize int
+	// MaxFileSize is the maximum size of a file which is not chunked. Larger
+	// files are rejected. If zero, 32 MiB is used.
+	MaxFileSize int64
+
+	mediaType string
+	boundary  string
+	// chunk is set when decoding the request of a client-streaming method.
+	chunk bool
+}
+
+// bindContentType returns a copy of f decoding bodies of the given Content-Type.
+func (f *FormMarshaler) bindContentType(mediaType string, params map[string]string) Marshaler {
+	bound := *f
+	bound.mediaType, bound.boundary = mediaType, params["boundary"]
+	return &bound
+}
+
+// chunked returns a copy of f splitting files in chunks of ChunkSize bytes.
+func (f *FormMarshaler) chunked() Marshaler {
+	c := *f
+	c.chunk = true
+	return &c
+}
+
+func (f *FormMarshaler) responseMarshaler() Marshaler {
+	if f.Marshaler == nil {
+		return defaultMarshaler
+	}
+	return f.Marshaler
+}
+
+// ContentType returns the content type of the embedded Marshaler.
+func (f *FormMarshaler) ContentType(v interface{}) string {
+	return f.responseMarshaler().ContentType(v)
+}
+
+// Marshal marshals "v" with the embedded Marshaler.
+func (f *FormMarshaler) Marshal(v interface{}) ([]byte, error) {
+	return f.responseMarshaler().Marshal(v)
+}
+
+// NewEncoder returns an Encoder of the embedded Marshaler.
+func (f *FormMarshaler) NewEncoder(w io.Writer) Encoder {
+	return f.responseMarshaler().NewEncoder(w)
+}
+
+// Unmarshal unmarshals the form "data" into "v", which must be a proto.Message.
+// Only the first message is decoded if "data" holds a file split into chunks.
+func (f *FormMarshaler) Unmarshal(data []byte, v interface{}) error {
+	err := f.NewDecoder(bytes.NewReader(data)).Decode(v)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
+
+// NewDecoder returns a Decoder which reads a form from "r". Each call to
+// Decode fills a proto.Message and returns io.EOF once the form is consumed.
+func (f *FormMarshaler) NewDecoder(r io.Reader) Decoder {
+	d := &formDecoder{marshaler: f, r: r}
+	if f.mediaType == MIMEMultipartForm {
+		if f.boundary == "" {
+			d.err = errors.New("multipart form without boundary")
+		} else {
+			d.mr = multipart.NewReader(r, f.boundary)
+		}
+	}
+	return d
+}
+
+// formDecoder decodes a form into one or more messages.
+type formDecoder struct {
+	marshaler *FormMarshaler
+	r         io.Reader
+	mr        *multipart.Reader
+	err       error
+	done      bool
+
+	// file is the file part being split into chunks, if any.
+	file     *multipart.Part
+	fileData *bufio.Reader
+}
+
+func (d *formDecoder) Decode(v interface{}) error {
+	if d.err != nil {
+		return d.err
+	}
+	if d.done {
+		return io.EOF
+	}
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%T is not a proto.Message", v)
+	}
+	if d.mr == nil {
+		d.done = true
+		return d.decodeURLEncoded(msg)
+	}
+	if err := d.decodeMultipart(msg); err != nil {
+		d.err = err
+		return err
+	}
+	return nil
+}
+
+func (d *formDecoder) decodeURLEncoded(msg proto.Message) error {
+	data, err := io.ReadAll(io.LimitReader(d.r, maxFormValueSize+1))
+	if err != nil {
+		return err
+	}
+	if len(data) > maxFormValueSize {
+		return errors.New("form body too large")
+	}
+	if len(data) == 0 {
+		return io.EOF
+	}
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+	return populateFormValues(msg, values)
+}
+
+// decodeMultipart reads parts into msg until the form is consumed, or a file
+// chunk was read.
+func (d *formDecoder) decodeMultipart(msg proto.Message) error {
+	values := make(url.Values)
+	var read bool
+	for {
+		if d.file == nil {
+			part, err := d.mr.NextPart()
+			if errors.Is(err, io.EOF) {
+				d.done = true
+				if !read {
+					return io.EOF
+				}
+				break
+			}
+			if err != nil {
+				return err
+			}
+			read = true
+			name := part.FormName()
+			if name == "" {
+				continue
+			}
+			if part.FileName() == "" {
+				value, err := io.ReadAll(io.LimitReader(part, maxFormValueSize+1))
+				if err != nil {
+					return err
+				}
+				if len(value) > maxFormValueSize {
+					return fmt.Errorf("form field %q too large", name)
+				}
+				values.Add(name, string(value))
+				continue
+			}
+			d.file, d.fileData = part, bufio.NewReader(part)
+		}
+		read = true
+		more, err := d.readFile(msg.ProtoReflect())
+		if err != nil {
+			return err
+		}
+		if more {
+			break
+		}
+		d.file, d.fileData = nil, nil
+	}
+	return populateFormValues(msg, values)
+}
+
+// readFile sets the next chunk of the current file on msgValue, and reports
+// whether more of the file remains to be read.
+func (d *formDecoder) readFile(msgValue protoreflect.Message) (bool, error) {
+	name := d.file.FormName()
+	msgValue, fd, err := fieldByPath(msgValue, normalizeFieldPath(msgValue, strings.Split(name, ".")))
+	if err != nil {
+		return false, err
+	}
+	if fd == nil {
+		_, err := io.Copy(io.Discard, d.fileData)
+		return false, err
+	}
+
+	var data []byte
+	var more bool
+	if size := d.marshaler.ChunkSize; size > 0 && d.marshaler.chunk {
+		data = make([]byte, size)
+		n, err := io.ReadFull(d.fileData, data)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return false, err
+		}
+		data = data[:n]
+		if n == size {
+			_, err := d.fileData.Peek(1)
+			more = err == nil
+		}
+	} else {
+		maxSize := d.marshaler.MaxFileSize
+		if maxSize <= 0 {
+			maxSize = defaultMaxFileSize
+		}
+		data, err = io.ReadAll(io.LimitReader(d.fileData, maxSize+1))
+		if err != nil {
+			return false, err
+		}
+		if int64(len(data)) > maxSize {
+			return false, fmt.Errorf("file %q exceeds %d bytes", name, maxSize)
+		}
+	}
+
+	value, err := fileValue(msgValue, fd, d.file.Header.Get("Content-Type"), data)
+	if err != nil {
+		return false, err
+	}
+	if fd.IsList() {
+		msgValue.Mutable(fd).List().Append(value)
+	} else {
+		msgValue.Set(fd, value)
+	}
+	return more, nil
+}
+
+// fileValue converts the contents of a file to a value of fd.
+func fileValue(msgValue protoreflect.Message, fd protoreflect.FieldDescriptor, contentType string, data []byte) (protoreflect.Value, error) {
+	switch {
+	case fd.IsMap():
+	case fd.Kind() == protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(data), nil
+	case fd.Message() != nil && fd.Message().FullName() == httpBodyFullName:
+		var body protoreflect.Message
+		if fd.IsList() {
+			body = msgValue.Mutable(fd).List().NewElement().Message()
+		} else {
+			body = msgValue.NewField(fd).Message()
+		}
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		fields := body.Descriptor().Fields()
+		body.Set(fields.ByName("content_type"), protoreflect.ValueOfString(contentType))
+		body.Set(fields.ByName("data"), protoreflect.ValueOfBytes(data))
+		return protoreflect.ValueOfMessage(body), nil
+	}
+	return protoreflect.Value{}, fmt.Errorf("field %q cannot hold a file", fd.FullName().Name())
+}
+
+func populateFormValues(msg proto.Message, values url.Values) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return (&DefaultQueryParser{}).Parse(msg, values, utilities.NewDoubleArray(nil))
+}
@@ -0,0 +1,217 @@
+package runtime_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+type formPart struct {
+	name, filename, value string
+}
+
+func newMultipartRequest(t *testing.T, parts []formPart) (body *bytes.Buffer, contentType string) {
+	t.Helper()
+	body = new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+	for _, p := range parts {
+		var pw io.Writer
+		var err error
+		if p.filename != "" {
+			pw, err = w.CreateFormFile(p.name, p.filename)
+		} else {
+			pw, err = w.CreateFormField(p.name)
+		}
+		if err != nil {
+			t.Fatalf("Failed to create part %q: %v", p.name, err)
+		}
+		if _, err := io.WriteString(pw, p.value); err != nil {
+			t.Fatalf("Failed to write part %q: %v", p.name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("w.Close() failed with %v; want success", err)
+	}
+	return body, w.FormDataContentType()
+}
+
+// formDecoder returns the decoder selected by mux for a request body sent to
+// a unary method.
+func formDecoder(t *testing.T, m *runtime.FormMarshaler, body io.Reader, contentType string) runtime.Decoder {
+	t.Helper()
+	return methodFormDecoder(t, m, getBook, body, contentType)
+}
+
+// methodFormDecoder returns the decoder selected by mux for a request body
+// sent to the handler of "rpcMethod".
+func methodFormDecoder(t *testing.T, m *runtime.FormMarshaler, rpcMethod string, body io.Reader, contentType string) runtime.Decoder {
+	t.Helper()
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEMultipartForm, m),
+		runtime.WithMarshalerOption(runtime.MIMEURLEncodedForm, m),
+	)
+	var dec runtime.Decoder
+	pattern := runtime.MustPattern(runtime.NewPattern(1, []int{int(utilities.OpLitPush), 0}, []string{"upload"}, ""))
+	mux.HandleBinding("POST", pattern, rpcMethod, 0, func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		inbound, _ := runtime.MarshalerForRequest(mux, r)
+		dec = inbound.NewDecoder(r.Body)
+	})
+	r := httptest.NewRequest("POST", "/upload", body)
+	r.Header.Set("Content-Type", contentType)
+	mux.ServeHTTP(httptest.NewRecorder(), r)
+	if dec == nil {
+		t.Fatalf("the handler of %s was not called", rpcMethod)
+	}
+	return dec
+}
+
+func decodeForm(t *testing.T, dec runtime.Decoder) []*examplepb.Proto3Message {
+	t.Helper()
+	var msgs []*examplepb.Proto3Message
+	for {
+		msg := new(examplepb.Proto3Message)
+		err := dec.Decode(msg)
+		if errors.Is(err, io.EOF) {
+			return msgs
+		}
+		if err != nil {
+			t.Fatalf("dec.Decode() failed with %v; want success", err)
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+func TestFormMarshaler_URLEncoded(t *testing.T) {
+	body := strings.NewReader("string_value=hello&nested.int32_value=42&repeated_value=a&repeated_value=b&map_value[k]=v&unknown=x")
+	dec := formDecoder(t, &runtime.FormMarshaler{}, body, "application/x-www-form-urlencoded")
+
+	got := decodeForm(t, dec)
+	want := []*examplepb.Proto3Message{{
+		StringValue:   "hello",
+		Nested:        &examplepb.Proto3Message{Int32Value: 42},
+		RepeatedValue: []string{"a", "b"},
+		MapValue:      map[string]string{"k": "v"},
+	}}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("decoded messages differ (-got, +want):\n%s", diff)
+	}
+}
+
+func TestFormMarshaler_Multipart(t *testing.T) {
+	body, contentType := newMultipartRequest(t, []formPart{
+		{name: "stringValue", value: "hello"},
+		{name: "repeated_value", value: "a"},
+		{name: "repeated_value", value: "b"},
+		{name: "bytes_value", filename: "data.bin", value: "file contents"},
+		{name: "unknown", filename: "ignored.bin", value: "ignored"},
+	})
+	dec := formDecoder(t, &runtime.FormMarshaler{}, body, contentType)
+
+	got := decodeForm(t, dec)
+	want := []*examplepb.Proto3Message{{
+		StringValue:   "hello",
+		RepeatedValue: []string{"a", "b"},
+		BytesValue:    []byte("file contents"),
+	}}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("decoded messages differ (-got, +want):\n%s", diff)
+	}
+}
+
+func TestFormMarshaler_MultipartChunks(t *testing.T) {
+	body, contentType := newMultipartRequest(t, []formPart{
+		{name: "string_value", value: "hello"},
+		{name: "bytes_value", filename: "data.bin", value: "0123456789"},
+		{name: "int32_value", value: "7"},
+	})
+	dec := methodFormDecoder(t, &runtime.FormMarshaler{ChunkSize: 4}, uploadBooks, body, contentType)
+
+	got := decodeForm(t, dec)
+	want := []*examplepb.Proto3Message{
+		{StringValue: "hello", BytesValue: []byte("0123")},
+		{BytesValue: []byte("4567")},
+		{BytesValue: []byte("89"), Int32Value: 7},
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("decoded messages differ (-got, +want):\n%s", diff)
+	}
+}
+
+func TestFormMarshaler_MultipartChunksUnary(t *testing.T) {
+	parts := []formPart{
+		{name: "string_value", value: "hello"},
+		{name: "bytes_value", filename: "data.bin", value: "0123456789"},
+		{name: "int32_value", value: "7"},
+	}
+	body, contentType := newMultipartRequest(t, parts)
+	dec := formDecoder(t, &runtime.FormMarshaler{ChunkSize: 4}, body, contentType)
+
+	got := decodeForm(t, dec)
+	want := []*examplepb.Proto3Message{
+		{StringValue: "hello", BytesValue: []byte("0123456789"), Int32Value: 7},
+	}
+	if diff := cmp.Diff(got, want, protocmp.Transform()); diff != "" {
+		t.Errorf("decoded messages differ (-got, +want):\n%s", diff)
+	}
+
+	body, contentType = newMultipartRequest(t, parts)
+	dec = formDecoder(t, &runtime.FormMarshaler{ChunkSize: 4, MaxFileSize: 8}, body, contentType)
+	if err := dec.Decode(new(examplepb.Proto3Message)); err == nil || !strings.Contains(err.Error(), "exceeds 8 bytes") {
+		t.Errorf("dec.Decode() = %v; want an error for a file exceeding MaxFileSize", err)
+	}
+}
+
+func TestFormMarshaler_Errors(t *testing.T) {
+	for _, spec := range []struct {
+		name   string
+		parts  []formPart
+		m      *runtime.FormMarshaler
+		substr string
+	}{
+		{
+			name:   "file too large",
+			parts:  []formPart{{name: "bytes_value", filename: "data.bin", value: "0123456789"}},
+			m:      &runtime.FormMarshaler{MaxFileSize: 4},
+			substr: "exceeds 4 bytes",
+		},
+		{
+			name:   "file in scalar field",
+			parts:  []formPart{{name: "string_value", filename: "data.bin", value: "data"}},
+			m:      &runtime.FormMarshaler{},
+			substr: "cannot hold a file",
+		},
+		{
+			name:   "invalid value",
+			parts:  []formPart{{name: "int32_value", value: "nan"}},
+			m:      &runtime.FormMarshaler{},
+			substr: "int32_value",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			body, contentType := newMultipartRequest(t, spec.parts)
+			dec := formDecoder(t, spec.m, body, contentType)
+			err := dec.Decode(new(examplepb.Proto3Message))
+			if err == nil || !strings.Contains(err.Error(), spec.substr) {
+				t.Errorf("dec.Decode() = %v; want error containing %q", err, spec.substr)
+			}
+		})
+	}
+}
+
+func TestFormMarshaler_MissingBoundary(t *testing.T) {
+	dec := formDecoder(t, &runtime.FormMarshaler{}, strings.NewReader(""), "multipart/form-data")
+	if err := dec.Decode(new(examplepb.Proto3Message)); err == nil {
+		t.Errorf("dec.Decode() succeeded; want error")
+	}
+}
@@ -37,6 +37,18 @@ func (j *JSONPb) Marshal(v interface{}) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// AppendMarshal appends the JSON encoding of "v" to "b".
+func (j *JSONPb) AppendMarshal(b []byte, v interface{}) ([]byte, error) {
+	if p, ok := v.(proto.Message); ok {
+		return j.MarshalOptions.MarshalAppend(b, p)
+	}
+	buf := bytes.NewBuffer(b)
+	if err := j.marshalTo(buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 func (j *JSONPb) marshalTo(w io.Writer, v interface{}) error {
 	p, ok := v.(proto.Message)
 	if !ok {
@@ -0,0 +1,512 @@
+package runtime
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FastJSONPb is a Marshaler which produces the same JSON as JSONPb, using
+// encoders built once per message type instead of walking each message with
+// "google.golang.org/protobuf/encoding/protojson".
+//
+// The output is equal to that of JSONPb up to insignificant whitespace, which
+// protojson deliberately randomizes. The MarshalOptions UseProtoNames,
+// EmitUnpopulated, EmitDefaultValues, UseEnumNumbers and AllowPartial are
+// supported. Well-known types, messages with extensions, and output with
+// Multiline or Indent set are encoded by JSONPb. Decoding is done by JSONPb.
+type FastJSONPb struct {
+	JSONPb
+}
+
+// Marshal marshals "v" into JSON.
+func (j *FastJSONPb) Marshal(v interface{}) ([]byte, error) {
+	return j.AppendMarshal(nil, v)
+}
+
+// AppendMarshal appends the JSON encoding of "v" to "b".
+func (j *FastJSONPb) AppendMarshal(b []byte, v interface{}) ([]byte, error) {
+	if j.Multiline || j.Indent != "" {
+		return j.JSONPb.AppendMarshal(b, v)
+	}
+	return j.appendValue(b, v, &jsonOutput{MarshalOptions: &j.MarshalOptions})
+}
+
+// NewEncoder returns an Encoder which writes JSON stream into "w". Large
+// values are written as they are encoded, rather than held whole in memory.
+func (j *FastJSONPb) NewEncoder(w io.Writer) Encoder {
+	return EncoderFunc(func(v interface{}) error {
+		return j.writeTo(w, v, j.Delimiter())
+	})
+}
+
+// writeTo writes the JSON encoding of "v" followed by suffix to "w". The
+// encoding is written out whenever it grows beyond fastJSONFlushSize between
+// two elements of a repeated field, so that its buffer stays small.
+func (j *FastJSONPb) writeTo(w io.Writer, v interface{}, suffix []byte) error {
+	if j.Multiline || j.Indent != "" {
+		if err := j.JSONPb.marshalTo(w, v); err != nil {
+			return err
+		}
+		_, err := w.Write(suffix)
+		return err
+	}
+	bp := getBuffer()
+	defer putBuffer(bp)
+	b, err := j.appendValue(*bp, v, &jsonOutput{MarshalOptions: &j.MarshalOptions, w: w})
+	if err != nil {
+		return err
+	}
+	*bp = append(b, suffix...)
+	_, err = w.Write(*bp)
+	return err
+}
+
+// fastJSONFlushSize is the size of the encoded bytes FastJSONPb.writeTo holds
+// before writing them out.
+const fastJSONFlushSize = 32 << 10
+
+// jsonOutput holds the options of an encoding, and the writer its bytes are
+// flushed to, if any.
+type jsonOutput struct {
+	*protojson.MarshalOptions
+	w io.Writer
+	// flushed is set once bytes were written to w, after which the encoding
+	// cannot fall back to protojson.
+	flushed bool
+}
+
+// flush writes b to the writer of o if it grew beyond fastJSONFlushSize, and
+// returns the buffer to append the next bytes to.
+func (o *jsonOutput) flush(b []byte) ([]byte, error) {
+	if o.w == nil || len(b) < fastJSONFlushSize {
+		return b, nil
+	}
+	if _, err := o.w.Write(b); err != nil {
+		return nil, err
+	}
+	o.flushed = true
+	return b[:0], nil
+}
+
+// errFallback is returned by message encoders for values they do not handle,
+// and which are then encoded by JSONPb.
+var errFallback = errors.New("fallback to protojson")
+
+// appendValue appends the JSON encoding of v to b, matching JSONPb.marshalTo.
+func (j *FastJSONPb) appendValue(b []byte, v interface{}, o *jsonOutput) ([]byte, error) {
+	if p, ok := v.(proto.Message); ok {
+		return j.appendProto(b, p, o)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Kind() == reflect.Slice && !rv.IsNil() && rv.Type().Elem().Implements(protoMessageType):
+		b = append(b, '[')
+		for i := 0; i < rv.Len(); i++ {
+			if i != 0 {
+				b = append(b, ',')
+			}
+			var err error
+			if b, err = j.appendProto(b, rv.Index(i).Interface().(proto.Message), o); err != nil {
+				return nil, err
+			}
+			if b, err = o.flush(b); err != nil {
+				return nil, err
+			}
+		}
+		return append(b, ']'), nil
+	case rv.Kind() == reflect.Map:
+		m := make(map[string]*json.RawMessage, rv.Len())
+		for _, k := range rv.MapKeys() {
+			buf, err := j.Marshal(rv.MapIndex(k).Interface())
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprintf("%v", k.Interface())] = (*json.RawMessage)(&buf)
+		}
+		buf, err := json.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		return append(b, buf...), nil
+	}
+
+	buf, err := j.JSONPb.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append(b, buf...), nil
+}
+
+func (j *FastJSONPb) appendProto(b []byte, p proto.Message, o *jsonOutput) ([]byte, error) {
+	if p == nil {
+		return append(b, '{', '}'), nil
+	}
+	if !j.MarshalOptions.AllowPartial {
+		if err := proto.CheckInitialized(p); err != nil {
+			return nil, err
+		}
+	}
+	m := p.ProtoReflect()
+	out, err := messageEncoderFor(m.Descriptor()).append(b, m, o)
+	if errors.Is(err, errFallback) {
+		if o.flushed {
+			// Part of the encoding was written, so only report the error
+			// protojson finds.
+			if _, err := j.MarshalOptions.Marshal(p); err != nil {
+				return nil, err
+			}
+			return nil, errFallback
+		}
+		return j.MarshalOptions.MarshalAppend(b, p)
+	}
+	return out, err
+}
+
+// messageEncoders caches the messageEncoder of each message descriptor.
+var messageEncoders sync.Map
+
+// messageEncoder encodes the messages of a single type.
+type messageEncoder struct {
+	// wellKnown is true for the types with a special JSON mapping.
+	wellKnown bool
+	// extendable is true if the messages may hold extensions.
+	extendable bool
+	fields     []fieldEncoder
+}
+
+// fieldEncoder encodes a field of a message.
+type fieldEncoder struct {
+	fd protoreflect.FieldDescriptor
+	// jsonName and protoName are the quoted names of the field, followed by a colon.
+	jsonName, protoName []byte
+	// inOneof is true for fields which are never emitted when unpopulated.
+	inOneof bool
+	// hasPresence is true for fields emitted as null when unpopulated.
+	hasPresence bool
+}
+
+func messageEncoderFor(md protoreflect.MessageDescriptor) *messageEncoder {
+	if e, ok := messageEncoders.Load(md); ok {
+		return e.(*messageEncoder)
+	}
+	e := &messageEncoder{
+		wellKnown:  md.ParentFile().Package() == "google.protobuf" && isWellKnownType(md.Name()),
+		extendable: md.ExtensionRanges().Len() > 0,
+	}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		e.fields = append(e.fields, fieldEncoder{
+			fd:          fd,
+			jsonName:    appendFieldName(nil, fd.JSONName()),
+			protoName:   appendFieldName(nil, fd.TextName()),
+			inOneof:     fd.ContainingOneof() != nil,
+			hasPresence: fd.HasPresence(),
+		})
+	}
+	actual, _ := messageEncoders.LoadOrStore(md, e)
+	return actual.(*messageEncoder)
+}
+
+func isWellKnownType(name protoreflect.Name) bool {
+	switch name {
+	case "Any", "Timestamp", "Duration", "Struct", "Value", "ListValue", "FieldMask", "Empty",
+		"BoolValue", "Int32Value", "Int64Value", "UInt32Value", "UInt64Value",
+		"FloatValue", "DoubleValue", "StringValue", "BytesValue":
+		return true
+	}
+	return false
+}
+
+func appendFieldName(b []byte, name string) []byte {
+	b, _ = appendJSONString(b, name)
+	return append(b, ':')
+}
+
+func (e *messageEncoder) append(b []byte, m protoreflect.Message, o *jsonOutput) ([]byte, error) {
+	if e.wellKnown || (e.extendable && hasExtensions(m)) {
+		opts := *o.MarshalOptions
+		opts.AllowPartial = true
+		out, err := opts.Marshal(m.Interface())
+		if err != nil {
+			return nil, err
+		}
+		// Drop the whitespace protojson randomly inserts.
+		buf := bytes.NewBuffer(b)
+		if err := json.Compact(buf, out); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	b = append(b, '{')
+	first := true
+	for i := range e.fields {
+		f := &e.fields[i]
+		v, ok := f.value(m, o.MarshalOptions)
+		if !ok {
+			continue
+		}
+
+		if !first {
+			b = append(b, ',')
+		}
+		first = false
+		if o.UseProtoNames {
+			b = append(b, f.protoName...)
+		} else {
+			b = append(b, f.jsonName...)
+		}
+
+		var err error
+		switch {
+		case f.fd.IsList():
+			b, err = appendList(b, v.List(), f.fd, o)
+		case f.fd.IsMap():
+			b, err = appendMap(b, v.Map(), f.fd, o)
+		default:
+			b, err = appendSingular(b, v, f.fd, o)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return append(b, '}'), nil
+}
+
+// value returns the value of the field in m, and whether protojson emits it
+// with the options o. The value is invalid for fields emitted as null.
+func (f *fieldEncoder) value(m protoreflect.Message, o *protojson.MarshalOptions) (protoreflect.Value, bool) {
+	switch {
+	case m.Has(f.fd):
+		return m.Get(f.fd), true
+	case !(o.EmitUnpopulated || o.EmitDefaultValues) || f.inOneof:
+		return protoreflect.Value{}, false
+	case f.hasPresence:
+		return protoreflect.Value{}, o.EmitUnpopulated
+	}
+	return m.Get(f.fd), true
+}
+
+func hasExtensions(m protoreflect.Message) bool {
+	var found bool
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		found = fd.IsExtension()
+		return !found
+	})
+	return found
+}
+
+func appendList(b []byte, list protoreflect.List, fd protoreflect.FieldDescriptor, o *jsonOutput) ([]byte, error) {
+	b = append(b, '[')
+	for i := 0; i < list.Len(); i++ {
+		if i != 0 {
+			b = append(b, ',')
+		}
+		var err error
+		if b, err = appendSingular(b, list.Get(i), fd, o); err != nil {
+			return nil, err
+		}
+		if b, err = o.flush(b); err != nil {
+			return nil, err
+		}
+	}
+	return append(b, ']'), nil
+}
+
+func appendMap(b []byte, mp protoreflect.Map, fd protoreflect.FieldDescriptor, o *jsonOutput) ([]byte, error) {
+	b = append(b, '{')
+	for i, k := range sortedMapKeys(mp, fd) {
+		if i != 0 {
+			b = append(b, ',')
+		}
+		var ok bool
+		if b, ok = appendJSONString(b, k.String()); !ok {
+			return nil, errFallback
+		}
+		b = append(b, ':')
+		var err error
+		if b, err = appendSingular(b, mp.Get(k), fd.MapValue(), o); err != nil {
+			return nil, err
+		}
+	}
+	return append(b, '}'), nil
+}
+
+// sortedMapKeys returns the keys of the map field fd in the order protojson
+// writes them.
+func sortedMapKeys(mp protoreflect.Map, fd protoreflect.FieldDescriptor) []protoreflect.MapKey {
+	keys := make([]protoreflect.MapKey, 0, mp.Len())
+	mp.Range(func(k protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	switch fd.MapKey().Kind() {
+	case protoreflect.BoolKind:
+		slices.SortFunc(keys, func(x, y protoreflect.MapKey) int {
+			return cmp.Compare(boolToInt(x.Bool()), boolToInt(y.Bool()))
+		})
+	case protoreflect.StringKind:
+		slices.SortFunc(keys, func(x, y protoreflect.MapKey) int { return strings.Compare(x.String(), y.String()) })
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		slices.SortFunc(keys, func(x, y protoreflect.MapKey) int { return cmp.Compare(x.Uint(), y.Uint()) })
+	default:
+		slices.SortFunc(keys, func(x, y protoreflect.MapKey) int { return cmp.Compare(x.Int(), y.Int()) })
+	}
+	return keys
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func appendSingular(b []byte, v protoreflect.Value, fd protoreflect.FieldDescriptor, o *jsonOutput) ([]byte, error) {
+	if !v.IsValid() {
+		return append(b, "null"...), nil
+	}
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return strconv.AppendBool(b, v.Bool()), nil
+	case protoreflect.StringKind:
+		b, ok := appendJSONString(b, v.String())
+		if !ok {
+			// Let protojson report the invalid UTF-8.
+			return nil, errFallback
+		}
+		return b, nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return strconv.AppendInt(b, v.Int(), 10), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return strconv.AppendUint(b, v.Uint(), 10), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		b = append(b, '"')
+		b = strconv.AppendInt(b, v.Int(), 10)
+		return append(b, '"'), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		b = append(b, '"')
+		b = strconv.AppendUint(b, v.Uint(), 10)
+		return append(b, '"'), nil
+	case protoreflect.FloatKind:
+		return appendJSONFloat(b, v.Float(), 32), nil
+	case protoreflect.DoubleKind:
+		return appendJSONFloat(b, v.Float(), 64), nil
+	case protoreflect.BytesKind:
+		b = append(b, '"')
+		b = base64.StdEncoding.AppendEncode(b, v.Bytes())
+		return append(b, '"'), nil
+	case protoreflect.EnumKind:
+		if fd.Enum().FullName() == "google.protobuf.NullValue" {
+			return append(b, "null"...), nil
+		}
+		desc := fd.Enum().Values().ByNumber(v.Enum())
+		if o.UseEnumNumbers || desc == nil {
+			return strconv.AppendInt(b, int64(v.Enum()), 10), nil
+		}
+		b = append(b, '"')
+		b = append(b, desc.Name()...)
+		return append(b, '"'), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		m := v.Message()
+		return messageEncoderFor(m.Descriptor()).append(b, m, o)
+	}
+	return nil, fmt.Errorf("%v has unknown kind: %v", fd.FullName(), fd.Kind())
+}
+
+// appendJSONFloat appends n as protojson does, following encoding/json for
+// finite numbers.
+func appendJSONFloat(b []byte, n float64, bitSize int) []byte {
+	switch {
+	case math.IsNaN(n):
+		return append(b, `"NaN"`...)
+	case math.IsInf(n, +1):
+		return append(b, `"Infinity"`...)
+	case math.IsInf(n, -1):
+		return append(b, `"-Infinity"`...)
+	}
+
+	format := byte('f')
+	if abs := math.Abs(n); abs != 0 {
+		if bitSize == 64 && (abs < 1e-6 || abs >= 1e21) ||
+			bitSize == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b = strconv.AppendFloat(b, n, format, -1, bitSize)
+	if format == 'e' {
+		// Clean up e-09 to e-9.
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	return b
+}
+
+// appendJSONString appends s as a quoted JSON string, escaping it as protojson
+// does. It reports false if s is not valid UTF-8.
+func appendJSONString(b []byte, s string) ([]byte, bool) {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			r, n := utf8.DecodeRuneInString(s[i:])
+			if r == utf8.RuneError && n == 1 {
+				return nil, false
+			}
+			i += n
+			continue
+		}
+		if c >= ' ' && c != '"' && c != '\\' {
+			i++
+			continue
+		}
+		b = append(b, s[start:i]...)
+		b = append(b, '\\')
+		switch c {
+		case '"', '\\':
+			b = append(b, c)
+		case '\b':
+			b = append(b, 'b')
+		case '\f':
+			b = append(b, 'f')
+		case '\n':
+			b = append(b, 'n')
+		case '\r':
+			b = append(b, 'r')
+		case '\t':
+			b = append(b, 't')
+		default:
+			b = append(b, 'u')
+			b = append(b, "0000"[1+(bits.Len32(uint32(c))-1)/4:]...)
+			b = strconv.AppendUint(b, uint64(c), 16)
+		}
+		i++
+		start = i
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"'), true
+}
@@ -0,0 +1,281 @@
+package runtime_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func fastJSONPbFixtures(t testing.TB) []interface{} {
+	nested := &examplepb.ABitOfEverything_Nested{Name: "foo", Amount: 12345, Ok: examplepb.ABitOfEverything_Nested_TRUE}
+	anyNested, err := anypb.New(nested)
+	if err != nil {
+		t.Fatalf("anypb.New() failed with %v; want success", err)
+	}
+	structValue, err := structpb.NewStruct(map[string]interface{}{"a": 1.5, "b": []interface{}{"c", true, nil}})
+	if err != nil {
+		t.Fatalf("structpb.NewStruct() failed with %v; want success", err)
+	}
+
+	everything := &examplepb.ABitOfEverything{
+		SingleNested:        nested,
+		Uuid:                "6EC2446F-7E89-4127-B3E6-5C05E6BECBA7",
+		Nested:              []*examplepb.ABitOfEverything_Nested{nested, {}},
+		FloatValue:          1.5e-7,
+		DoubleValue:         -2.5e21,
+		Int64Value:          math.MinInt64,
+		Uint64Value:         math.MaxUint64,
+		Int32Value:          math.MinInt32,
+		Fixed64Value:        1 << 60,
+		Fixed32Value:        math.MaxUint32,
+		BoolValue:           true,
+		StringValue:         "quote \" backslash \\ control \x00\x1f\x7f\b\f\n\r\t html <&> unicode é ",
+		BytesValue:          []byte{0, 1, 2, 0xff},
+		Uint32Value:         42,
+		EnumValue:           examplepb.NumericEnum_ONE,
+		Sfixed32Value:       -1,
+		Sfixed64Value:       -1 << 40,
+		Sint32Value:         -7,
+		Sint64Value:         -7 << 40,
+		RepeatedStringValue: []string{"a", "", "c"},
+		OneofValue:          &examplepb.ABitOfEverything_OneofEmpty{OneofEmpty: &emptypb.Empty{}},
+		MapValue: map[string]examplepb.NumericEnum{
+			"b": examplepb.NumericEnum_ZERO,
+			"a": examplepb.NumericEnum_ONE,
+			"c": examplepb.NumericEnum(7),
+		},
+		MappedStringValue:        map[string]string{"z": "1", "y": "2"},
+		MappedNestedValue:        map[string]*examplepb.ABitOfEverything_Nested{"n": nested},
+		NonConventionalNameValue: "camel",
+		TimestampValue:           timestamppb.New(timestamppb.Now().AsTime().Truncate(1000)),
+		RepeatedEnumValue:        []examplepb.NumericEnum{examplepb.NumericEnum_ONE, examplepb.NumericEnum_ZERO},
+		Anytype:                  anyNested,
+		RepeatedAnytype:          []*anypb.Any{anyNested},
+	}
+
+	proto3 := &examplepb.Proto3Message{
+		Nested:             &examplepb.Proto3Message{StringValue: "nested"},
+		FloatValue:         float32(math.Inf(1)),
+		DoubleValue:        math.NaN(),
+		RepeatedMessage:    []*wrapperspb.UInt64Value{wrapperspb.UInt64(1), {}},
+		OptionalValue:      proto.String(""),
+		DurationValue:      durationpb.New(1500000000),
+		FieldmaskValue:     &fieldmaskpb.FieldMask{Paths: []string{"a.b", "c"}},
+		OneofValue:         &examplepb.Proto3Message_OneofBoolValue{OneofBoolValue: false},
+		NestedOneofValue:   &examplepb.Proto3Message_NestedOneofValueOne{NestedOneofValueOne: &examplepb.Proto3Message{}},
+		WrapperDoubleValue: wrapperspb.Double(math.Inf(-1)),
+		WrapperStringValue: wrapperspb.String("wrapped"),
+		MapValue3:          map[int32]string{-1: "a", 10: "b", 2: "c"},
+		MapValue5:          map[int64]string{math.MaxInt64: "a", -3: "b"},
+		MapValue7:          map[uint32]string{10: "a", 9: "b"},
+		MapValue9:          map[uint64]string{math.MaxUint64: "a", 0: "b"},
+		MapValue10:         map[string]float32{"nan": float32(math.NaN()), "small": 1e-7},
+		MapValue15:         map[bool]string{true: "t", false: "f"},
+		MapValue16:         map[string]*wrapperspb.UInt64Value{"a": wrapperspb.UInt64(3)},
+		StructValueValue:   structpb.NewStringValue("value"),
+		StructValue:        structValue,
+		RepeatedEnum:       []examplepb.EnumValue{examplepb.EnumValue_Y},
+		WrapperBytesValue:  wrapperspb.Bytes([]byte("bytes")),
+		WrapperUInt64Value: wrapperspb.UInt64(math.MaxUint64),
+		WrapperInt64Value:  wrapperspb.Int64(math.MinInt64),
+		TimestampValue:     &timestamppb.Timestamp{Seconds: 1, Nanos: 20},
+	}
+
+	return []interface{}{
+		everything,
+		&examplepb.ABitOfEverything{},
+		proto3,
+		&examplepb.Proto3Message{},
+		&examplepb.Proto2Message{StringValue: proto.String(""), RepeatedValue: []string{"a"}},
+		&examplepb.Proto2Message{},
+		(*examplepb.SimpleMessage)(nil),
+		[]*examplepb.ABitOfEverything_Nested{nested, {}},
+		map[string]proto.Message{"error": nested},
+		map[string]interface{}{"result": everything},
+		"<html>",
+		int64(1),
+		[]string{"a"},
+		nil,
+	}
+}
+
+func jsonPbOptions() []protojson.MarshalOptions {
+	var opts []protojson.MarshalOptions
+	for i := 0; i < 16; i++ {
+		opts = append(opts, protojson.MarshalOptions{
+			UseProtoNames:     i&1 != 0,
+			EmitUnpopulated:   i&2 != 0,
+			EmitDefaultValues: i&4 != 0,
+			UseEnumNumbers:    i&8 != 0,
+		})
+	}
+	return opts
+}
+
+func compactJSON(t *testing.T, b []byte) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, b); err != nil {
+		t.Fatalf("json.Compact(%q) failed with %v; want success", b, err)
+	}
+	return buf.String()
+}
+
+func TestFastJSONPbMarshal(t *testing.T) {
+	fixtures := fastJSONPbFixtures(t)
+	for _, opts := range jsonPbOptions() {
+		for i, v := range fixtures {
+			t.Run(fmt.Sprintf("%+v/%d", opts, i), func(t *testing.T) {
+				want, err := (&runtime.JSONPb{MarshalOptions: opts}).Marshal(v)
+				if err != nil {
+					t.Fatalf("JSONPb.Marshal(%v) failed with %v; want success", v, err)
+				}
+				got, err := (&runtime.FastJSONPb{JSONPb: runtime.JSONPb{MarshalOptions: opts}}).Marshal(v)
+				if err != nil {
+					t.Fatalf("FastJSONPb.Marshal(%v) failed with %v; want success", v, err)
+				}
+				if got, want := string(got), compactJSON(t, want); got != want {
+					t.Errorf("FastJSONPb.Marshal(%v) = %s; want %s", v, got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestFastJSONPbEncoder(t *testing.T) {
+	fixtures := fastJSONPbFixtures(t)
+	var want, got bytes.Buffer
+	enc := (&runtime.JSONPb{}).NewEncoder(&want)
+	fastEnc := (&runtime.FastJSONPb{}).NewEncoder(&got)
+	for _, v := range fixtures {
+		if err := enc.Encode(v); err != nil {
+			t.Fatalf("JSONPb encoder failed with %v; want success", err)
+		}
+		if err := fastEnc.Encode(v); err != nil {
+			t.Fatalf("FastJSONPb encoder failed with %v; want success", err)
+		}
+	}
+
+	wantLines := bytes.Split(want.Bytes(), []byte("\n"))
+	gotLines := bytes.Split(got.Bytes(), []byte("\n"))
+	if len(gotLines) != len(wantLines) {
+		t.Fatalf("FastJSONPb encoder wrote %d lines; want %d", len(gotLines), len(wantLines))
+	}
+	for i := range wantLines {
+		if len(wantLines[i]) == 0 {
+			continue
+		}
+		if got, want := string(gotLines[i]), compactJSON(t, wantLines[i]); got != want {
+			t.Errorf("line %d = %s; want %s", i, got, want)
+		}
+	}
+}
+
+func TestFastJSONPbEncoderLarge(t *testing.T) {
+	// Large enough for the encoder to write it out in several parts.
+	msg := &examplepb.ABitOfEverything{}
+	for i := 0; i < 10000; i++ {
+		msg.Nested = append(msg.Nested, &examplepb.ABitOfEverything_Nested{Name: fmt.Sprintf("item-%d", i), Amount: uint32(i)})
+	}
+	m := &runtime.FastJSONPb{}
+	want, err := m.Marshal(msg)
+	if err != nil {
+		t.Fatalf("FastJSONPb.Marshal() failed with %v; want success", err)
+	}
+	w := &countingWriter{}
+	if err := m.NewEncoder(w).Encode(msg); err != nil {
+		t.Fatalf("FastJSONPb encoder failed with %v; want success", err)
+	}
+	if got, want := w.String(), string(want)+"\n"; got != want {
+		t.Errorf("FastJSONPb encoder wrote %d bytes; want %d", len(got), len(want))
+	}
+	if w.writes < 2 {
+		t.Errorf("FastJSONPb encoder wrote %d times; want several writes", w.writes)
+	}
+
+	msg.Nested[len(msg.Nested)-1].Name = "\xff"
+	_, wantErr := (&runtime.JSONPb{}).Marshal(msg)
+	err = m.NewEncoder(&countingWriter{}).Encode(msg)
+	if err == nil || wantErr == nil || err.Error() != wantErr.Error() {
+		t.Errorf("FastJSONPb encoder failed with %v; want %v", err, wantErr)
+	}
+}
+
+// countingWriter counts the writes to a bytes.Buffer.
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+func TestFastJSONPbMarshalIndent(t *testing.T) {
+	opts := protojson.MarshalOptions{Indent: "  "}
+	msg := &examplepb.SimpleMessage{Id: "foo"}
+	want, err := (&runtime.JSONPb{MarshalOptions: opts}).Marshal(msg)
+	if err != nil {
+		t.Fatalf("JSONPb.Marshal() failed with %v; want success", err)
+	}
+	got, err := (&runtime.FastJSONPb{JSONPb: runtime.JSONPb{MarshalOptions: opts}}).Marshal(msg)
+	if err != nil {
+		t.Fatalf("FastJSONPb.Marshal() failed with %v; want success", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("FastJSONPb.Marshal() = %s; want %s", got, want)
+	}
+}
+
+func TestFastJSONPbMarshalInvalidUTF8(t *testing.T) {
+	msg := &examplepb.SimpleMessage{Id: "\xff"}
+	_, wantErr := (&runtime.JSONPb{}).Marshal(msg)
+	_, err := (&runtime.FastJSONPb{}).Marshal(msg)
+	if err == nil || wantErr == nil || err.Error() != wantErr.Error() {
+		t.Errorf("FastJSONPb.Marshal() failed with %v; want %v", err, wantErr)
+	}
+}
+
+func BenchmarkJSONPbMarshal(b *testing.B) {
+	// A list response, as typically returned by the gateway.
+	msg := &examplepb.ABitOfEverything{
+		MappedStringValue: map[string]string{},
+	}
+	for i := 0; i < 100; i++ {
+		name := fmt.Sprintf("item-%d", i)
+		msg.Nested = append(msg.Nested, &examplepb.ABitOfEverything_Nested{Name: name, Amount: uint32(i), Ok: examplepb.ABitOfEverything_Nested_TRUE})
+		msg.RepeatedStringValue = append(msg.RepeatedStringValue, name)
+		msg.MappedStringValue[name] = name
+	}
+	for _, m := range []struct {
+		name      string
+		marshaler runtime.Marshaler
+	}{
+		{name: "JSONPb", marshaler: &runtime.JSONPb{}},
+		{name: "FastJSONPb", marshaler: &runtime.FastJSONPb{}},
+	} {
+		b.Run(m.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := m.marshaler.Marshal(msg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// MIMEMsgPack is the MIME type of MessagePack bodies.
+const MIMEMsgPack = "application/msgpack"
+
+// MsgPackMarshaler is a Marshaler which marshals/unmarshals into/from
+// MessagePack. Values are mapped as by JSONPb, with the same options: messages
+// are encoded as maps keyed by their protojson field names, and well-known
+// types take their protojson representation. 64-bit integers and bytes fields
+// are however encoded as MessagePack integers and binary values.
+//
+// Streams are encoded as consecutive MessagePack objects, which need no
+// delimiter. The decoder also accepts the protojson representation of 64-bit
+// integers and bytes fields. Extension types are not supported.
+type MsgPackMarshaler struct {
+	protojson.MarshalOptions
+	protojson.UnmarshalOptions
+}
+
+// ContentType always returns "application/msgpack".
+func (*MsgPackMarshaler) ContentType(_ interface{}) string {
+	return MIMEMsgPack
+}
+
+// Marshal marshals "v" into MessagePack.
+func (m *MsgPackMarshaler) Marshal(v interface{}) ([]byte, error) {
+	return m.AppendMarshal(nil, v)
+}
+
+// AppendMarshal appends the MessagePack encoding of "v" to "b".
+func (m *MsgPackMarshaler) AppendMarshal(b []byte, v interface{}) ([]byte, error) {
+	return marshalDataModel(b, m.MarshalOptions, v, msgPackFormat{})
+}
+
+// Unmarshal unmarshals MessagePack "data" into "v".
+func (m *MsgPackMarshaler) Unmarshal(data []byte, v interface{}) error {
+	return m.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// NewDecoder returns a Decoder which reads MessagePack objects from "r".
+func (m *MsgPackMarshaler) NewDecoder(r io.Reader) Decoder {
+	return &dataModelDecoder{r: bufio.NewReader(r), readValue: readMsgPack, opts: m.UnmarshalOptions}
+}
+
+// NewEncoder returns an Encoder which writes MessagePack objects into "w".
+func (m *MsgPackMarshaler) NewEncoder(w io.Writer) Encoder {
+	return EncoderFunc(func(v interface{}) error {
+		buf, err := m.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(buf)
+		return err
+	})
+}
+
+// Delimiter returns an empty delimiter, as MessagePack objects are
+// self-delimiting.
+func (*MsgPackMarshaler) Delimiter() []byte {
+	return []byte{}
+}
+
+// appendMsgPackLength appends the header of a string, array or map of length
+// n. fixPrefix holds lengths up to fixMax; prefix8 is zero for types without
+// an 8-bit length.
+func appendMsgPackLength(b []byte, fixPrefix byte, fixMax int, prefix8, prefix16, prefix32 byte, n int) []byte {
+	switch {
+	case n <= fixMax:
+		return append(b, fixPrefix|byte(n))
+	case prefix8 != 0 && n <= math.MaxUint8:
+		return append(b, prefix8, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, prefix16), uint16(n))
+	}
+	return binary.BigEndian.AppendUint32(append(b, prefix32), uint32(n))
+}
+
+// msgPackFormat appends values in MessagePack.
+type msgPackFormat struct{}
+
+func (msgPackFormat) appendNull(b []byte) []byte {
+	return append(b, 0xc0)
+}
+
+func (msgPackFormat) appendBool(b []byte, v bool) []byte {
+	if v {
+		return append(b, 0xc3)
+	}
+	return append(b, 0xc2)
+}
+
+func (msgPackFormat) appendInt(b []byte, n int64) []byte {
+	switch {
+	case n >= 0:
+		return appendMsgPackUint(b, uint64(n))
+	case n >= -32:
+		return append(b, byte(n))
+	case n >= math.MinInt8:
+		return append(b, 0xd0, byte(n))
+	case n >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(n))
+	case n >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(n))
+}
+
+func (msgPackFormat) appendUint(b []byte, n uint64) []byte {
+	return appendMsgPackUint(b, n)
+}
+
+func (msgPackFormat) appendFloat(b []byte, f float64) []byte {
+	if isFloat32(f) {
+		return binary.BigEndian.AppendUint32(append(b, 0xca), math.Float32bits(float32(f)))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xcb), math.Float64bits(f))
+}
+
+func (msgPackFormat) appendString(b []byte, s string) []byte {
+	b = appendMsgPackLength(b, 0xa0, 31, 0xd9, 0xda, 0xdb, len(s))
+	return append(b, s...)
+}
+
+func (msgPackFormat) appendBytes(b []byte, data []byte) []byte {
+	// bin has no fixed-length form.
+	b = appendMsgPackLength(b, 0, -1, 0xc4, 0xc5, 0xc6, len(data))
+	return append(b, data...)
+}
+
+func (msgPackFormat) appendArrayHeader(b []byte, n int) []byte {
+	return appendMsgPackLength(b, 0x90, 15, 0, 0xdc, 0xdd, n)
+}
+
+func (msgPackFormat) appendMapHeader(b []byte, n int) []byte {
+	return appendMsgPackLength(b, 0x80, 15, 0, 0xde, 0xdf, n)
+}
+
+func appendMsgPackUint(b []byte, n uint64) []byte {
+	switch {
+	case n <= 0x7f:
+		return append(b, byte(n))
+	case n <= math.MaxUint8:
+		return append(b, 0xcc, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xcf), n)
+}
+
+// readMsgPackUint reads a big-endian unsigned integer of size bytes.
+func readMsgPackUint(r *bufio.Reader, size int) (uint64, error) {
+	var buf [8]byte
+	if _, err := io.ReadFull(r, buf[8-size:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(buf[:]), nil
+}
+
+// readMsgPack reads a MessagePack object from r.
+func readMsgPack(r *bufio.Reader, depth int) (interface{}, error) {
+	if depth > maxDataModelDepth {
+		return nil, errors.New("msgpack: exceeded max recursion depth")
+	}
+	b, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case b <= 0x7f:
+		return int64(b), nil
+	case b >= 0xe0:
+		return int64(int8(b)), nil
+	case b <= 0x8f:
+		return readMsgPackMap(r, uint64(b&0x0f), depth)
+	case b <= 0x9f:
+		return readMsgPackArray(r, uint64(b&0x0f), depth)
+	case b <= 0xbf:
+		data, err := readSizedBytes(r, uint64(b&0x1f))
+		return string(data), err
+	}
+
+	switch b {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := readMsgPackUint(r, 1<<(b-0xc4))
+		if err != nil {
+			return nil, err
+		}
+		return readSizedBytes(r, n)
+	case 0xca:
+		n, err := readMsgPackUint(r, 4)
+		return float64(math.Float32frombits(uint32(n))), err
+	case 0xcb:
+		n, err := readMsgPackUint(r, 8)
+		return math.Float64frombits(n), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		n, err := readMsgPackUint(r, 1<<(b-0xcc))
+		if err != nil {
+			return nil, err
+		}
+		if n <= math.MaxInt64 {
+			return int64(n), nil
+		}
+		return n, nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (b - 0xd0)
+		n, err := readMsgPackUint(r, size)
+		if err != nil {
+			return nil, err
+		}
+		// Sign-extend the integer.
+		shift := 64 - 8*size
+		return int64(n<<shift) >> shift, nil
+	case 0xd9, 0xda, 0xdb:
+		n, err := readMsgPackUint(r, 1<<(b-0xd9))
+		if err != nil {
+			return nil, err
+		}
+		data, err := readSizedBytes(r, n)
+		return string(data), err
+	case 0xdc, 0xdd:
+		n, err := readMsgPackUint(r, 2<<(b-0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgPackArray(r, n, depth)
+	case 0xde, 0xdf:
+		n, err := readMsgPackUint(r, 2<<(b-0xde))
+		if err != nil {
+			return nil, err
+		}
+		return readMsgPackMap(r, n, depth)
+	}
+	return nil, fmt.Errorf("msgpack: unsupported type 0x%02x", b)
+}
+
+func readMsgPackArray(r *bufio.Reader, n uint64, depth int) (interface{}, error) {
+	list := make([]interface{}, 0, dataModelCap(n))
+	for i := uint64(0); i < n; i++ {
+		elem, err := readMsgPack(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, elem)
+	}
+	return list, nil
+}
+
+func readMsgPackMap(r *bufio.Reader, n uint64, depth int) (interface{}, error) {
+	obj := make(jsonObject, 0, dataModelCap(n))
+	for i := uint64(0); i < n; i++ {
+		key, err := readMsgPack(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		name, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("msgpack: map key of type %T is not a string", key)
+		}
+		value, err := readMsgPack(r, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		obj = append(obj, jsonMember{key: name, value: value})
+	}
+	return obj, nil
+}
@@ -0,0 +1,114 @@
+package runtime_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestMsgPackMarshaler(t *testing.T) {
+	m := &runtime.MsgPackMarshaler{}
+	msg := &examplepb.Proto3Message{
+		Int32Value:    -200,
+		StringValue:   "foo",
+		RepeatedValue: []string{"a"},
+		FloatValue:    1.5,
+	}
+	got, err := m.Marshal(msg)
+	if err != nil {
+		t.Fatalf("m.Marshal(%v) failed with %v; want success", msg, err)
+	}
+	want := []byte{
+		// map(4)
+		0x84,
+		0xaa, 'f', 'l', 'o', 'a', 't', 'V', 'a', 'l', 'u', 'e', 0xca, 0x3f, 0xc0, 0, 0, // "floatValue": 1.5
+		0xaa, 'i', 'n', 't', '3', '2', 'V', 'a', 'l', 'u', 'e', 0xd1, 0xff, 0x38, // "int32Value": -200
+		0xab, 's', 't', 'r', 'i', 'n', 'g', 'V', 'a', 'l', 'u', 'e', 0xa3, 'f', 'o', 'o', // "stringValue": "foo"
+		0xad, 'r', 'e', 'p', 'e', 'a', 't', 'e', 'd', 'V', 'a', 'l', 'u', 'e', 0x91, 0xa1, 'a', // "repeatedValue": ["a"]
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("m.Marshal(%v) = %x; want %x", msg, got, want)
+	}
+}
+
+func TestMsgPackMarshaler_IntegersAndBytes(t *testing.T) {
+	m := &runtime.MsgPackMarshaler{}
+	msg := &examplepb.Proto3Message{
+		Uint64Value:       1 << 40,
+		BytesValue:        []byte{1, 2},
+		WrapperInt64Value: wrapperspb.Int64(-1),
+		WrapperBytesValue: wrapperspb.Bytes([]byte{3}),
+	}
+	got, err := m.Marshal(msg)
+	if err != nil {
+		t.Fatalf("m.Marshal(%v) failed with %v; want success", msg, err)
+	}
+	want := []byte{
+		// map(4)
+		0x84,
+		0xab, 'u', 'i', 'n', 't', '6', '4', 'V', 'a', 'l', 'u', 'e', 0xcf, 0, 0, 1, 0, 0, 0, 0, 0, // "uint64Value": 2^40
+		0xaa, 'b', 'y', 't', 'e', 's', 'V', 'a', 'l', 'u', 'e', 0xc4, 2, 1, 2, // "bytesValue": bin(01 02)
+		0xb1, 'w', 'r', 'a', 'p', 'p', 'e', 'r', 'I', 'n', 't', '6', '4', 'V', 'a', 'l', 'u', 'e', 0xff, // "wrapperInt64Value": -1
+		0xb1, 'w', 'r', 'a', 'p', 'p', 'e', 'r', 'B', 'y', 't', 'e', 's', 'V', 'a', 'l', 'u', 'e', 0xc4, 1, 3, // "wrapperBytesValue": bin(03)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("m.Marshal(%v) = %x; want %x", msg, got, want)
+	}
+}
+
+func TestMsgPackMarshaler_NativeTypes(t *testing.T) {
+	m := &runtime.MsgPackMarshaler{}
+	data := []byte{
+		// map(3)
+		0x83,
+		0xaa, 'i', 'n', 't', '6', '4', 'V', 'a', 'l', 'u', 'e', 0xd3, 0x80, 0, 0, 0, 0, 0, 0, 0, // "int64Value": -2^63
+		0xab, 'u', 'i', 'n', 't', '6', '4', 'V', 'a', 'l', 'u', 'e', 0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, // "uint64Value": 2^64-1
+		0xaa, 'b', 'y', 't', 'e', 's', 'V', 'a', 'l', 'u', 'e', 0xc4, 2, 1, 2, // "bytesValue": bin(01 02)
+	}
+	var got examplepb.Proto3Message
+	if err := m.Unmarshal(data, &got); err != nil {
+		t.Fatalf("m.Unmarshal(%x) failed with %v; want success", data, err)
+	}
+	want := &examplepb.Proto3Message{
+		Int64Value:  -1 << 63,
+		Uint64Value: 1<<64 - 1,
+		BytesValue:  []byte{1, 2},
+	}
+	if !proto.Equal(&got, want) {
+		t.Errorf("m.Unmarshal(%x) = %v; want %v", data, &got, want)
+	}
+}
+
+func TestMsgPackMarshaler_RoundTrip(t *testing.T) {
+	testDataModelRoundTrip(t, &runtime.MsgPackMarshaler{})
+}
+
+func TestMsgPackMarshaler_Stream(t *testing.T) {
+	testDataModelStream(t, &runtime.MsgPackMarshaler{})
+}
+
+func TestMsgPackMarshaler_Errors(t *testing.T) {
+	for _, spec := range []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{name: "truncated", data: []byte{0x81, 0xa2, 'i', 'd', 0xa3, 'f'}, want: io.ErrUnexpectedEOF},
+		{name: "integer key", data: []byte{0x81, 0x01, 0x01}},
+		{name: "extension", data: []byte{0xd4, 0x01, 0x00}},
+		{name: "invalid UTF-8", data: []byte{0x81, 0xa2, 'i', 'd', 0xa1, 0xff}},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			err := (&runtime.MsgPackMarshaler{}).Unmarshal(spec.data, new(examplepb.SimpleMessage))
+			if err == nil || spec.want != nil && !errors.Is(err, spec.want) {
+				t.Errorf("Unmarshal(%x) failed with %v; want %v", spec.data, err, spec.want)
+			}
+		})
+	}
+}
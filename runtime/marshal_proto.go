@@ -1,14 +1,95 @@
 package runtime
 
 import (
+	"bufio"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
 
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 )
 
+const (
+	// MIMEProtobuf is the MIME type of protobuf bodies.
+	MIMEProtobuf = "application/x-protobuf"
+	// MIMEProtobufDelimited is the MIME type of streams of length-prefixed
+	// protobuf messages, as read and written by ProtoMarshaller.
+	MIMEProtobufDelimited = MIMEProtobuf + "; delimited=true"
+
+	// maxDelimitedMessageSize bounds the size of a message of a length-prefixed
+	// request stream.
+	maxDelimitedMessageSize = 64 << 20
+)
+
 // ProtoMarshaller is a Marshaller which marshals/unmarshals into/from serialize proto bytes
-type ProtoMarshaller struct{}
+//
+// Response streams are sent as length-prefixed messages, each a varint holding
+// the size of the message followed by the message, with the Content-Type
+// "application/x-protobuf; delimited=true". Each message has the wire format
+// of the following message, where Response is the response message of the
+// method:
+//
+//	message StreamResult {
+//	  Response result = 1;
+//	  google.rpc.Status error = 2;
+//	}
+//
+// An error ends the stream. Clients may read the stream with the
+// parseDelimitedFrom method of the Java runtime, or its equivalent in other
+// languages.
+//
+// A request whose Content-Type is "application/x-protobuf; delimited=true"
+// is read as a stream of length-prefixed request messages, without the
+// StreamResult envelope. Register ProtoMarshaller for "application/x-protobuf"
+// to accept such requests.
+type ProtoMarshaller struct {
+	// delimited is set when decoding a request of length-prefixed messages.
+	delimited bool
+}
+
+// bindContentType returns a ProtoMarshaller decoding length-prefixed messages
+// if the delimited parameter of the Content-Type is set.
+func (marshaller *ProtoMarshaller) bindContentType(_ string, params map[string]string) Marshaler {
+	if params["delimited"] != "true" {
+		return marshaller
+	}
+	return &ProtoMarshaller{delimited: true}
+}
+
+// StreamContentType always returns "application/x-protobuf; delimited=true".
+func (*ProtoMarshaller) StreamContentType(_ interface{}) string {
+	return MIMEProtobufDelimited
+}
+
+// MarshalStreamResult returns a response message of a stream, prefixed with
+// its length.
+func (*ProtoMarshaller) MarshalStreamResult(value interface{}) ([]byte, error) {
+	message, ok := value.(proto.Message)
+	if !ok {
+		return nil, errors.New("unable to marshal non proto field")
+	}
+	return appendStreamChunk(nil, 1, message)
+}
+
+// MarshalStreamError returns the google.rpc.Status ending a stream, prefixed
+// with its length.
+func (*ProtoMarshaller) MarshalStreamError(st *status.Status) ([]byte, error) {
+	return appendStreamChunk(nil, 2, st.Proto())
+}
+
+// appendStreamChunk appends a StreamResult holding message in the field num,
+// prefixed with its length, to b.
+func appendStreamChunk(b []byte, num protowire.Number, message proto.Message) ([]byte, error) {
+	size := proto.Size(message)
+	chunkSize := protowire.SizeTag(num) + protowire.SizeBytes(size)
+	b = protowire.AppendVarint(b, uint64(chunkSize))
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	b = protowire.AppendVarint(b, uint64(size))
+	return proto.MarshalOptions{UseCachedSize: true}.MarshalAppend(b, message)
+}
 
 // ContentType always returns "application/octet-stream".
 func (*ProtoMarshaller) ContentType(_ interface{}) string {
@@ -24,6 +105,15 @@ func (*ProtoMarshaller) Marshal(value interface{}) ([]byte, error) {
 	return proto.Marshal(message)
 }
 
+// AppendMarshal appends the Proto encoding of "value" to "b".
+func (*ProtoMarshaller) AppendMarshal(b []byte, value interface{}) ([]byte, error) {
+	message, ok := value.(proto.Message)
+	if !ok {
+		return nil, errors.New("unable to marshal non proto field")
+	}
+	return proto.MarshalOptions{}.MarshalAppend(b, message)
+}
+
 // Unmarshal unmarshals proto "data" into "value"
 func (*ProtoMarshaller) Unmarshal(data []byte, value interface{}) error {
 	message, ok := value.(proto.Message)
@@ -34,8 +124,21 @@ func (*ProtoMarshaller) Unmarshal(data []byte, value interface{}) error {
 }
 
 // NewDecoder returns a Decoder which reads proto stream from "reader".
+// Unless the request is a stream of length-prefixed messages, the whole of
+// "reader" is read as one message, and io.EOF is returned afterwards.
 func (marshaller *ProtoMarshaller) NewDecoder(reader io.Reader) Decoder {
+	if marshaller.delimited {
+		r := bufio.NewReader(reader)
+		return DecoderFunc(func(value interface{}) error {
+			return marshaller.decodeDelimited(r, value)
+		})
+	}
+	var done bool
 	return DecoderFunc(func(value interface{}) error {
+		if done {
+			return io.EOF
+		}
+		done = true
 		buffer, err := io.ReadAll(reader)
 		if err != nil {
 			return err
@@ -44,6 +147,25 @@ func (marshaller *ProtoMarshaller) NewDecoder(reader io.Reader) Decoder {
 	})
 }
 
+// decodeDelimited reads a length-prefixed message from r into value.
+func (marshaller *ProtoMarshaller) decodeDelimited(r *bufio.Reader, value interface{}) error {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return err
+	}
+	if size > maxDelimitedMessageSize {
+		return fmt.Errorf("message of %d bytes exceeds %d bytes", size, maxDelimitedMessageSize)
+	}
+	buffer, err := readSizedBytes(r, size)
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+	return marshaller.Unmarshal(buffer, value)
+}
+
 // NewEncoder returns an Encoder which writes proto stream into "writer".
 func (marshaller *ProtoMarshaller) NewEncoder(writer io.Writer) Encoder {
 	return EncoderFunc(func(value interface{}) error {
@@ -2,10 +2,15 @@ package runtime_test
 
 import (
 	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"slices"
 	"testing"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -89,3 +94,65 @@ func TestProtoEncoderDecodert(t *testing.T) {
 		)
 	}
 }
+
+func TestProtoDecoderDelimited(t *testing.T) {
+	want := []string{"foo", "", "bar"}
+	var body []byte
+	for _, id := range want {
+		b, err := proto.Marshal(&examplepb.SimpleMessage{Id: id})
+		if err != nil {
+			t.Fatalf("proto.Marshal() failed with %v; want success", err)
+		}
+		body = protowire.AppendVarint(body, uint64(len(b)))
+		body = append(body, b...)
+	}
+
+	mux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEProtobuf, &runtime.ProtoMarshaller{}))
+	newDecoder := func(body []byte) runtime.Decoder {
+		r := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+		r.Header.Set("Content-Type", runtime.MIMEProtobufDelimited)
+		inbound, _ := runtime.MarshalerForRequest(mux, r)
+		return inbound.NewDecoder(r.Body)
+	}
+
+	dec := newDecoder(body)
+	var got []string
+	for {
+		var msg examplepb.SimpleMessage
+		err := dec.Decode(&msg)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("dec.Decode() failed with %v; want success", err)
+		}
+		got = append(got, msg.Id)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("decoded ids = %q; want %q", got, want)
+	}
+
+	dec = newDecoder(body[:len(body)-1])
+	var err error
+	for err == nil {
+		err = dec.Decode(new(examplepb.SimpleMessage))
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("dec.Decode() of a truncated stream failed with %v; want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestProtoDecoderSingleMessage(t *testing.T) {
+	marshaller := runtime.ProtoMarshaller{}
+	b, err := marshaller.Marshal(message)
+	if err != nil {
+		t.Fatalf("marshaller.Marshal() failed with %v; want success", err)
+	}
+	dec := marshaller.NewDecoder(bytes.NewReader(b))
+	if err := dec.Decode(new(examplepb.ABitOfEverything)); err != nil {
+		t.Fatalf("dec.Decode() failed with %v; want success", err)
+	}
+	if err := dec.Decode(new(examplepb.ABitOfEverything)); !errors.Is(err, io.EOF) {
+		t.Errorf("second dec.Decode() failed with %v; want %v", err, io.EOF)
+	}
+}
@@ -2,6 +2,9 @@ package runtime
 
 import (
 	"io"
+	"sync"
+
+	"google.golang.org/grpc/status"
 )
 
 // Marshaler defines a conversion between byte sequence and gRPC payloads / fields.
@@ -49,6 +52,15 @@ type Delimited interface {
 	Delimiter() []byte
 }
 
+// AppendMarshaler is implemented by Marshalers which can append the encoding
+// of a value to an existing buffer. ForwardResponseMessage uses it to encode
+// responses into pooled buffers rather than allocating one per response.
+type AppendMarshaler interface {
+	// AppendMarshal appends the encoding of "v" to "b", and returns the
+	// extended buffer.
+	AppendMarshal(b []byte, v interface{}) ([]byte, error)
+}
+
 // StreamContentType defines the streaming content type.
 type StreamContentType interface {
 	// StreamContentType returns the content type for a stream. This shares the
@@ -56,3 +68,38 @@ type StreamContentType interface {
 	// in the case of a streamed response.
 	StreamContentType(v interface{}) string
 }
+
+// StreamMarshaler is implemented by Marshalers which frame the messages of a
+// response stream themselves. ForwardResponseStream then writes the output of
+// its methods as is, instead of the chunks {"result": ...} and {"error": ...}
+// followed by a delimiter.
+type StreamMarshaler interface {
+	// MarshalStreamResult returns the frame of a response message, or of the
+	// response body field of the message.
+	MarshalStreamResult(v interface{}) ([]byte, error)
+	// MarshalStreamError returns the frame of the error ending the stream.
+	MarshalStreamError(st *status.Status) ([]byte, error)
+}
+
+// maxPooledBufferSize is the capacity beyond which buffers are not returned to
+// bufferPool, so that a few large responses do not pin memory.
+const maxPooledBufferSize = 1 << 20
+
+var bufferPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 1024)
+		return &b
+	},
+}
+
+func getBuffer() *[]byte {
+	return bufferPool.Get().(*[]byte)
+}
+
+func putBuffer(bp *[]byte) {
+	if cap(*bp) > maxPooledBufferSize {
+		return
+	}
+	*bp = (*bp)[:0]
+	bufferPool.Put(bp)
+}
@@ -1,9 +1,13 @@
 package runtime
 
 import (
+	"context"
 	"errors"
 	"mime"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -34,40 +38,252 @@ var (
 // If it isn't set (or the request Content-Type is empty), checks for "*".
 // If there are multiple Content-Type headers set, choose the first one that it can
 // exactly match in the registry.
-// Otherwise, it follows the above logic for "*"/InboundMarshaler/OutboundMarshaler.
+//
+// The outbound marshaler is negotiated from the Accept header as described in RFC 9110:
+// media ranges are weighted by their "q" parameter, may use "type/*" and "*/*" wildcards,
+// and their other parameters, such as "charset", are ignored. Among the registered MIME
+// types, and the content type of the marshaler registered for MIMEWildcard, the one with
+// the highest weight wins, ties going to the more specific range and
+// then to the range listed first. If no registered MIME type is acceptable, or only "*/*"
+// matches, the outbound marshaler is the inbound one. See WithStrictContentNegotiation
+// for rejecting such requests instead. The parameters of the winning range are only
+// used by ProtoMarshaller, which sends length-prefixed streams for "delimited=true".
 func MarshalerForRequest(mux *ServeMux, r *http.Request) (inbound Marshaler, outbound Marshaler) {
-	for _, acceptVal := range r.Header[acceptHeader] {
-		if m, ok := mux.marshalers.mimeMap[acceptVal]; ok {
-			outbound = m
-			break
-		}
+	n, ok := negotiationFromContext(r.Context())
+	if !ok {
+		n = mux.negotiate(r)
 	}
+	inbound, outbound = n.inbound, n.outbound
+	if f, ok := inbound.(*FormMarshaler); ok && f.ChunkSize > 0 && isClientStreaming(r.Context()) {
+		inbound = f.chunked()
+	}
+
+	return inbound, outbound
+}
+
+// WithStrictContentNegotiation returns a ServeMuxOption which rejects requests whose
+// Content-Type or Accept headers do not match any registered marshaler, instead of
+// falling back to the marshaler registered for MIMEWildcard.
+//
+// The content type of the marshaler registered for MIMEWildcard, application/json by
+// default, counts as registered. A request with any other Content-Type which is not
+// registered is rejected with http.StatusUnsupportedMediaType, and a request whose
+// Accept header does not accept any registered MIME type with
+// http.StatusNotAcceptable. Both are reported to the
+// routing error handler.
+func WithStrictContentNegotiation() ServeMuxOption {
+	return func(mux *ServeMux) {
+		mux.strictContentNegotiation = true
+	}
+}
+
+// NegotiatedMediaType returns the media type of the response negotiated for the request,
+// if one was negotiated. If the response uses the marshaler registered for MIMEWildcard,
+// this is the content type reported by that marshaler.
+func NegotiatedMediaType(ctx context.Context) (string, bool) {
+	n, ok := negotiationFromContext(ctx)
+	if !ok || n.mediaType == "" {
+		return "", false
+	}
+	return n.mediaType, true
+}
+
+type negotiationKey struct{}
+
+// negotiation is the outcome of content negotiation for a request.
+type negotiation struct {
+	inbound   Marshaler
+	outbound  Marshaler
+	mediaType string
+	// httpStatus is http.StatusUnsupportedMediaType or http.StatusNotAcceptable
+	// if negotiation failed, and zero otherwise.
+	httpStatus int
+}
+
+func withNegotiation(ctx context.Context, n negotiation) context.Context {
+	return context.WithValue(ctx, negotiationKey{}, n)
+}
+
+func negotiationFromContext(ctx context.Context) (negotiation, bool) {
+	n, ok := ctx.Value(negotiationKey{}).(negotiation)
+	return n, ok
+}
 
-	for _, contentTypeVal := range r.Header[contentTypeHeader] {
-		contentType, _, err := mime.ParseMediaType(contentTypeVal)
+// negotiate selects the inbound and outbound marshalers of r.
+func (s *ServeMux) negotiate(r *http.Request) negotiation {
+	var n negotiation
+
+	contentTypes := r.Header[contentTypeHeader]
+	for _, contentTypeVal := range contentTypes {
+		contentType, params, err := mime.ParseMediaType(contentTypeVal)
 		if err != nil {
 			grpclog.Errorf("Failed to parse Content-Type %s: %v", contentTypeVal, err)
 			continue
 		}
-		if m, ok := mux.marshalers.mimeMap[contentType]; ok {
-			inbound = m
+		m, ok := s.marshalers.mimeMap[contentType]
+		if !ok && contentType == s.marshalers.wildcardMediaType {
+			m, ok = s.marshalers.mimeMap[MIMEWildcard]
+		}
+		if ok {
+			if b, ok := m.(contentTypeBinder); ok {
+				m = b.bindContentType(contentType, params)
+			}
+			n.inbound = m
 			break
 		}
 	}
+	if n.inbound == nil {
+		if len(contentTypes) > 0 {
+			n.httpStatus = http.StatusUnsupportedMediaType
+		}
+		n.inbound = s.marshalers.mimeMap[MIMEWildcard]
+	}
 
-	if inbound == nil {
-		inbound = mux.marshalers.mimeMap[MIMEWildcard]
+	if accept := r.Header[acceptHeader]; len(accept) > 0 {
+		if mt, params, ok := s.marshalers.negotiate(parseAccept(accept)); ok {
+			if mt.mimeType != "" {
+				n.outbound = s.marshalers.mimeMap[mt.mimeType]
+				n.mediaType = mt.mediaType
+				if b, ok := n.outbound.(contentTypeBinder); ok && len(params) > 0 {
+					n.outbound = b.bindContentType(mt.mediaType, params)
+				}
+			}
+		} else if n.httpStatus == 0 {
+			n.httpStatus = http.StatusNotAcceptable
+		}
 	}
-	if outbound == nil {
-		outbound = inbound
+	if n.outbound == nil {
+		n.outbound = n.inbound
+		n.mediaType = n.outbound.ContentType(nil)
 	}
+	return n
+}
 
-	return inbound, outbound
+// contentTypeBinder is implemented by marshalers which need the parameters of
+// the Content-Type of a request, such as the boundary of a multipart body. It is
+// also given the parameters of the Accept media range selecting the marshaler
+// for the response, such as "delimited=true".
+type contentTypeBinder interface {
+	bindContentType(mediaType string, params map[string]string) Marshaler
+}
+
+// mediaRange is an element of an Accept header.
+type mediaRange struct {
+	typ, subtype string
+	q            float64
+	// params are the parameters of the range other than "q".
+	params map[string]string
+	// index is the position of the range in the header.
+	index int
+}
+
+// specificity ranks how precisely r designates a media type.
+func (r mediaRange) specificity() int {
+	switch {
+	case r.typ == "*":
+		return 0
+	case r.subtype == "*":
+		return 1
+	}
+	return 2
+}
+
+func (r mediaRange) matches(typ, subtype string) bool {
+	return (r.typ == "*" || r.typ == typ) && (r.subtype == "*" || r.subtype == subtype)
+}
+
+// parseAccept parses the values of an Accept header. Malformed ranges are skipped.
+func parseAccept(values []string) []mediaRange {
+	var ranges []mediaRange
+	for _, value := range values {
+		for _, elem := range strings.Split(value, ",") {
+			elem = strings.TrimSpace(elem)
+			if elem == "" {
+				continue
+			}
+			mediaType, params, err := mime.ParseMediaType(elem)
+			if err != nil {
+				grpclog.Errorf("Failed to parse Accept %s: %v", elem, err)
+				continue
+			}
+			typ, subtype, ok := strings.Cut(mediaType, "/")
+			if !ok || (typ == "*" && subtype != "*") {
+				continue
+			}
+			q := 1.0
+			if qv, ok := params["q"]; ok {
+				if q, err = strconv.ParseFloat(qv, 64); err != nil || q < 0 || q > 1 {
+					continue
+				}
+				delete(params, "q")
+			}
+			ranges = append(ranges, mediaRange{typ: typ, subtype: subtype, q: q, params: params, index: len(ranges)})
+		}
+	}
+	return ranges
+}
+
+// negotiate returns the registered media type best matching ranges, the parameters of
+// the matching range, and whether any registered media type is acceptable at all. The
+// returned media type is the zero value if only "*/*" matched, in which case the default
+// marshaler should be used.
+func (m marshalerRegistry) negotiate(ranges []mediaRange) (registeredMediaType, map[string]string, bool) {
+	var (
+		best       registeredMediaType
+		bestRange  mediaRange
+		found      bool
+		acceptable bool
+	)
+	for _, r := range ranges {
+		if r.typ == "*" && r.q > 0 {
+			acceptable = true
+		}
+	}
+	for _, mt := range m.mediaTypes {
+		// The most specific matching range determines the weight of a type.
+		var match mediaRange
+		matched := false
+		for _, r := range ranges {
+			if !r.matches(mt.typ, mt.subtype) {
+				continue
+			}
+			if !matched || r.specificity() > match.specificity() {
+				match, matched = r, true
+			}
+		}
+		if !matched || match.q == 0 {
+			continue
+		}
+		acceptable = true
+		if match.specificity() == 0 {
+			continue
+		}
+		if !found || match.q > bestRange.q ||
+			(match.q == bestRange.q && match.specificity() > bestRange.specificity()) ||
+			(match.q == bestRange.q && match.specificity() == bestRange.specificity() && match.index < bestRange.index) {
+			best, bestRange, found = mt, match, true
+		}
+	}
+	return best, bestRange.params, acceptable
+}
+
+// registeredMediaType is a media type produced by a registered marshaler, parsed
+// for content negotiation.
+type registeredMediaType struct {
+	// mimeType is the key of the marshaler in the registry, and mediaType the
+	// media type it produces.
+	mimeType, mediaType string
+	typ, subtype        string
 }
 
 // marshalerRegistry is a mapping from MIME types to Marshalers.
 type marshalerRegistry struct {
 	mimeMap map[string]Marshaler
+	// mediaTypes are the media types produced by the registered marshalers,
+	// and wildcardMediaType the one of the marshaler registered for
+	// MIMEWildcard. They are set by compile.
+	mediaTypes        []registeredMediaType
+	wildcardMediaType string
 }
 
 // add adds a marshaler for a case-sensitive MIME type string ("*" to match any
@@ -82,6 +298,53 @@ func (m marshalerRegistry) add(mime string, marshaler Marshaler) error {
 	return nil
 }
 
+// compile parses the registered MIME types for content negotiation. The media type
+// of the marshaler registered for MIMEWildcard counts as registered, unless another
+// marshaler is registered for it.
+func (m *marshalerRegistry) compile() {
+	mimeTypes := make([]string, 0, len(m.mimeMap))
+	for mimeType := range m.mimeMap {
+		if mimeType != MIMEWildcard {
+			mimeTypes = append(mimeTypes, mimeType)
+		}
+	}
+	// Sort for a deterministic choice between equally acceptable types.
+	sort.Strings(mimeTypes)
+
+	m.mediaTypes = nil
+	registered := make(map[string]bool, len(mimeTypes))
+	for _, mimeType := range mimeTypes {
+		if mt, ok := parseRegisteredMediaType(mimeType, mimeType); ok {
+			m.mediaTypes = append(m.mediaTypes, mt)
+			registered[mt.typ+"/"+mt.subtype] = true
+		}
+	}
+	m.wildcardMediaType = ""
+	if wildcard, ok := m.mimeMap[MIMEWildcard]; ok {
+		if mt, ok := parseRegisteredMediaType(MIMEWildcard, wildcard.ContentType(nil)); ok {
+			m.wildcardMediaType = mt.mediaType
+			if !registered[mt.mediaType] {
+				m.mediaTypes = append(m.mediaTypes, mt)
+			}
+		}
+	}
+}
+
+func parseRegisteredMediaType(mimeType, contentType string) (registeredMediaType, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return registeredMediaType{}, false
+	}
+	typ, subtype, ok := strings.Cut(mediaType, "/")
+	if !ok {
+		return registeredMediaType{}, false
+	}
+	if mimeType == MIMEWildcard {
+		contentType = mediaType
+	}
+	return registeredMediaType{mimeType: mimeType, mediaType: contentType, typ: typ, subtype: subtype}, true
+}
+
 // makeMarshalerMIMERegistry returns a new registry of marshalers.
 // It allows for a mapping of case-sensitive Content-Type MIME type string to runtime.Marshaler interfaces.
 //
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -93,6 +94,189 @@ func TestMarshalerForRequest(t *testing.T) {
 	}
 }
 
+func TestMarshalerForRequest_Negotiation(t *testing.T) {
+	marshalers := []dummyMarshaler{0, 1, 2, 3}
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &marshalers[0]),
+		runtime.WithMarshalerOption("application/json", &marshalers[1]),
+		runtime.WithMarshalerOption("application/x-protobuf", &marshalers[2]),
+		runtime.WithMarshalerOption("text/csv", &marshalers[3]),
+	)
+	for _, spec := range []struct {
+		accept  []string
+		wantOut runtime.Marshaler
+	}{
+		{
+			accept:  []string{"application/x-protobuf"},
+			wantOut: &marshalers[2],
+		},
+		{
+			accept:  []string{"application/json; charset=utf-8"},
+			wantOut: &marshalers[1],
+		},
+		{
+			accept:  []string{"application/json;q=0.5, application/x-protobuf"},
+			wantOut: &marshalers[2],
+		},
+		{
+			accept:  []string{"application/json;q=0.5", "application/x-protobuf;q=0.9"},
+			wantOut: &marshalers[2],
+		},
+		{
+			accept:  []string{"text/*, application/json;q=0.8"},
+			wantOut: &marshalers[3],
+		},
+		{
+			// The more specific range wins between equally weighted ones.
+			accept:  []string{"application/*, application/x-protobuf"},
+			wantOut: &marshalers[2],
+		},
+		{
+			// The first listed range wins between equally weighted ones.
+			accept:  []string{"text/csv, application/json"},
+			wantOut: &marshalers[3],
+		},
+		{
+			accept:  []string{"application/*, application/json;q=0"},
+			wantOut: &marshalers[2],
+		},
+		{
+			accept:  []string{"text/html, */*;q=0.8"},
+			wantOut: &marshalers[0],
+		},
+		{
+			accept:  []string{"image/png"},
+			wantOut: &marshalers[0],
+		},
+	} {
+		t.Run(fmt.Sprint(spec.accept), func(t *testing.T) {
+			r, err := http.NewRequestWithContext(context.Background(), "GET", "http://example.com", nil)
+			if err != nil {
+				t.Fatalf(`http.NewRequest("GET", "http://example.com", nil) failed with %v; want success`, err)
+			}
+			for _, accept := range spec.accept {
+				r.Header.Add("Accept", accept)
+			}
+			in, out := runtime.MarshalerForRequest(mux, r)
+			if got, want := in, &marshalers[0]; got != want {
+				t.Errorf("in = %#v; want %#v", got, want)
+			}
+			if got, want := out, spec.wantOut; got != want {
+				t.Errorf("out = %#v; want %#v", got, want)
+			}
+		})
+	}
+}
+
+func TestServeMux_StrictContentNegotiation(t *testing.T) {
+	for _, spec := range []struct {
+		name          string
+		strict        bool
+		defaultOnly   bool
+		contentType   string
+		accept        string
+		wantStatus    int
+		wantMediaType string
+	}{
+		{
+			name:          "negotiated",
+			strict:        true,
+			contentType:   "application/json",
+			accept:        "application/*;q=0.5, application/x-protobuf",
+			wantStatus:    http.StatusOK,
+			wantMediaType: "application/x-protobuf",
+		},
+		{
+			name:          "default marshaler",
+			strict:        true,
+			accept:        "*/*",
+			wantStatus:    http.StatusOK,
+			wantMediaType: "application/json",
+		},
+		{
+			name:        "not acceptable",
+			strict:      true,
+			accept:      "text/html, */*;q=0",
+			wantStatus:  http.StatusNotAcceptable,
+			contentType: "application/json",
+		},
+		{
+			name:        "unsupported media type",
+			strict:      true,
+			contentType: "text/plain",
+			wantStatus:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:          "default marshaler media type",
+			strict:        true,
+			defaultOnly:   true,
+			contentType:   "application/json; charset=utf-8",
+			accept:        "application/json",
+			wantStatus:    http.StatusOK,
+			wantMediaType: "application/json",
+		},
+		{
+			name:        "default marshaler not acceptable",
+			strict:      true,
+			defaultOnly: true,
+			accept:      "application/x-protobuf",
+			wantStatus:  http.StatusNotAcceptable,
+		},
+		{
+			name:        "default marshaler unsupported media type",
+			strict:      true,
+			defaultOnly: true,
+			contentType: "application/x-protobuf",
+			wantStatus:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:          "lenient",
+			contentType:   "text/plain",
+			accept:        "text/html",
+			wantStatus:    http.StatusOK,
+			wantMediaType: "application/json",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			var opts []runtime.ServeMuxOption
+			if !spec.defaultOnly {
+				opts = append(opts,
+					runtime.WithMarshalerOption("application/json", &runtime.JSONPb{}),
+					runtime.WithMarshalerOption("application/x-protobuf", &runtime.ProtoMarshaller{}),
+				)
+			}
+			if spec.strict {
+				opts = append(opts, runtime.WithStrictContentNegotiation())
+			}
+			mux := runtime.NewServeMux(opts...)
+			var gotMediaType string
+			err := mux.HandlePath("POST", "/v1/greetings", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+				gotMediaType, _ = runtime.NegotiatedMediaType(r.Context())
+			})
+			if err != nil {
+				t.Fatalf("mux.HandlePath() failed with %v; want success", err)
+			}
+
+			r := httptest.NewRequest("POST", "/v1/greetings", nil)
+			if spec.contentType != "" {
+				r.Header.Set("Content-Type", spec.contentType)
+			}
+			if spec.accept != "" {
+				r.Header.Set("Accept", spec.accept)
+			}
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, r)
+
+			if got, want := w.Code, spec.wantStatus; got != want {
+				t.Errorf("w.Code = %d; want %d", got, want)
+			}
+			if got, want := gotMediaType, spec.wantMediaType; got != want {
+				t.Errorf("runtime.NegotiatedMediaType() = %q; want %q", got, want)
+			}
+		})
+	}
+}
+
 type dummyMarshaler int
 
 func (dummyMarshaler) ContentType(_ interface{}) string { return "" }
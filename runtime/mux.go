@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/netip"
 	"net/textproto"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/httprule"
 	"google.golang.org/grpc"
@@ -71,8 +73,21 @@ type ServeMux struct {
 	streamErrorHandler        StreamErrorHandlerFunc
 	routingErrorHandler       RoutingErrorHandlerFunc
 	disablePathLengthFallback bool
+	basePath                  string
 	unescapingMode            UnescapingMode
 	writeContentLength        bool
+	trustedProxies            []netip.Prefix
+	forwardedHeader           ForwardedHeader
+	defaultTimeoutPolicy      *TimeoutPolicy
+	routeTimeoutPolicies      map[string]TimeoutPolicy
+	instrumentation           Instrumentation
+	accessLogger              func(context.Context, AccessLogEntry)
+	strictContentNegotiation  bool
+	streamFraming             StreamFraming
+	streamEnvelope            StreamEnvelope
+	streamErrorTrailers       bool
+	requestValidator          RequestValidator
+	rpcServer                 *rpcServer
 }
 
 // ServeMuxOption is an option that can be given to a ServeMux on construction.
@@ -220,6 +235,67 @@ func WithMetadata(annotator func(context.Context, *http.Request) metadata.MD) Se
 	}
 }
 
+// WithTrustedProxies returns a ServeMuxOption which configures the proxies whose forwarding
+// headers are trusted. Each entry is either a CIDR (e.g. "10.0.0.0/8") or a single IP address.
+// Invalid entries are logged and ignored.
+//
+// Without this option the gateway appends the peer address to any incoming X-Forwarded-For
+// header and passes X-Forwarded-Host through as is, which lets clients spoof both.
+//
+// With this option the chain of hops is read from the header selected with WithForwardedHeader,
+// X-Forwarded-For by default, and is walked from the peer address towards the client. The first
+// hop that is not a trusted proxy is taken to be the client; anything before it is discarded.
+// The "x-forwarded-for" metadata then only contains the client followed by the trusted proxies,
+// and the host and scheme are only taken from the forwarding headers when the request was
+// received from a trusted proxy. The resolved client address and scheme are sent as
+// MetadataClientAddress and MetadataClientScheme, and the unfiltered chain as
+// MetadataForwardedChain.
+func WithTrustedProxies(cidrs ...string) ServeMuxOption {
+	prefixes := parseTrustedProxies(cidrs)
+	return func(serveMux *ServeMux) {
+		// Always leave a non-nil slice behind, so that a list which only contained
+		// invalid entries still disables the legacy forwarding behavior.
+		trusted := make([]netip.Prefix, 0, len(serveMux.trustedProxies)+len(prefixes))
+		trusted = append(trusted, serveMux.trustedProxies...)
+		serveMux.trustedProxies = append(trusted, prefixes...)
+	}
+}
+
+// WithForwardedHeader returns a ServeMuxOption which selects the header set by the proxies
+// configured with WithTrustedProxies. The chain of hops is only read from this header: the
+// other forwarding headers are ignored, since they may come from the client.
+func WithForwardedHeader(header ForwardedHeader) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.forwardedHeader = header
+	}
+}
+
+// WithTimeoutPolicy returns a ServeMuxOption which bounds the deadline of every gRPC call
+// made by the ServeMux. See WithRouteTimeoutPolicy for setting a policy for a single method.
+//
+// Once a timeout policy is configured, the timeout requested by the client is read from the
+// Grpc-Timeout header, or else from the Request-Timeout or X-Request-Timeout header, which
+// hold either a number of seconds ("2.5") or a Go duration ("2500ms"). The requested timeout is
+// then raised to the policy's Min and capped to its Max. Requests which do not carry a timeout
+// use the policy's Default, or DefaultContextTimeout if that is unset.
+func WithTimeoutPolicy(policy TimeoutPolicy) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.defaultTimeoutPolicy = &policy
+	}
+}
+
+// WithRouteTimeoutPolicy returns a ServeMuxOption which sets the timeout policy of the routes
+// bound to the given gRPC method, in the format of "/package.service/method". Fields left
+// unset in policy are taken from the policy given to WithTimeoutPolicy, if any.
+func WithRouteTimeoutPolicy(rpcMethodName string, policy TimeoutPolicy) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		if serveMux.routeTimeoutPolicies == nil {
+			serveMux.routeTimeoutPolicies = make(map[string]TimeoutPolicy)
+		}
+		serveMux.routeTimeoutPolicies[rpcMethodName] = policy
+	}
+}
+
 // WithErrorHandler returns a ServeMuxOption for configuring a custom error handler.
 //
 // This can be used to configure a custom error response.
@@ -260,6 +336,21 @@ func WithDisablePathLengthFallback() ServeMuxOption {
 	}
 }
 
+// WithBasePath returns a ServeMuxOption mounting all the routes of the ServeMux,
+// including the health endpoints and the methods served with WithRPCProtocols,
+// under "basePath", such as "/api/v2". Requests outside of the base path get a
+// http.StatusNotFound routing error, and HTTPPathPattern includes the base path.
+//
+// Unlike http.StripPrefix, the requests keep their full URL path.
+func WithBasePath(basePath string) ServeMuxOption {
+	return func(serveMux *ServeMux) {
+		serveMux.basePath = ""
+		if basePath = strings.Trim(basePath, "/"); basePath != "" {
+			serveMux.basePath = "/" + basePath
+		}
+	}
+}
+
 // WithWriteContentLength returns a ServeMuxOption to enable writing content length on non-streaming responses
 func WithWriteContentLength() ServeMuxOption {
 	return func(serveMux *ServeMux) {
@@ -340,6 +431,7 @@ func NewServeMux(opts ...ServeMuxOption) *ServeMux {
 	for _, opt := range opts {
 		opt(serveMux)
 	}
+	serveMux.marshalers.compile()
 
 	if serveMux.incomingHeaderMatcher == nil {
 		serveMux.incomingHeaderMatcher = DefaultHeaderMatcher
@@ -350,6 +442,9 @@ func NewServeMux(opts ...ServeMuxOption) *ServeMux {
 	if serveMux.outgoingTrailerMatcher == nil {
 		serveMux.outgoingTrailerMatcher = defaultOutgoingTrailerMatcher
 	}
+	if serveMux.instrumentation != nil || serveMux.accessLogger != nil {
+		serveMux.trackErrors()
+	}
 
 	return serveMux
 }
@@ -380,6 +475,15 @@ func (s *ServeMux) HandlePath(meth string, pathPattern string, h HandlerFunc) er
 
 // ServeHTTP dispatches the request to the first handler whose pattern matches to r.Method and r.URL.Path.
 func (s *ServeMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.accessLogger != nil {
+		var tracker *requestTracker
+		w, r, tracker = trackRequest(w, r)
+		start := time.Now()
+		// The request is logged as routed, with the base path and the
+		// method override applied to it below.
+		defer func() { s.logAccess(r, tracker, start) }()
+	}
+
 	ctx := r.Context()
 
 	path := r.URL.Path
@@ -394,6 +498,25 @@ func (s *ServeMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		path = r.URL.RawPath
 	}
 
+	if s.basePath != "" {
+		rest, ok := strings.CutPrefix(path, s.basePath)
+		if !ok || (rest != "" && rest[0] != '/') {
+			_, outboundMarshaler := MarshalerForRequest(s, r)
+			s.routingErrorHandler(ctx, s, outboundMarshaler, w, r, http.StatusNotFound)
+			return
+		}
+		if rest == "" {
+			rest = "/"
+		}
+		path = rest
+		ctx = withPathPrefix(ctx, s.basePath)
+		r = r.WithContext(ctx)
+	}
+
+	if s.rpcServer != nil && s.serveRPC(w, r, path) {
+		return
+	}
+
 	if override := r.Header.Get("X-HTTP-Method-Override"); override != "" && s.isPathLengthFallback(r) {
 		if err := r.ParseForm(); err != nil {
 			_, outboundMarshaler := MarshalerForRequest(s, r)
@@ -540,7 +663,26 @@ type handler struct {
 }
 
 func (s *ServeMux) handleHandler(h handler, w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
-	h.h(w, r.WithContext(withHTTPPattern(r.Context(), h.pat)), pathParams)
+	n := s.negotiate(r)
+	r = r.WithContext(withNegotiation(withHTTPPattern(r.Context(), h.pat), n))
+	if s.strictContentNegotiation && n.httpStatus != 0 {
+		s.routingErrorHandler(r.Context(), s, n.outbound, w, r, n.httpStatus)
+		return
+	}
+	if tracker := requestTrackerFromContext(r.Context()); tracker != nil {
+		route := h.pat.String()
+		if prefix, ok := pathPrefix(r.Context()); ok {
+			route = prefix + route
+		}
+		tracker.setRoute("", route)
+	}
+	if s.instrumentation == nil {
+		h.h(w, r, pathParams)
+		return
+	}
+	s.instrumentRequest(w, r, func(w http.ResponseWriter, r *http.Request) {
+		h.h(w, r, pathParams)
+	})
 }
 
 func chainMiddlewares(mws []Middleware) Middleware {
@@ -849,6 +849,48 @@ func TestWithHealthzEndpoint_header(t *testing.T) {
 	}
 }
 
+func TestWithBasePath(t *testing.T) {
+	mux := runtime.NewServeMux(
+		runtime.WithHealthzEndpoint(&dummyHealthCheckClient{status: grpc_health_v1.HealthCheckResponse_SERVING}),
+		runtime.WithBasePath("/api/v2/"),
+	)
+	err := mux.HandlePath(http.MethodGet, "/v1/books/{name}", func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r, "/library.v1.LibraryService/GetBook", runtime.WithHTTPPathPattern("/v1/books/{name}"))
+		if err != nil {
+			t.Errorf("runtime.AnnotateContext() failed with %v", err)
+			return
+		}
+		pattern, _ := runtime.HTTPPathPattern(ctx)
+		fmt.Fprintf(w, "%s %s", pattern, pathParams["name"])
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath() failed with %v", err)
+	}
+
+	for _, spec := range []struct {
+		path     string
+		wantCode int
+		wantBody string
+	}{
+		{path: "/api/v2/v1/books/1", wantCode: http.StatusOK, wantBody: "/api/v2/v1/books/{name} 1"},
+		{path: "/api/v2/healthz", wantCode: http.StatusOK},
+		{path: "/v1/books/1", wantCode: http.StatusNotFound},
+		{path: "/healthz", wantCode: http.StatusNotFound},
+		{path: "/api/v2healthz", wantCode: http.StatusNotFound},
+		{path: "/api/v2", wantCode: http.StatusNotFound},
+	} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, spec.path, nil))
+		if w.Code != spec.wantCode {
+			t.Errorf("GET %s status = %d; want %d", spec.path, w.Code, spec.wantCode)
+			continue
+		}
+		if spec.wantBody != "" && w.Body.String() != spec.wantBody {
+			t.Errorf("GET %s body = %q; want %q", spec.path, w.Body.String(), spec.wantBody)
+		}
+	}
+}
+
 var _ grpc_health_v1.HealthClient = (*dummyHealthCheckClient)(nil)
 
 type dummyHealthCheckClient struct {
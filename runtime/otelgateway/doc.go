@@ -0,0 +1,15 @@
+/*
+Package otelgateway instruments a runtime.ServeMux with OpenTelemetry.
+
+It records a span for every routed request, named after the HTTP path pattern
+of the matched route, propagates the trace context to the gRPC server, and
+records request latency, body sizes, marshaling time and streamed message
+counts as histograms.
+
+	inst, err := otelgateway.NewInstrumentation()
+	if err != nil {
+		return err
+	}
+	mux := runtime.NewServeMux(runtime.WithInstrumentation(inst))
+*/
+package otelgateway
@@ -0,0 +1,4 @@
+// Package sdktest tests the otelgateway package with the OpenTelemetry SDK.
+// It is a separate module, so that the gateway module does not depend on the
+// SDK.
+package sdktest
@@ -0,0 +1,233 @@
+package sdktest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime/otelgateway"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	parentTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	traceparent   = "00-" + parentTraceID + "-00f067aa0ba902b7-01"
+)
+
+func newInstrumentedMux(t *testing.T) (*runtime.ServeMux, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	inst, err := otelgateway.NewInstrumentation(
+		otelgateway.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+		otelgateway.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		otelgateway.WithPropagators(propagation.TraceContext{}),
+	)
+	if err != nil {
+		t.Fatalf("otelgateway.NewInstrumentation() failed with %v; want success", err)
+	}
+	return runtime.NewServeMux(runtime.WithInstrumentation(inst)), recorder, reader
+}
+
+func TestInstrumentation_Unary(t *testing.T) {
+	mux, recorder, reader := newInstrumentedMux(t)
+
+	var outgoing metadata.MD
+	err := mux.HandlePath("POST", "/v1/{name=greetings/*}:echo", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		inbound, outbound := runtime.MarshalerForRequest(mux, r)
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r, "/example.Greeter/Echo", runtime.WithHTTPPathPattern("/v1/{name=greetings/*}:echo"))
+		if err != nil {
+			t.Errorf("runtime.AnnotateContext() failed with %v; want success", err)
+			return
+		}
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		var msg wrapperspb.StringValue
+		if err := runtime.DecodeRequest(ctx, inbound.NewDecoder(r.Body), &msg); err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		runtime.ForwardResponseMessage(ctx, mux, outbound, w, r, &msg)
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath() failed with %v; want success", err)
+	}
+
+	body := `"hello"`
+	r := httptest.NewRequest("POST", "/v1/greetings/world:echo", strings.NewReader(body))
+	r.Header.Set("Traceparent", traceparent)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("w.Code = %d; want %d; body %q", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("len(spans) = %d; want 1", len(spans))
+	}
+	span := spans[0]
+	if got, want := span.Name(), "POST /v1/{name=greetings/*}:echo"; got != want {
+		t.Errorf("span.Name() = %q; want %q", got, want)
+	}
+	if got, want := span.SpanKind(), trace.SpanKindServer; got != want {
+		t.Errorf("span.SpanKind() = %v; want %v", got, want)
+	}
+	if got, want := span.Parent().TraceID().String(), parentTraceID; got != want {
+		t.Errorf("span.Parent().TraceID() = %q; want %q", got, want)
+	}
+	attrs := attribute.NewSet(span.Attributes()...)
+	for key, want := range map[attribute.Key]attribute.Value{
+		"http.route":                attribute.StringValue("/v1/{name=greetings/*}:echo"),
+		"rpc.service":               attribute.StringValue("example.Greeter"),
+		"rpc.method":                attribute.StringValue("Echo"),
+		"http.response.status_code": attribute.IntValue(http.StatusOK),
+	} {
+		if got, ok := attrs.Value(key); !ok || got != want {
+			t.Errorf("span attribute %q = %v; want %v", key, got.Emit(), want.Emit())
+		}
+	}
+
+	// The trace context sent to the gRPC server must be the one of the gateway span.
+	if got := outgoing.Get("traceparent"); len(got) != 1 || !strings.Contains(got[0], span.SpanContext().SpanID().String()) {
+		t.Errorf(`outgoing.Get("traceparent") = %v; want the span ID %s`, got, span.SpanContext().SpanID())
+	}
+
+	rm := collect(t, reader)
+	if got, want := histogramSum(t, rm, "http.server.request.body.size"), float64(len(body)); got != want {
+		t.Errorf("http.server.request.body.size = %v; want %v", got, want)
+	}
+	if got, want := histogramSum(t, rm, "http.server.response.body.size"), float64(w.Body.Len()); got != want {
+		t.Errorf("http.server.response.body.size = %v; want %v", got, want)
+	}
+	if got, want := histogramSum(t, rm, "rpc.server.requests_per_rpc"), 1.0; got != want {
+		t.Errorf("rpc.server.requests_per_rpc = %v; want %v", got, want)
+	}
+	if got, want := histogramSum(t, rm, "rpc.server.responses_per_rpc"), 1.0; got != want {
+		t.Errorf("rpc.server.responses_per_rpc = %v; want %v", got, want)
+	}
+	if got := histogramSum(t, rm, "grpc_gateway.server.unmarshal.duration"); got <= 0 {
+		t.Errorf("grpc_gateway.server.unmarshal.duration = %v; want > 0", got)
+	}
+	if got := histogramSum(t, rm, "grpc_gateway.server.marshal.duration"); got <= 0 {
+		t.Errorf("grpc_gateway.server.marshal.duration = %v; want > 0", got)
+	}
+}
+
+func TestInstrumentation_ServerStream(t *testing.T) {
+	mux, recorder, reader := newInstrumentedMux(t)
+
+	err := mux.HandlePath("GET", "/v1/greetings", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r, "/example.Greeter/List", runtime.WithHTTPPathPattern("/v1/greetings"))
+		if err != nil {
+			t.Errorf("runtime.AnnotateContext() failed with %v; want success", err)
+			return
+		}
+		ctx = runtime.NewServerMetadataContext(ctx, runtime.ServerMetadata{})
+		msgs := []string{"a", "b", "c"}
+		runtime.ForwardResponseStream(ctx, mux, outbound, w, r, func() (proto.Message, error) {
+			if len(msgs) == 0 {
+				return nil, io.EOF
+			}
+			msg := wrapperspb.String(msgs[0])
+			msgs = msgs[1:]
+			return msg, nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath() failed with %v; want success", err)
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/v1/greetings", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("len(spans) = %d; want 1", len(spans))
+	}
+	if got, want := spans[0].Name(), "GET /v1/greetings"; got != want {
+		t.Errorf("span.Name() = %q; want %q", got, want)
+	}
+	rm := collect(t, reader)
+	if got, want := histogramSum(t, rm, "rpc.server.responses_per_rpc"), 3.0; got != want {
+		t.Errorf("rpc.server.responses_per_rpc = %v; want %v", got, want)
+	}
+	if got, want := histogramSum(t, rm, "rpc.server.requests_per_rpc"), 0.0; got != want {
+		t.Errorf("rpc.server.requests_per_rpc = %v; want %v", got, want)
+	}
+}
+
+func TestInstrumentation_ErrorStatus(t *testing.T) {
+	mux, recorder, _ := newInstrumentedMux(t)
+
+	err := mux.HandlePath("GET", "/v1/fail", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+		runtime.HTTPError(r.Context(), mux, outbound, w, r, errors.New("boom"))
+	})
+	if err != nil {
+		t.Fatalf("mux.HandlePath() failed with %v; want success", err)
+	}
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/v1/fail", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("len(spans) = %d; want 1", len(spans))
+	}
+	if got, want := spans[0].Name(), "GET /v1/fail"; got != want {
+		t.Errorf("span.Name() = %q; want %q", got, want)
+	}
+	if got, want := spans[0].Status().Code.String(), "Error"; got != want {
+		t.Errorf("span.Status().Code = %q; want %q", got, want)
+	}
+}
+
+func collect(t *testing.T, reader *sdkmetric.ManualReader) metricdata.ResourceMetrics {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("reader.Collect() failed with %v; want success", err)
+	}
+	return rm
+}
+
+func histogramSum(t *testing.T, rm metricdata.ResourceMetrics, name string) float64 {
+	t.Helper()
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+			var sum float64
+			switch data := m.Data.(type) {
+			case metricdata.Histogram[int64]:
+				for _, dp := range data.DataPoints {
+					sum += float64(dp.Sum)
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					sum += dp.Sum
+				}
+			default:
+				t.Fatalf("metric %q has unexpected type %T", name, m.Data)
+			}
+			return sum
+		}
+	}
+	t.Fatalf("metric %q was not recorded", name)
+	return 0
+}
@@ -0,0 +1,269 @@
+package otelgateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+// ScopeName is the instrumentation scope name used for the tracer and meter.
+const ScopeName = "github.com/grpc-ecosystem/grpc-gateway/v2/runtime/otelgateway"
+
+const (
+	httpRequestMethodKey      = attribute.Key("http.request.method")
+	httpRouteKey              = attribute.Key("http.route")
+	httpResponseStatusCodeKey = attribute.Key("http.response.status_code")
+	urlPathKey                = attribute.Key("url.path")
+	rpcSystemKey              = attribute.Key("rpc.system")
+	rpcServiceKey             = attribute.Key("rpc.service")
+	rpcMethodKey              = attribute.Key("rpc.method")
+	rpcGRPCStatusCodeKey      = attribute.Key("rpc.grpc.status_code")
+)
+
+// Option configures an Instrumentation.
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// WithTracerProvider sets the TracerProvider used to create spans.
+// The global TracerProvider is used by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the MeterProvider used to record metrics.
+// The global MeterProvider is used by default.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// WithPropagators sets the propagators used to extract the trace context from
+// incoming HTTP headers and inject it into outgoing gRPC metadata.
+// The global TextMapPropagator is used by default.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = p
+	}
+}
+
+// Instrumentation is a runtime.Instrumentation recording OpenTelemetry traces
+// and metrics.
+type Instrumentation struct {
+	tracer      trace.Tracer
+	propagators propagation.TextMapPropagator
+
+	duration          metric.Float64Histogram
+	requestSize       metric.Int64Histogram
+	responseSize      metric.Int64Histogram
+	requestsPerRPC    metric.Int64Histogram
+	responsesPerRPC   metric.Int64Histogram
+	marshalDuration   metric.Float64Histogram
+	unmarshalDuration metric.Float64Histogram
+}
+
+var _ runtime.Instrumentation = (*Instrumentation)(nil)
+
+// NewInstrumentation returns an Instrumentation to be passed to
+// runtime.WithInstrumentation.
+func NewInstrumentation(opts ...Option) (*Instrumentation, error) {
+	c := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	meter := c.meterProvider.Meter(ScopeName)
+	inst := &Instrumentation{
+		tracer:      c.tracerProvider.Tracer(ScopeName),
+		propagators: c.propagators,
+	}
+	var err error
+	if inst.duration, err = meter.Float64Histogram("http.server.request.duration",
+		metric.WithDescription("Duration of HTTP server requests."),
+		metric.WithUnit("s"),
+	); err != nil {
+		return nil, fmt.Errorf("creating request duration histogram: %w", err)
+	}
+	if inst.requestSize, err = meter.Int64Histogram("http.server.request.body.size",
+		metric.WithDescription("Size of HTTP server request bodies."),
+		metric.WithUnit("By"),
+	); err != nil {
+		return nil, fmt.Errorf("creating request size histogram: %w", err)
+	}
+	if inst.responseSize, err = meter.Int64Histogram("http.server.response.body.size",
+		metric.WithDescription("Size of HTTP server response bodies."),
+		metric.WithUnit("By"),
+	); err != nil {
+		return nil, fmt.Errorf("creating response size histogram: %w", err)
+	}
+	if inst.requestsPerRPC, err = meter.Int64Histogram("rpc.server.requests_per_rpc",
+		metric.WithDescription("Number of messages received per request."),
+		metric.WithUnit("{count}"),
+	); err != nil {
+		return nil, fmt.Errorf("creating requests per RPC histogram: %w", err)
+	}
+	if inst.responsesPerRPC, err = meter.Int64Histogram("rpc.server.responses_per_rpc",
+		metric.WithDescription("Number of messages sent per request."),
+		metric.WithUnit("{count}"),
+	); err != nil {
+		return nil, fmt.Errorf("creating responses per RPC histogram: %w", err)
+	}
+	if inst.marshalDuration, err = meter.Float64Histogram("grpc_gateway.server.marshal.duration",
+		metric.WithDescription("Time spent encoding response messages per request."),
+		metric.WithUnit("s"),
+	); err != nil {
+		return nil, fmt.Errorf("creating marshal duration histogram: %w", err)
+	}
+	if inst.unmarshalDuration, err = meter.Float64Histogram("grpc_gateway.server.unmarshal.duration",
+		metric.WithDescription("Time spent decoding request messages per request."),
+		metric.WithUnit("s"),
+	); err != nil {
+		return nil, fmt.Errorf("creating unmarshal duration histogram: %w", err)
+	}
+	return inst, nil
+}
+
+// StartRequest starts the span of a request, continuing the trace found in
+// its headers. The span is named after the matched route until its HTTP path
+// pattern is known.
+func (i *Instrumentation) StartRequest(ctx context.Context, r *http.Request) (context.Context, runtime.RequestInstrumentation) {
+	ctx = i.propagators.Extract(ctx, propagation.HeaderCarrier(r.Header))
+
+	route := r.URL.Path
+	if pattern, ok := runtime.HTTPPattern(ctx); ok {
+		route = pattern.String()
+	}
+	attrs := []attribute.KeyValue{
+		httpRequestMethodKey.String(r.Method),
+		httpRouteKey.String(route),
+		urlPathKey.String(r.URL.Path),
+	}
+	ctx, span := i.tracer.Start(ctx, r.Method+" "+route,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...),
+	)
+	return ctx, &request{
+		inst:   i,
+		span:   span,
+		start:  time.Now(),
+		method: r.Method,
+		route:  route,
+	}
+}
+
+type request struct {
+	inst  *Instrumentation
+	span  trace.Span
+	start time.Time
+
+	method    string
+	route     string
+	rpcMethod string
+}
+
+// Annotate renames the span after the HTTP path pattern, records the RPC
+// method and returns the trace context to propagate to the gRPC server.
+func (r *request) Annotate(ctx context.Context, rpcMethodName, httpPathPattern string) metadata.MD {
+	if httpPathPattern != "" {
+		r.route = httpPathPattern
+		r.span.SetName(r.method + " " + httpPathPattern)
+		r.span.SetAttributes(httpRouteKey.String(httpPathPattern))
+	}
+	r.rpcMethod = rpcMethodName
+	r.span.SetAttributes(rpcAttributes(rpcMethodName)...)
+
+	md := metadata.MD{}
+	r.inst.propagators.Inject(ctx, metadataCarrier(md))
+	return md
+}
+
+// End ends the span and records the metrics of the request.
+func (r *request) End(ctx context.Context, stats runtime.RequestStats) {
+	r.span.SetAttributes(
+		httpResponseStatusCodeKey.Int(stats.HTTPStatus),
+		rpcGRPCStatusCodeKey.Int(int(stats.GRPCCode)),
+		attribute.Int("grpc_gateway.messages.received", stats.MessagesReceived),
+		attribute.Int("grpc_gateway.messages.sent", stats.MessagesSent),
+	)
+	if stats.HTTPStatus >= http.StatusInternalServerError {
+		r.span.SetStatus(codes.Error, http.StatusText(stats.HTTPStatus))
+	}
+	r.span.End()
+
+	attrs := []attribute.KeyValue{
+		httpRequestMethodKey.String(r.method),
+		httpRouteKey.String(r.route),
+		httpResponseStatusCodeKey.Int(stats.HTTPStatus),
+	}
+	if r.rpcMethod != "" {
+		attrs = append(attrs, rpcAttributes(r.rpcMethod)...)
+		attrs = append(attrs, rpcGRPCStatusCodeKey.Int(int(stats.GRPCCode)))
+	}
+	set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+
+	r.inst.duration.Record(ctx, time.Since(r.start).Seconds(), set)
+	r.inst.requestSize.Record(ctx, stats.RequestSize, set)
+	r.inst.responseSize.Record(ctx, stats.ResponseSize, set)
+	r.inst.requestsPerRPC.Record(ctx, int64(stats.MessagesReceived), set)
+	r.inst.responsesPerRPC.Record(ctx, int64(stats.MessagesSent), set)
+	r.inst.marshalDuration.Record(ctx, stats.MarshalDuration.Seconds(), set)
+	r.inst.unmarshalDuration.Record(ctx, stats.UnmarshalDuration.Seconds(), set)
+}
+
+// rpcAttributes returns the attributes describing a method in the format of
+// "/package.service/method".
+func rpcAttributes(fullMethod string) []attribute.KeyValue {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return []attribute.KeyValue{rpcSystemKey.String("grpc"), rpcMethodKey.String(fullMethod)}
+	}
+	return []attribute.KeyValue{
+		rpcSystemKey.String("grpc"),
+		rpcServiceKey.String(service),
+		rpcMethodKey.String(method),
+	}
+}
+
+// metadataCarrier adapts metadata.MD to propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if vals := metadata.MD(c).Get(key); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -110,6 +110,29 @@ func populateFieldValueFromPath(msgValue protoreflect.Message, fieldPath []strin
 		return errors.New("no value provided")
 	}
 
+	msgValue, fieldDescriptor, err := fieldByPath(msgValue, fieldPath)
+	if err != nil || fieldDescriptor == nil {
+		return err
+	}
+
+	switch {
+	case fieldDescriptor.IsList():
+		return populateRepeatedField(fieldDescriptor, msgValue.Mutable(fieldDescriptor).List(), values)
+	case fieldDescriptor.IsMap():
+		return populateMapField(fieldDescriptor, msgValue.Mutable(fieldDescriptor).Map(), values)
+	}
+
+	if len(values) > 1 {
+		return fmt.Errorf("too many values for field %q: %s", fieldDescriptor.FullName().Name(), strings.Join(values, ", "))
+	}
+
+	return populateField(fieldDescriptor, msgValue, values[0])
+}
+
+// fieldByPath returns the field designated by fieldPath within msgValue, along
+// with the message holding it. Intermediate messages are created as needed.
+// The returned descriptor is nil if the field does not exist.
+func fieldByPath(msgValue protoreflect.Message, fieldPath []string) (protoreflect.Message, protoreflect.FieldDescriptor, error) {
 	var fieldDescriptor protoreflect.FieldDescriptor
 	for i, fieldName := range fieldPath {
 		fields := msgValue.Descriptor().Fields()
@@ -122,7 +145,7 @@ func populateFieldValueFromPath(msgValue protoreflect.Message, fieldPath []strin
 				// We're not returning an error here because this could just be
 				// an extra query parameter that isn't part of the request.
 				grpclog.Infof("field not found in %q: %q", msgValue.Descriptor().FullName(), strings.Join(fieldPath, "."))
-				return nil
+				return nil, nil, nil
 			}
 		}
 
@@ -130,7 +153,7 @@ func populateFieldValueFromPath(msgValue protoreflect.Message, fieldPath []strin
 		if of := fieldDescriptor.ContainingOneof(); of != nil && !of.IsSynthetic() {
 			if f := msgValue.WhichOneof(of); f != nil {
 				if fieldDescriptor.Message() == nil || fieldDescriptor.FullName() != f.FullName() {
-					return fmt.Errorf("field already set for oneof %q", of.FullName().Name())
+					return nil, nil, fmt.Errorf("field already set for oneof %q", of.FullName().Name())
 				}
 			}
 		}
@@ -142,25 +165,13 @@ func populateFieldValueFromPath(msgValue protoreflect.Message, fieldPath []strin
 
 		// Only singular message fields are allowed
 		if fieldDescriptor.Message() == nil || fieldDescriptor.Cardinality() == protoreflect.Repeated {
-			return fmt.Errorf("invalid path: %q is not a message", fieldName)
+			return nil, nil, fmt.Errorf("invalid path: %q is not a message", fieldName)
 		}
 
 		// Get the nested message
 		msgValue = msgValue.Mutable(fieldDescriptor).Message()
 	}
-
-	switch {
-	case fieldDescriptor.IsList():
-		return populateRepeatedField(fieldDescriptor, msgValue.Mutable(fieldDescriptor).List(), values)
-	case fieldDescriptor.IsMap():
-		return populateMapField(fieldDescriptor, msgValue.Mutable(fieldDescriptor).Map(), values)
-	}
-
-	if len(values) > 1 {
-		return fmt.Errorf("too many values for field %q: %s", fieldDescriptor.FullName().Name(), strings.Join(values, ", "))
-	}
-
-	return populateField(fieldDescriptor, msgValue, values[0])
+	return msgValue, fieldDescriptor, nil
 }
 
 func populateField(fieldDescriptor protoreflect.FieldDescriptor, msgValue protoreflect.Message, value string) error {
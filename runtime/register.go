@@ -0,0 +1,242 @@
+package runtime
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/genproto/googleapis/api/visibility"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// RegisterOption is an option of the generated Register*Handler functions,
+// selecting the bindings they register on the ServeMux, and where.
+type RegisterOption func(*registerOptions)
+
+type registerOptions struct {
+	methods         []string
+	excludedMethods []string
+	selectors       []string
+	bindings        []int
+	pathPrefix      []string
+}
+
+// WithMethods returns a RegisterOption which only registers the bindings of
+// "methods". The methods are either their full name, such as
+// "/library.v1.LibraryService/GetBook", their dotted name, such as
+// "library.v1.LibraryService.GetBook", or their name alone, such as
+// "GetBook".
+func WithMethods(methods ...string) RegisterOption {
+	return func(o *registerOptions) {
+		o.methods = append(o.methods, methods...)
+	}
+}
+
+// WithoutMethods returns a RegisterOption which doesn't register the
+// bindings of "methods", named as with WithMethods.
+func WithoutMethods(methods ...string) RegisterOption {
+	return func(o *registerOptions) {
+		o.excludedMethods = append(o.excludedMethods, methods...)
+	}
+}
+
+// WithVisibilitySelectors returns a RegisterOption which only registers the
+// bindings of the methods visible with the visibility "selectors": methods
+// whose service and method have no google.api.VisibilityRule restriction, or
+// a restriction listing one of the selectors. It mirrors the
+// visibility_restriction_selectors option of the OpenAPI generators.
+//
+// The restrictions are read from the descriptors of the methods in
+// protoregistry.GlobalFiles. Methods whose descriptor is missing are not
+// registered.
+func WithVisibilitySelectors(selectors ...string) RegisterOption {
+	return func(o *registerOptions) {
+		o.selectors = append(o.selectors, selectors...)
+	}
+}
+
+// WithBindingIndexes returns a RegisterOption which only registers the
+// bindings with the given indexes: 0 for the binding of the
+// google.api.http option of a method, and i for its i-th additional
+// binding.
+func WithBindingIndexes(indexes ...int) RegisterOption {
+	return func(o *registerOptions) {
+		o.bindings = append(o.bindings, indexes...)
+	}
+}
+
+// WithPathPrefix returns a RegisterOption which registers the bindings under
+// the literal path "prefix", such as "/admin". The HTTP path patterns of the
+// annotated contexts include the prefix.
+func WithPathPrefix(prefix string) RegisterOption {
+	return func(o *registerOptions) {
+		for _, segment := range strings.Split(prefix, "/") {
+			if segment != "" {
+				o.pathPrefix = append(o.pathPrefix, segment)
+			}
+		}
+	}
+}
+
+// HandleBinding associates "h" to the pair of HTTP method and path pattern
+// of the binding "index" of the RPC method "rpcMethod", such as
+// "/library.v1.LibraryService/GetBook", unless the binding is filtered out
+// by "opts". It is called by the generated Register*Handler functions.
+func (s *ServeMux) HandleBinding(meth string, pat Pattern, rpcMethod string, index int, h HandlerFunc, opts ...RegisterOption) {
+	var o registerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.registers(rpcMethod, index) {
+		return
+	}
+	if method, ok := lookupMethod(rpcMethod); ok && method.IsStreamingClient() {
+		handler := h
+		h = func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			handler(w, r.WithContext(context.WithValue(r.Context(), clientStreamingKey{}, true)), pathParams)
+		}
+	}
+	if len(o.pathPrefix) > 0 {
+		prefix := "/" + strings.Join(o.pathPrefix, "/")
+		pat = pat.withPrefix(o.pathPrefix)
+		handler := h
+		h = func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			handler(w, r.WithContext(withPathPrefix(r.Context(), prefix)), pathParams)
+		}
+	}
+	s.Handle(meth, pat, h)
+}
+
+// registers reports whether the binding "index" of "rpcMethod" is
+// registered with the options o.
+func (o *registerOptions) registers(rpcMethod string, index int) bool {
+	if len(o.bindings) > 0 && !slices.Contains(o.bindings, index) {
+		return false
+	}
+	return o.registersMethod(rpcMethod)
+}
+
+// registersMethod reports whether "rpcMethod" is selected by the options o.
+func (o *registerOptions) registersMethod(rpcMethod string) bool {
+	if len(o.methods) > 0 && !matchesMethod(rpcMethod, o.methods) {
+		return false
+	}
+	if matchesMethod(rpcMethod, o.excludedMethods) {
+		return false
+	}
+	if len(o.selectors) > 0 && !methodVisible(rpcMethod, o.selectors) {
+		return false
+	}
+	return true
+}
+
+// matchesMethod reports whether the full method name "rpcMethod" is one of
+// "names", either full, dotted, or alone.
+func matchesMethod(rpcMethod string, names []string) bool {
+	full := strings.TrimPrefix(rpcMethod, "/")
+	dotted := strings.Replace(full, "/", ".", 1)
+	short := full[strings.LastIndex(full, "/")+1:]
+	for _, name := range names {
+		switch strings.TrimPrefix(name, "/") {
+		case full, dotted, short:
+			return true
+		}
+	}
+	return false
+}
+
+// lookupMethod returns the descriptor of the method "rpcMethod" in
+// protoregistry.GlobalFiles, if any.
+func lookupMethod(rpcMethod string) (protoreflect.MethodDescriptor, bool) {
+	name := protoreflect.FullName(strings.Replace(strings.TrimPrefix(rpcMethod, "/"), "/", ".", 1))
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return nil, false
+	}
+	method, ok := desc.(protoreflect.MethodDescriptor)
+	return method, ok
+}
+
+// methodVisible reports whether the service and the method "rpcMethod" are
+// visible with the visibility "selectors". Methods missing from
+// protoregistry.GlobalFiles are not visible, as their restrictions are
+// unknown.
+func methodVisible(rpcMethod string, selectors []string) bool {
+	method, ok := lookupMethod(rpcMethod)
+	if !ok {
+		grpclog.Warningf("Not registering %s: its descriptor is missing from protoregistry.GlobalFiles, so its visibility cannot be checked", rpcMethod)
+		return false
+	}
+	return visible(method.Parent().Options(), visibility.E_ApiVisibility, selectors) &&
+		visible(method.Options(), visibility.E_MethodVisibility, selectors)
+}
+
+func visible(opts proto.Message, ext protoreflect.ExtensionType, selectors []string) bool {
+	if opts == nil || !proto.HasExtension(opts, ext) {
+		return true
+	}
+	rule, _ := proto.GetExtension(opts, ext).(*visibility.VisibilityRule)
+	if rule.GetRestriction() == "" {
+		return true
+	}
+	for _, restriction := range strings.Split(rule.GetRestriction(), ",") {
+		if slices.Contains(selectors, strings.TrimSpace(restriction)) {
+			return true
+		}
+	}
+	return false
+}
+
+type clientStreamingKey struct{}
+
+// isClientStreaming reports whether the handler of the request with context
+// "ctx" was registered for a client-streaming method.
+func isClientStreaming(ctx context.Context) bool {
+	streaming, _ := ctx.Value(clientStreamingKey{}).(bool)
+	return streaming
+}
+
+type pathPrefixKey struct{}
+
+func withPathPrefix(ctx context.Context, prefix string) context.Context {
+	if outer, ok := ctx.Value(pathPrefixKey{}).(string); ok {
+		prefix = outer + prefix
+	}
+	return context.WithValue(ctx, pathPrefixKey{}, prefix)
+}
+
+// pathPrefix returns the path prefix the handler of the request with context
+// "ctx" was registered under, if any.
+func pathPrefix(ctx context.Context) (string, bool) {
+	prefix, ok := ctx.Value(pathPrefixKey{}).(string)
+	return prefix, ok
+}
+
+// withPrefix returns the pattern matching the paths of p under the literal
+// "segments".
+func (p Pattern) withPrefix(segments []string) Pattern {
+	n := len(segments)
+	ops := make([]op, 0, n+len(p.ops))
+	for i := range segments {
+		ops = append(ops, op{code: utilities.OpLitPush, operand: i})
+	}
+	for _, o := range p.ops {
+		if o.code == utilities.OpLitPush {
+			o.operand += n
+		}
+		ops = append(ops, o)
+	}
+	return Pattern{
+		ops:       ops,
+		pool:      append(slices.Clone(segments), p.pool...),
+		vars:      p.vars,
+		stacksize: p.stacksize + n,
+		tailLen:   p.tailLen,
+		verb:      p.verb,
+	}
+}
@@ -0,0 +1,210 @@
+package runtime_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/genproto/googleapis/api/visibility"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const (
+	getBook     = "/register.test.Library/GetBook"
+	deleteBook  = "/register.test.Library/DeleteBook"
+	uploadBooks = "/register.test.Library/UploadBooks"
+)
+
+func init() {
+	opts := &descriptorpb.MethodOptions{}
+	proto.SetExtension(opts, visibility.E_MethodVisibility, &visibility.VisibilityRule{Restriction: "INTERNAL, PREVIEW"})
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("runtime/register_test.proto"),
+		Package: proto.String("register.test"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Book"),
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Library"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				{
+					Name:       proto.String("GetBook"),
+					InputType:  proto.String(".register.test.Book"),
+					OutputType: proto.String(".register.test.Book"),
+				},
+				{
+					Name:       proto.String("DeleteBook"),
+					InputType:  proto.String(".register.test.Book"),
+					OutputType: proto.String(".register.test.Book"),
+					Options:    opts,
+				},
+				{
+					Name:            proto.String("UploadBooks"),
+					InputType:       proto.String(".register.test.Book"),
+					OutputType:      proto.String(".register.test.Book"),
+					ClientStreaming: proto.Bool(true),
+				},
+			},
+		}},
+	}, nil)
+	if err != nil {
+		panic(err)
+	}
+	if err := protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+		panic(err)
+	}
+}
+
+// registerLibrary registers the bindings of the Library service the way the
+// generated Register*Handler functions do.
+func registerLibrary(mux *runtime.ServeMux, opts ...runtime.RegisterOption) {
+	book := runtime.MustPattern(runtime.NewPattern(1, []int{
+		int(utilities.OpLitPush), 0,
+		int(utilities.OpLitPush), 1,
+		int(utilities.OpPush), 0,
+		int(utilities.OpConcatN), 1,
+		int(utilities.OpCapture), 2,
+	}, []string{"v1", "books", "name"}, ""))
+	legacyBook := runtime.MustPattern(runtime.NewPattern(1, []int{
+		int(utilities.OpLitPush), 0,
+		int(utilities.OpPush), 0,
+		int(utilities.OpConcatN), 1,
+		int(utilities.OpCapture), 1,
+	}, []string{"books", "name"}, ""))
+	handler := func(rpcMethod string) runtime.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			ctx, err := runtime.AnnotateContext(r.Context(), mux, r, rpcMethod, runtime.WithHTTPPathPattern("/v1/books/{name}"))
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			pattern, _ := runtime.HTTPPathPattern(ctx)
+			w.Header().Set("pattern", pattern)
+			w.Header().Set("name", pathParams["name"])
+		}
+	}
+	mux.HandleBinding(http.MethodGet, book, getBook, 0, handler(getBook), opts...)
+	mux.HandleBinding(http.MethodGet, legacyBook, getBook, 1, handler(getBook), opts...)
+	mux.HandleBinding(http.MethodDelete, book, deleteBook, 0, handler(deleteBook), opts...)
+}
+
+func TestServeMuxHandleBinding(t *testing.T) {
+	for _, spec := range []struct {
+		name string
+		opts []runtime.RegisterOption
+		want map[string]bool
+	}{
+		{
+			name: "all",
+			want: map[string]bool{"GET /v1/books/1": true, "GET /books/1": true, "DELETE /v1/books/1": true},
+		},
+		{
+			name: "methods",
+			opts: []runtime.RegisterOption{runtime.WithMethods("register.test.Library.DeleteBook")},
+			want: map[string]bool{"DELETE /v1/books/1": true},
+		},
+		{
+			name: "short methods",
+			opts: []runtime.RegisterOption{runtime.WithMethods("GetBook")},
+			want: map[string]bool{"GET /v1/books/1": true, "GET /books/1": true},
+		},
+		{
+			name: "without methods",
+			opts: []runtime.RegisterOption{runtime.WithoutMethods(getBook)},
+			want: map[string]bool{"DELETE /v1/books/1": true},
+		},
+		{
+			name: "binding indexes",
+			opts: []runtime.RegisterOption{runtime.WithBindingIndexes(0)},
+			want: map[string]bool{"GET /v1/books/1": true, "DELETE /v1/books/1": true},
+		},
+		{
+			name: "visibility selectors",
+			opts: []runtime.RegisterOption{runtime.WithVisibilitySelectors("PUBLIC")},
+			want: map[string]bool{"GET /v1/books/1": true, "GET /books/1": true},
+		},
+		{
+			name: "restriction selectors",
+			opts: []runtime.RegisterOption{runtime.WithVisibilitySelectors("PREVIEW")},
+			want: map[string]bool{"GET /v1/books/1": true, "GET /books/1": true, "DELETE /v1/books/1": true},
+		},
+		{
+			name: "path prefix",
+			opts: []runtime.RegisterOption{runtime.WithPathPrefix("/admin/")},
+			want: map[string]bool{"GET /admin/v1/books/1": true, "GET /admin/books/1": true, "DELETE /admin/v1/books/1": true},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			mux := runtime.NewServeMux()
+			registerLibrary(mux, spec.opts...)
+			for _, req := range []string{
+				"GET /v1/books/1", "GET /books/1", "DELETE /v1/books/1",
+				"GET /admin/v1/books/1", "GET /admin/books/1", "DELETE /admin/v1/books/1",
+			} {
+				method, path, _ := strings.Cut(req, " ")
+				w := httptest.NewRecorder()
+				mux.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+				if got, want := w.Code == http.StatusOK, spec.want[req]; got != want {
+					t.Errorf("%s status = %d; want registered %t", req, w.Code, want)
+					continue
+				}
+				if !spec.want[req] {
+					continue
+				}
+				if got, want := w.Header().Get("name"), "1"; got != want {
+					t.Errorf("%s name = %q; want %q", req, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestServeMuxHandleBindingVisibilityMissingDescriptor(t *testing.T) {
+	pattern := runtime.MustPattern(runtime.NewPattern(1, []int{
+		int(utilities.OpLitPush), 0,
+	}, []string{"missing"}, ""))
+	for _, spec := range []struct {
+		name string
+		opts []runtime.RegisterOption
+		want int
+	}{
+		{
+			name: "without selectors",
+			want: http.StatusOK,
+		},
+		{
+			name: "with selectors",
+			opts: []runtime.RegisterOption{runtime.WithVisibilitySelectors("PUBLIC")},
+			want: http.StatusNotFound,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			mux := runtime.NewServeMux()
+			mux.HandleBinding(http.MethodGet, pattern, "/register.test.Missing/Get", 0, func(http.ResponseWriter, *http.Request, map[string]string) {}, spec.opts...)
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing", nil))
+			if w.Code != spec.want {
+				t.Errorf("GET /missing status = %d; want %d", w.Code, spec.want)
+			}
+		})
+	}
+}
+
+func TestServeMuxHandleBindingHTTPPathPattern(t *testing.T) {
+	mux := runtime.NewServeMux()
+	registerLibrary(mux, runtime.WithPathPrefix("/admin"))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/v1/books/1", nil))
+	if got, want := w.Header().Get("pattern"), "/admin/v1/books/{name}"; got != want {
+		t.Errorf("runtime.HTTPPathPattern(ctx) = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,524 @@
+package runtime
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// RPCProtocol is a protocol by which a ServeMux serves gRPC methods, besides
+// the REST bindings of generated handlers.
+type RPCProtocol int
+
+const (
+	// RPCProtocolGRPCWeb serves gRPC-Web requests, sent with the
+	// "application/grpc-web", "application/grpc-web+proto" or
+	// "application/grpc-web+json" content type.
+	RPCProtocolGRPCWeb RPCProtocol = 1 << iota
+	// RPCProtocolConnect serves Connect requests: unary requests sent with
+	// the "application/proto" or "application/json" content type and the
+	// Connect-Protocol-Version header, and streaming requests sent with the
+	// "application/connect+proto" or "application/connect+json" content type.
+	RPCProtocolConnect
+)
+
+// WithRPCProtocols returns a ServeMuxOption which serves the given protocols
+// for the methods of services, or of all services if none is given. Requests
+// are matched by their "/package.Service/Method" path, under the base path
+// set by WithBasePath, and their content type, before the REST bindings.
+//
+// The methods are those registered by the generated Register*HandlerClient
+// functions, and their calls are forwarded to the client of the
+// registration, as for the REST bindings. Requests are annotated as by
+// AnnotateContext, reported to the Instrumentation and the AccessLogger of
+// the ServeMux like routed requests, and the metadata of responses is
+// filtered by the outgoing header and trailer matchers of the ServeMux.
+// Errors are converted to a status by the stream error handler.
+//
+// Messages are encoded in the protobuf binary format, or in JSON by the
+// marshaler registered for "application/json", or else for MIMEWildcard.
+// Compressed messages are not supported.
+func WithRPCProtocols(protocols RPCProtocol, services ...string) ServeMuxOption {
+	return func(mux *ServeMux) {
+		rpc := &rpcServer{protocols: protocols, methods: make(map[string]RPCClientMethod)}
+		if len(services) > 0 {
+			rpc.services = make(map[string]bool, len(services))
+			for _, service := range services {
+				rpc.services[service] = true
+			}
+		}
+		mux.rpcServer = rpc
+	}
+}
+
+// rpcServer serves the methods of gRPC services over the protocols set by
+// WithRPCProtocols.
+type rpcServer struct {
+	protocols RPCProtocol
+	// services restricts the services which are served, unless nil.
+	services map[string]bool
+	// methods maps the full names of the methods registered by HandleRPC to
+	// their client.
+	methods map[string]RPCClientMethod
+}
+
+// RPCClientMethod forwards the calls of a method served with the protocols set by
+// WithRPCProtocols to a gRPC client. It is built from a method of the client
+// by UnaryRPC, ServerStreamingRPC, ClientStreamingRPC or BidiStreamingRPC.
+type RPCClientMethod struct {
+	name                         string
+	clientStreams, serverStreams bool
+	newRequest                   func() proto.Message
+	// unary calls a unary method.
+	unary func(ctx context.Context, req proto.Message, opts ...grpc.CallOption) (proto.Message, error)
+	// stream starts a call of a streaming method. req is nil for methods
+	// streaming their requests.
+	stream func(ctx context.Context, req proto.Message) (*rpcClientStream, error)
+}
+
+// rpcClientStream is a call of a streaming method, sending and receiving
+// messages of the types of the method.
+type rpcClientStream struct {
+	grpc.ClientStream
+	send func(proto.Message) error
+	recv func() (proto.Message, error)
+}
+
+// UnaryRPC returns the RPCClientMethod of the unary method "call" of a client, such
+// as "client.GetBook".
+func UnaryRPC[Req any, PReq interface {
+	*Req
+	proto.Message
+}, Resp proto.Message](call func(context.Context, PReq, ...grpc.CallOption) (Resp, error)) RPCClientMethod {
+	return RPCClientMethod{
+		newRequest: func() proto.Message { return PReq(new(Req)) },
+		unary: func(ctx context.Context, req proto.Message, opts ...grpc.CallOption) (proto.Message, error) {
+			return call(ctx, req.(PReq), opts...)
+		},
+	}
+}
+
+// ServerStreamingRPC returns the RPCClientMethod of the server-streaming method
+// "call" of a client.
+func ServerStreamingRPC[Req any, PReq interface {
+	*Req
+	proto.Message
+}, Resp proto.Message, Stream interface {
+	grpc.ClientStream
+	Recv() (Resp, error)
+}](call func(context.Context, PReq, ...grpc.CallOption) (Stream, error)) RPCClientMethod {
+	return RPCClientMethod{
+		serverStreams: true,
+		newRequest:    func() proto.Message { return PReq(new(Req)) },
+		stream: func(ctx context.Context, req proto.Message) (*rpcClientStream, error) {
+			stream, err := call(ctx, req.(PReq))
+			if err != nil {
+				return nil, err
+			}
+			return &rpcClientStream{
+				ClientStream: stream,
+				recv:         func() (proto.Message, error) { return stream.Recv() },
+			}, nil
+		},
+	}
+}
+
+// ClientStreamingRPC returns the RPCClientMethod of the client-streaming method
+// "call" of a client.
+func ClientStreamingRPC[Req any, PReq interface {
+	*Req
+	proto.Message
+}, Resp any, PResp interface {
+	*Resp
+	proto.Message
+}, Stream interface {
+	grpc.ClientStream
+	Send(PReq) error
+	CloseAndRecv() (PResp, error)
+}](call func(context.Context, ...grpc.CallOption) (Stream, error)) RPCClientMethod {
+	return RPCClientMethod{
+		clientStreams: true,
+		newRequest:    func() proto.Message { return PReq(new(Req)) },
+		stream: func(ctx context.Context, _ proto.Message) (*rpcClientStream, error) {
+			stream, err := call(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return &rpcClientStream{
+				ClientStream: stream,
+				send:         func(msg proto.Message) error { return stream.Send(msg.(PReq)) },
+				// The response is received while the requests are sent, as
+				// the method may fail before the last one.
+				recv: func() (proto.Message, error) {
+					resp := PResp(new(Resp))
+					if err := stream.RecvMsg(resp); err != nil {
+						return nil, err
+					}
+					return resp, nil
+				},
+			}, nil
+		},
+	}
+}
+
+// BidiStreamingRPC returns the RPCClientMethod of the bidirectional streaming
+// method "call" of a client.
+func BidiStreamingRPC[Req any, PReq interface {
+	*Req
+	proto.Message
+}, Resp proto.Message, Stream interface {
+	grpc.ClientStream
+	Send(PReq) error
+	Recv() (Resp, error)
+}](call func(context.Context, ...grpc.CallOption) (Stream, error)) RPCClientMethod {
+	return RPCClientMethod{
+		clientStreams: true,
+		serverStreams: true,
+		newRequest:    func() proto.Message { return PReq(new(Req)) },
+		stream: func(ctx context.Context, _ proto.Message) (*rpcClientStream, error) {
+			stream, err := call(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return &rpcClientStream{
+				ClientStream: stream,
+				send:         func(msg proto.Message) error { return stream.Send(msg.(PReq)) },
+				recv:         func() (proto.Message, error) { return stream.Recv() },
+			}, nil
+		},
+	}
+}
+
+// HandleRPC serves the method "rpcMethod", such as
+// "/library.v1.LibraryService/GetBook", with the protocols set by
+// WithRPCProtocols, unless the method is filtered out by "opts". The calls
+// are forwarded to "method". It is called by the generated
+// Register*HandlerClient functions.
+func (s *ServeMux) HandleRPC(rpcMethod string, method RPCClientMethod, opts ...RegisterOption) {
+	if s.rpcServer == nil {
+		return
+	}
+	var o registerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.registersMethod(rpcMethod) {
+		return
+	}
+	method.name = rpcMethod
+	s.rpcServer.methods[rpcMethod] = method
+}
+
+// rpcCodec encodes the messages of an RPC.
+type rpcCodec struct {
+	marshal   func(v proto.Message) ([]byte, error)
+	unmarshal func(data []byte, v proto.Message) error
+}
+
+func (s *ServeMux) rpcCodec(name string) rpcCodec {
+	if name == "json" {
+		m, ok := s.marshalers.mimeMap["application/json"]
+		if !ok {
+			m = s.marshalers.mimeMap[MIMEWildcard]
+		}
+		return rpcCodec{
+			marshal:   func(v proto.Message) ([]byte, error) { return m.Marshal(v) },
+			unmarshal: func(data []byte, v proto.Message) error { return m.Unmarshal(data, v) },
+		}
+	}
+	return rpcCodec{marshal: proto.Marshal, unmarshal: proto.Unmarshal}
+}
+
+// serveRPC serves r if it is a request of one of the protocols set by
+// WithRPCProtocols, and reports whether it did. path is the path of r below
+// the base path of the ServeMux.
+func (s *ServeMux) serveRPC(w http.ResponseWriter, r *http.Request, path string) bool {
+	if r.Method != http.MethodPost {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	var serve func(http.ResponseWriter, *http.Request, RPCClientMethod, string)
+	switch protocols := s.rpcServer.protocols; {
+	case protocols&RPCProtocolGRPCWeb != 0 && strings.HasPrefix(mediaType, "application/grpc-web"):
+		codec, ok := strings.CutPrefix(strings.TrimPrefix(mediaType, "application/grpc-web"), "+")
+		if !ok && codec == "" {
+			codec = "proto"
+		}
+		if codec != "proto" && codec != "json" {
+			return false
+		}
+		serve = func(w http.ResponseWriter, r *http.Request, method RPCClientMethod, contentType string) {
+			s.serveGRPCWeb(w, r, method, contentType, s.rpcCodec(codec))
+		}
+	case protocols&RPCProtocolConnect != 0 && (mediaType == "application/connect+proto" || mediaType == "application/connect+json"):
+		codec := strings.TrimPrefix(mediaType, "application/connect+")
+		serve = func(w http.ResponseWriter, r *http.Request, method RPCClientMethod, contentType string) {
+			s.serveConnectStream(w, r, method, contentType, s.rpcCodec(codec))
+		}
+	case protocols&RPCProtocolConnect != 0 && (mediaType == "application/proto" || mediaType == "application/json") && r.Header.Get(connectProtocolVersion) != "":
+		codec := strings.TrimPrefix(mediaType, "application/")
+		serve = func(w http.ResponseWriter, r *http.Request, method RPCClientMethod, contentType string) {
+			s.serveConnectUnary(w, r, method, contentType, s.rpcCodec(codec))
+		}
+	default:
+		return false
+	}
+
+	method, ok := s.rpcServer.lookup(path)
+	if !ok {
+		return false
+	}
+	if s.instrumentation == nil {
+		serve(w, r, method, mediaType)
+		return true
+	}
+	s.instrumentRequest(w, r, func(w http.ResponseWriter, r *http.Request) {
+		serve(w, r, method, mediaType)
+	})
+	return true
+}
+
+// lookup returns the method served at path, of the form
+// "/package.Service/Method".
+func (rpc *rpcServer) lookup(path string) (RPCClientMethod, bool) {
+	service, _, ok := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if !ok || rpc.services != nil && !rpc.services[service] {
+		return RPCClientMethod{}, false
+	}
+	method, ok := rpc.methods[path]
+	return method, ok
+}
+
+// rpcCall is a call of a method forwarded to the backend. Request messages are
+// read by recv, which returns io.EOF after the last one. onHeader is called
+// with the header metadata of the response before its first message is passed
+// to send.
+type rpcCall struct {
+	method   RPCClientMethod
+	recv     func(proto.Message) error
+	onHeader func(metadata.MD)
+	send     func(proto.Message) error
+}
+
+// invoke forwards call to the backend, and returns the trailer metadata of
+// the response with the error ending the call.
+func (rpc *rpcServer) invoke(ctx context.Context, call rpcCall) (metadata.MD, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	method := call.method
+	tracker := requestTrackerFromContext(ctx)
+	if !method.clientStreams {
+		req := method.newRequest()
+		if err := call.recv(req); errors.Is(err, io.EOF) {
+			return nil, status.Error(codes.InvalidArgument, "missing request message")
+		} else if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid request message: %v", err)
+		}
+		tracker.add(func(s *RequestStats) { s.MessagesReceived++ })
+		if !method.serverStreams {
+			var header, trailer metadata.MD
+			resp, err := method.unary(ctx, req, grpc.Header(&header), grpc.Trailer(&trailer))
+			call.onHeader(header)
+			if err != nil {
+				return trailer, err
+			}
+			if err := call.send(resp); err != nil {
+				return trailer, err
+			}
+			tracker.messageSent()
+			return trailer, nil
+		}
+		stream, err := method.stream(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		err = rpc.receive(stream, call, cancel)
+		return stream.Trailer(), err
+	}
+
+	stream, err := method.stream(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Requests are sent concurrently with the responses received, so that
+	// bidirectional streams may interleave them. An error reading them is
+	// sent to sendErr before the call is canceled. The sender is not waited
+	// for, as it may be blocked reading requests which the client has not
+	// finished sending when the backend ends the call.
+	sendErr := make(chan error, 1)
+	go func() {
+		for {
+			req := method.newRequest()
+			err := call.recv(req)
+			if errors.Is(err, io.EOF) {
+				if err := stream.CloseSend(); err != nil {
+					grpclog.Errorf("Failed to close the request stream: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				sendErr <- status.Errorf(codes.InvalidArgument, "invalid request message: %v", err)
+				cancel()
+				return
+			}
+			tracker.add(func(s *RequestStats) { s.MessagesReceived++ })
+			if err := stream.send(req); err != nil {
+				// The error ending the call is returned by RecvMsg.
+				return
+			}
+		}
+	}()
+
+	err = rpc.receive(stream, call, cancel)
+	select {
+	case err := <-sendErr:
+		return stream.Trailer(), err
+	default:
+		return stream.Trailer(), err
+	}
+}
+
+// receive passes the responses of stream to call, until the end of the call.
+// cancel is called if call fails to send a response.
+func (rpc *rpcServer) receive(stream *rpcClientStream, call rpcCall, cancel context.CancelFunc) error {
+	header, err := stream.Header()
+	if err != nil {
+		return err
+	}
+	call.onHeader(header)
+	tracker := requestTrackerFromContext(stream.Context())
+	for {
+		resp, err := stream.recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := call.send(resp); err != nil {
+			cancel()
+			return err
+		}
+		tracker.messageSent()
+	}
+}
+
+// rpcHeaderMetadata sets the response headers of w from the header metadata
+// of the backend, filtered by the outgoing header matcher.
+func (s *ServeMux) rpcHeaderMetadata(w http.ResponseWriter, md metadata.MD) {
+	handleForwardResponseServerMetadata(w, s, ServerMetadata{HeaderMD: md}, false)
+}
+
+// rpcTrailerMetadata returns the trailer metadata of the backend, filtered by
+// the outgoing trailer matcher, with lowercase keys. Binary values are encoded
+// in unpadded base64.
+func (s *ServeMux) rpcTrailerMetadata(md metadata.MD) map[string][]string {
+	trailers := make(map[string][]string, len(md))
+	for k, vs := range md {
+		h, ok := s.outgoingTrailerMatcher(k)
+		if !ok {
+			continue
+		}
+		h = strings.ToLower(h)
+		for _, v := range vs {
+			if strings.HasSuffix(h, "-bin") {
+				v = base64.RawStdEncoding.EncodeToString([]byte(v))
+			}
+			trailers[h] = append(trailers[h], v)
+		}
+	}
+	return trailers
+}
+
+// Messages of gRPC-Web and Connect streams are framed by a flags byte and a
+// 32-bit big-endian length.
+const rpcFrameHeaderSize = 5
+
+// readRPCFrame reads a frame from r. It returns io.EOF if r ends before the
+// frame.
+func readRPCFrame(r *bufio.Reader) (flags byte, data []byte, err error) {
+	var header [rpcFrameHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, nil, err
+	}
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > maxDelimitedMessageSize {
+		return 0, nil, fmt.Errorf("message of %d bytes exceeds the limit of %d bytes", size, maxDelimitedMessageSize)
+	}
+	data, err = readSizedBytes(r, uint64(size))
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	return header[0], data, err
+}
+
+// appendRPCFrame appends a frame holding data to b.
+func appendRPCFrame(b []byte, flags byte, data []byte) []byte {
+	b = append(b, flags)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(data)))
+	return append(b, data...)
+}
+
+// rpcFrameReader returns a function reading the request messages of a
+// gRPC-Web or Connect stream from r.
+func rpcFrameReader(r io.Reader, codec rpcCodec) func(proto.Message) error {
+	br := bufio.NewReader(r)
+	return func(msg proto.Message) error {
+		flags, data, err := readRPCFrame(br)
+		if err != nil {
+			return err
+		}
+		if flags&rpcFrameCompressed != 0 {
+			return errors.New("compressed messages are not supported")
+		}
+		return codec.unmarshal(data, msg)
+	}
+}
+
+// rpcFrameCompressed is the flag of compressed messages, in both gRPC-Web and
+// Connect frames.
+const rpcFrameCompressed = 0x01
+
+// writeRPCFrame writes and flushes a frame.
+func writeRPCFrame(w http.ResponseWriter, flags byte, data []byte) error {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	*buf = appendRPCFrame((*buf)[:0], flags, data)
+	if _, err := w.Write(*buf); err != nil {
+		return err
+	}
+	return http.NewResponseController(w).Flush()
+}
+
+// annotateRPCContext annotates the context of an RPC request as
+// AnnotateContext does, and enables full-duplex HTTP/1.1 for streams. The
+// context of r is returned with an error.
+func (s *ServeMux) annotateRPCContext(w http.ResponseWriter, r *http.Request, method RPCClientMethod) (context.Context, error) {
+	if method.clientStreams {
+		// Errors are ignored, as HTTP/2 is always full-duplex.
+		_ = http.NewResponseController(w).EnableFullDuplex()
+	}
+	ctx, err := AnnotateContext(r.Context(), s, r, method.name)
+	if err != nil {
+		return r.Context(), err
+	}
+	return ctx, nil
+}
@@ -0,0 +1,215 @@
+package runtime
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	connectProtocolVersion = "Connect-Protocol-Version"
+	connectTimeout         = "Connect-Timeout-Ms"
+	// connectTrailerPrefix prefixes the trailers of unary responses, which
+	// are sent as headers.
+	connectTrailerPrefix = "Trailer-"
+)
+
+// connectFrameEndStream is the flag of the frame which ends a Connect stream.
+const connectFrameEndStream = 0x02
+
+// connectError is the JSON representation of an error in the Connect protocol.
+type connectError struct {
+	Code    string                `json:"code"`
+	Message string                `json:"message,omitempty"`
+	Details []connectErrorDetails `json:"details,omitempty"`
+}
+
+type connectErrorDetails struct {
+	// Type is the fully-qualified name of the message, without a type URL prefix.
+	Type string `json:"type"`
+	// Value is the message in the protobuf binary format, in unpadded base64.
+	Value string `json:"value"`
+}
+
+// connectEndStream is the message of the frame ending a Connect stream.
+type connectEndStream struct {
+	Error    *connectError       `json:"error,omitempty"`
+	Metadata map[string][]string `json:"metadata,omitempty"`
+}
+
+func newConnectError(st *status.Status) *connectError {
+	e := &connectError{Code: connectCode(st.Code()), Message: st.Message()}
+	for _, detail := range st.Proto().GetDetails() {
+		typeName := detail.GetTypeUrl()
+		typeName = typeName[strings.LastIndexByte(typeName, '/')+1:]
+		e.Details = append(e.Details, connectErrorDetails{
+			Type:  typeName,
+			Value: base64.RawStdEncoding.EncodeToString(detail.GetValue()),
+		})
+	}
+	return e
+}
+
+// connectCode returns the name of code in the Connect protocol, such as
+// "not_found" for codes.NotFound.
+func connectCode(code codes.Code) string {
+	var sb strings.Builder
+	for i, r := range code.String() {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				sb.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// connectContext applies the timeout set by the Connect-Timeout-Ms header of
+// r to ctx.
+func connectContext(ctx context.Context, r *http.Request) (context.Context, context.CancelFunc, error) {
+	timeout := r.Header.Get(connectTimeout)
+	if timeout == "" {
+		return ctx, func() {}, nil
+	}
+	ms, err := strconv.ParseInt(timeout, 10, 64)
+	if err != nil || ms < 0 || len(timeout) > 10 {
+		return ctx, func() {}, status.Errorf(codes.InvalidArgument, "invalid %s header: %q", connectTimeout, timeout)
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(ms)*time.Millisecond)
+	return ctx, cancel, nil
+}
+
+// serveConnectUnary serves a unary Connect request. The message, or the error,
+// is the whole body of the response, and trailers are sent as headers
+// prefixed with "Trailer-".
+func (s *ServeMux) serveConnectUnary(w http.ResponseWriter, r *http.Request, method RPCClientMethod, contentType string, codec rpcCodec) {
+	ctx, err := s.annotateRPCContext(w, r, method)
+	var resp proto.Message
+	var trailer metadata.MD
+	if err == nil && (method.clientStreams || method.serverStreams) {
+		err = status.Errorf(codes.Unimplemented, "method %s is a streaming method", method.name)
+	}
+	if err == nil {
+		var cancel context.CancelFunc
+		ctx, cancel, err = connectContext(ctx, r)
+		defer cancel()
+	}
+	if err == nil {
+		var read bool
+		trailer, err = s.rpcServer.invoke(ctx, rpcCall{
+			method: method,
+			recv: func(msg proto.Message) error {
+				if read {
+					return io.EOF
+				}
+				read = true
+				data, err := io.ReadAll(r.Body)
+				if err != nil {
+					return err
+				}
+				return codec.unmarshal(data, msg)
+			},
+			onHeader: func(md metadata.MD) {
+				s.rpcHeaderMetadata(w, md)
+			},
+			send: func(msg proto.Message) error {
+				resp = msg
+				return nil
+			},
+		})
+	}
+
+	for k, vs := range s.rpcTrailerMetadata(trailer) {
+		for _, v := range vs {
+			w.Header().Add(connectTrailerPrefix+k, v)
+		}
+	}
+	var body []byte
+	if err == nil {
+		if body, err = codec.marshal(resp); err == nil {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(http.StatusOK)
+		}
+	}
+	if err != nil {
+		st := s.streamErrorHandler(ctx, err)
+		if body, err = json.Marshal(newConnectError(st)); err != nil {
+			grpclog.Errorf("Failed to marshal a Connect error: %v", err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(HTTPStatusFromCode(st.Code()))
+	}
+	if _, err := w.Write(body); err != nil {
+		grpclog.Errorf("Failed to send a Connect response: %v", err)
+	}
+}
+
+// serveConnectStream serves a streaming Connect request. Responses always have
+// the 200 status; the error ending the call, if any, and the trailers are
+// sent in the last frame.
+func (s *ServeMux) serveConnectStream(w http.ResponseWriter, r *http.Request, method RPCClientMethod, contentType string, codec rpcCodec) {
+	var trailer metadata.MD
+	var wroteHeader bool
+	writeHeader := func() {
+		if !wroteHeader {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(http.StatusOK)
+			wroteHeader = true
+		}
+	}
+	ctx, err := s.annotateRPCContext(w, r, method)
+	if err == nil {
+		var cancel context.CancelFunc
+		ctx, cancel, err = connectContext(ctx, r)
+		defer cancel()
+	}
+	if err == nil {
+		trailer, err = s.rpcServer.invoke(ctx, rpcCall{
+			method: method,
+			recv:   rpcFrameReader(r.Body, codec),
+			onHeader: func(md metadata.MD) {
+				s.rpcHeaderMetadata(w, md)
+				writeHeader()
+			},
+			send: func(msg proto.Message) error {
+				data, err := codec.marshal(msg)
+				if err != nil {
+					return err
+				}
+				return writeRPCFrame(w, 0, data)
+			},
+		})
+	}
+	writeHeader()
+
+	end := connectEndStream{Metadata: s.rpcTrailerMetadata(trailer)}
+	if len(end.Metadata) == 0 {
+		end.Metadata = nil
+	}
+	if err != nil {
+		end.Error = newConnectError(s.streamErrorHandler(ctx, err))
+	}
+	data, err := json.Marshal(end)
+	if err != nil {
+		grpclog.Errorf("Failed to marshal a Connect end of stream: %v", err)
+		return
+	}
+	if err := writeRPCFrame(w, connectFrameEndStream, data); err != nil {
+		grpclog.Errorf("Failed to send a Connect end of stream: %v", err)
+	}
+}
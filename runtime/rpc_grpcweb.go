@@ -0,0 +1,98 @@
+package runtime
+
+import (
+	"encoding/base64"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// grpcWebFrameTrailer is the flag of the frame holding the trailers which
+// ends a gRPC-Web response.
+const grpcWebFrameTrailer = 0x80
+
+// serveGRPCWeb serves a gRPC-Web request. Responses always have the 200
+// status; the status of the call is sent in the trailer frame.
+func (s *ServeMux) serveGRPCWeb(w http.ResponseWriter, r *http.Request, method RPCClientMethod, contentType string, codec rpcCodec) {
+	var st *status.Status
+	var trailer metadata.MD
+	ctx, err := s.annotateRPCContext(w, r, method)
+	if err == nil {
+		var wroteHeader bool
+		writeHeader := func() {
+			if !wroteHeader {
+				w.Header().Set("Content-Type", contentType)
+				w.WriteHeader(http.StatusOK)
+				wroteHeader = true
+			}
+		}
+		trailer, err = s.rpcServer.invoke(ctx, rpcCall{
+			method: method,
+			recv:   rpcFrameReader(r.Body, codec),
+			onHeader: func(md metadata.MD) {
+				s.rpcHeaderMetadata(w, md)
+				writeHeader()
+			},
+			send: func(msg proto.Message) error {
+				data, err := codec.marshal(msg)
+				if err != nil {
+					return err
+				}
+				return writeRPCFrame(w, 0, data)
+			},
+		})
+		writeHeader()
+	} else {
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusOK)
+	}
+	if err != nil {
+		st = s.streamErrorHandler(ctx, err)
+	} else {
+		st = status.New(codes.OK, "")
+	}
+
+	if err := writeRPCFrame(w, grpcWebFrameTrailer, s.grpcWebTrailers(st, trailer)); err != nil {
+		grpclog.Errorf("Failed to send gRPC-Web trailers: %v", err)
+	}
+}
+
+// grpcWebTrailers encodes the trailer frame of a gRPC-Web response, as HTTP/1
+// headers with lowercase names.
+func (s *ServeMux) grpcWebTrailers(st *status.Status, md metadata.MD) []byte {
+	var b []byte
+	appendHeader := func(k, v string) {
+		b = append(b, k...)
+		b = append(b, ": "...)
+		b = append(b, v...)
+		b = append(b, "\r\n"...)
+	}
+	appendHeader("grpc-status", strconv.Itoa(int(st.Code())))
+	if st.Message() != "" {
+		appendHeader("grpc-message", encodeGrpcMessage(st.Message()))
+	}
+	if details := st.Proto().GetDetails(); len(details) > 0 {
+		data, err := proto.Marshal(st.Proto())
+		if err == nil {
+			appendHeader("grpc-status-details-bin", base64.RawStdEncoding.EncodeToString(data))
+		}
+	}
+	trailers := s.rpcTrailerMetadata(md)
+	keys := make([]string, 0, len(trailers))
+	for k := range trailers {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		for _, v := range trailers[k] {
+			appendHeader(k, v)
+		}
+	}
+	return b
+}
@@ -0,0 +1,408 @@
+package runtime_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// newHealthConn serves the health service on an in-process connection. The
+// "foo" request metadata is echoed in the "x-echo" header and trailer of
+// unary responses.
+func newHealthConn(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if foo := md.Get("foo"); len(foo) > 0 {
+			if err := grpc.SetHeader(ctx, metadata.Pairs("x-echo", foo[0])); err != nil {
+				return nil, err
+			}
+			if err := grpc.SetTrailer(ctx, metadata.Pairs("x-echo", foo[0])); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}))
+	hs := health.NewServer()
+	hs.SetServingStatus("foo", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, hs)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() failed with %v; want success", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// registerHealthRPC serves the health service over conn with the RPC protocols
+// of mux, as the generated Register*HandlerClient functions do.
+func registerHealthRPC(mux *runtime.ServeMux, conn grpc.ClientConnInterface, opts ...runtime.RegisterOption) {
+	client := healthpb.NewHealthClient(conn)
+	mux.HandleRPC("/grpc.health.v1.Health/Check", runtime.UnaryRPC(client.Check), opts...)
+	mux.HandleRPC("/grpc.health.v1.Health/Watch", runtime.ServerStreamingRPC(client.Watch), opts...)
+}
+
+type rpcFrame struct {
+	flags byte
+	data  string
+}
+
+func rpcFrames(t *testing.T, body []byte) []rpcFrame {
+	t.Helper()
+	var frames []rpcFrame
+	for len(body) > 0 {
+		if len(body) < 5 {
+			t.Fatalf("truncated frame header %x", body)
+		}
+		size := binary.BigEndian.Uint32(body[1:5])
+		if uint32(len(body)-5) < size {
+			t.Fatalf("truncated frame %x", body)
+		}
+		frames = append(frames, rpcFrame{flags: body[0], data: string(body[5 : 5+size])})
+		body = body[5+size:]
+	}
+	return frames
+}
+
+func rpcRequestFrame(t *testing.T, msg proto.Message) []byte {
+	t.Helper()
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatalf("proto.Marshal(%v) failed with %v; want success", msg, err)
+	}
+	frame := binary.BigEndian.AppendUint32([]byte{0}, uint32(len(data)))
+	return append(frame, data...)
+}
+
+func TestServeMuxGRPCWeb(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithRPCProtocols(runtime.RPCProtocolGRPCWeb))
+	registerHealthRPC(mux, newHealthConn(t))
+
+	for _, spec := range []struct {
+		name         string
+		service      string
+		wantMessage  proto.Message
+		wantTrailers string
+	}{
+		{
+			name:         "success",
+			service:      "foo",
+			wantMessage:  &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING},
+			wantTrailers: "grpc-status: 0\r\ngrpc-trailer-x-echo: bar\r\n",
+		},
+		{
+			name:         "error",
+			service:      "unknown",
+			wantTrailers: "grpc-status: 5\r\ngrpc-message: unknown service\r\ngrpc-trailer-x-echo: bar\r\n",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			body := rpcRequestFrame(t, &healthpb.HealthCheckRequest{Service: spec.service})
+			req := httptest.NewRequest(http.MethodPost, "/grpc.health.v1.Health/Check", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/grpc-web+proto")
+			req.Header.Set("Grpc-Metadata-Foo", "bar")
+			resp := httptest.NewRecorder()
+			mux.ServeHTTP(resp, req)
+
+			if got, want := resp.Code, http.StatusOK; got != want {
+				t.Errorf("resp.Code = %d; want %d", got, want)
+			}
+			if got, want := resp.Header().Get("Content-Type"), "application/grpc-web+proto"; got != want {
+				t.Errorf("Content-Type = %q; want %q", got, want)
+			}
+			var want []rpcFrame
+			if spec.wantMessage != nil {
+				if got, want := resp.Header().Get("Grpc-Metadata-X-Echo"), "bar"; got != want {
+					t.Errorf("Grpc-Metadata-X-Echo = %q; want %q", got, want)
+				}
+				data, err := proto.Marshal(spec.wantMessage)
+				if err != nil {
+					t.Fatalf("proto.Marshal(%v) failed with %v; want success", spec.wantMessage, err)
+				}
+				want = append(want, rpcFrame{data: string(data)})
+			}
+			want = append(want, rpcFrame{flags: 0x80, data: spec.wantTrailers})
+			got := rpcFrames(t, resp.Body.Bytes())
+			if len(got) != len(want) {
+				t.Fatalf("frames = %q; want %q", got, want)
+			}
+			for i := range got {
+				if got[i] != want[i] {
+					t.Errorf("frame %d = %q; want %q", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestServeMuxConnectUnary(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithRPCProtocols(runtime.RPCProtocolConnect))
+	registerHealthRPC(mux, newHealthConn(t))
+
+	for _, spec := range []struct {
+		name     string
+		body     string
+		wantCode int
+		wantBody string
+	}{
+		{
+			name:     "success",
+			body:     `{"service":"foo"}`,
+			wantCode: http.StatusOK,
+			wantBody: `{"status":"SERVING"}`,
+		},
+		{
+			name:     "error",
+			body:     `{"service":"unknown"}`,
+			wantCode: http.StatusNotFound,
+			wantBody: `{"code":"not_found","message":"unknown service"}`,
+		},
+		{
+			name:     "invalid request",
+			body:     `{"service":`,
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"code":"invalid_argument"`,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/grpc.health.v1.Health/Check", strings.NewReader(spec.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Connect-Protocol-Version", "1")
+			req.Header.Set("Grpc-Metadata-Foo", "bar")
+			resp := httptest.NewRecorder()
+			mux.ServeHTTP(resp, req)
+
+			if resp.Code != spec.wantCode {
+				t.Errorf("resp.Code = %d; want %d", resp.Code, spec.wantCode)
+			}
+			if got := compactJSON(t, resp.Body.Bytes()); !strings.HasPrefix(got, spec.wantBody) {
+				t.Errorf("resp.Body = %s; want %s", got, spec.wantBody)
+			}
+			if spec.wantCode == http.StatusOK {
+				if got, want := resp.Header().Get("Trailer-Grpc-Trailer-X-Echo"), "bar"; got != want {
+					t.Errorf("Trailer-Grpc-Trailer-X-Echo = %q; want %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestServeMuxConnectStream(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithRPCProtocols(runtime.RPCProtocolConnect))
+	registerHealthRPC(mux, newHealthConn(t))
+
+	body := rpcRequestFrame(t, &healthpb.HealthCheckRequest{Service: "foo"})
+	req := httptest.NewRequest(http.MethodPost, "/grpc.health.v1.Health/Watch", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/connect+proto")
+	// Watch streams until cancelled.
+	req.Header.Set("Connect-Timeout-Ms", "100")
+	resp := httptest.NewRecorder()
+	mux.ServeHTTP(resp, req)
+
+	if got, want := resp.Code, http.StatusOK; got != want {
+		t.Errorf("resp.Code = %d; want %d", got, want)
+	}
+	frames := rpcFrames(t, resp.Body.Bytes())
+	if len(frames) != 2 {
+		t.Fatalf("frames = %q; want a message and the end of the stream", frames)
+	}
+	var msg healthpb.HealthCheckResponse
+	if err := proto.Unmarshal([]byte(frames[0].data), &msg); err != nil {
+		t.Fatalf("proto.Unmarshal(%q) failed with %v; want success", frames[0].data, err)
+	}
+	if got, want := msg.GetStatus(), healthpb.HealthCheckResponse_SERVING; got != want {
+		t.Errorf("msg.Status = %v; want %v", got, want)
+	}
+	if got, want := frames[1].flags, byte(0x02); got != want {
+		t.Errorf("end of stream flags = %#x; want %#x", got, want)
+	}
+	if got, want := frames[1].data, `{"error":{"code":"deadline_exceeded"`; !strings.HasPrefix(got, want) {
+		t.Errorf("end of stream = %s; want prefix %s", got, want)
+	}
+}
+
+func TestServeMuxRPCProtocolsFallThrough(t *testing.T) {
+	for _, spec := range []struct {
+		name         string
+		opt          runtime.ServeMuxOption
+		registerOpts []runtime.RegisterOption
+		contentType  string
+		header       http.Header
+	}{
+		{
+			name:        "connect unary without protocol version",
+			opt:         runtime.WithRPCProtocols(runtime.RPCProtocolConnect),
+			contentType: "application/json",
+		},
+		{
+			name:        "protocol not enabled",
+			opt:         runtime.WithRPCProtocols(runtime.RPCProtocolConnect),
+			contentType: "application/grpc-web",
+		},
+		{
+			name:        "service not enabled",
+			opt:         runtime.WithRPCProtocols(runtime.RPCProtocolGRPCWeb, "grpc.reflection.v1.ServerReflection"),
+			contentType: "application/grpc-web",
+		},
+		{
+			name:         "method not registered",
+			opt:          runtime.WithRPCProtocols(runtime.RPCProtocolGRPCWeb),
+			registerOpts: []runtime.RegisterOption{runtime.WithoutMethods("Check")},
+			contentType:  "application/grpc-web",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			mux := runtime.NewServeMux(spec.opt)
+			registerHealthRPC(mux, newHealthConn(t), spec.registerOpts...)
+			req := httptest.NewRequest(http.MethodPost, "/grpc.health.v1.Health/Check", strings.NewReader("{}"))
+			req.Header.Set("Content-Type", spec.contentType)
+			resp := httptest.NewRecorder()
+			mux.ServeHTTP(resp, req)
+
+			if got, want := resp.Code, http.StatusNotFound; got != want {
+				body, _ := io.ReadAll(resp.Body)
+				t.Errorf("resp.Code = %d; want %d, body %s", got, want, body)
+			}
+		})
+	}
+}
+
+// recordingInstrumentation records the last request reported to it.
+type recordingInstrumentation struct {
+	rpcMethod string
+	stats     *runtime.RequestStats
+}
+
+func (i *recordingInstrumentation) StartRequest(ctx context.Context, _ *http.Request) (context.Context, runtime.RequestInstrumentation) {
+	return ctx, i
+}
+
+func (i *recordingInstrumentation) Annotate(_ context.Context, rpcMethodName, _ string) metadata.MD {
+	i.rpcMethod = rpcMethodName
+	return nil
+}
+
+func (i *recordingInstrumentation) End(_ context.Context, stats runtime.RequestStats) {
+	i.stats = &stats
+}
+
+func TestServeMuxRPCProtocolsBasePath(t *testing.T) {
+	inst := &recordingInstrumentation{}
+	var entry runtime.AccessLogEntry
+	mux := runtime.NewServeMux(
+		runtime.WithBasePath("/api"),
+		runtime.WithRPCProtocols(runtime.RPCProtocolConnect),
+		runtime.WithInstrumentation(inst),
+		runtime.WithAccessLogger(func(_ context.Context, e runtime.AccessLogEntry) { entry = e }),
+	)
+	registerHealthRPC(mux, newHealthConn(t))
+
+	for _, spec := range []struct {
+		path     string
+		wantCode int
+	}{
+		{path: "/grpc.health.v1.Health/Check", wantCode: http.StatusNotFound},
+		{path: "/api/grpc.health.v1.Health/Check", wantCode: http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodPost, spec.path, strings.NewReader(`{"service":"foo"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Connect-Protocol-Version", "1")
+		resp := httptest.NewRecorder()
+		mux.ServeHTTP(resp, req)
+
+		if resp.Code != spec.wantCode {
+			t.Errorf("POST %s: resp.Code = %d; want %d", spec.path, resp.Code, spec.wantCode)
+		}
+	}
+
+	if got, want := inst.rpcMethod, "/grpc.health.v1.Health/Check"; got != want {
+		t.Errorf("instrumented rpcMethodName = %q; want %q", got, want)
+	}
+	if inst.stats == nil {
+		t.Fatal("RequestInstrumentation.End() was not called")
+	}
+	if got, want := *inst.stats, (runtime.RequestStats{
+		HTTPStatus:        http.StatusOK,
+		RequestSize:       int64(len(`{"service":"foo"}`)),
+		ResponseSize:      inst.stats.ResponseSize,
+		MessagesReceived:  1,
+		MessagesSent:      1,
+		UnmarshalDuration: inst.stats.UnmarshalDuration,
+		MarshalDuration:   inst.stats.MarshalDuration,
+	}); got != want {
+		t.Errorf("RequestStats = %+v; want %+v", got, want)
+	}
+	if got, want := entry.RPCMethod, "/grpc.health.v1.Health/Check"; got != want {
+		t.Errorf("AccessLogEntry.RPCMethod = %q; want %q", got, want)
+	}
+}
+
+func TestServeMuxRPCProtocolsBackendErrorBeforeRequestsEnd(t *testing.T) {
+	ch := runtime.NewInProcessChannel()
+	ch.RegisterService(&echoServiceDesc, nil)
+	mux := runtime.NewServeMux(runtime.WithRPCProtocols(runtime.RPCProtocolConnect))
+	mux.HandleRPC("/example.Echo/Upper", runtime.BidiStreamingRPC(func(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[wrapperspb.StringValue, wrapperspb.StringValue], error) {
+		stream, err := ch.NewStream(ctx, &echoServiceDesc.Streams[0], "/example.Echo/Upper", opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &grpc.GenericClientStream[wrapperspb.StringValue, wrapperspb.StringValue]{ClientStream: stream}, nil
+	}))
+
+	// The backend fails on the empty string, while the client keeps its
+	// request stream open.
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	go func() {
+		_, _ = pw.Write(rpcRequestFrame(t, wrapperspb.String("")))
+	}()
+	req := httptest.NewRequest(http.MethodPost, "/example.Echo/Upper", pr)
+	req.Header.Set("Content-Type", "application/connect+proto")
+	resp := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		mux.ServeHTTP(resp, req)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ServeHTTP() did not return after the backend failed")
+	}
+
+	frames := rpcFrames(t, resp.Body.Bytes())
+	if len(frames) != 1 {
+		t.Fatalf("frames = %q; want the end of the stream", frames)
+	}
+	if got, want := frames[0].data, `{"error":{"code":"unknown","message":"empty string"}}`; got != want {
+		t.Errorf("end of stream = %s; want %s", got, want)
+	}
+}
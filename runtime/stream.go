@@ -0,0 +1,234 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// MIMENDJSON is the MIME type of newline-delimited JSON streams, as sent
+	// by ForwardResponseStream with StreamFramingNDJSON.
+	MIMENDJSON = "application/x-ndjson"
+	// MIMEJSONSeq is the MIME type of JSON text sequences (RFC 7464), as sent
+	// by ForwardResponseStream with StreamFramingJSONSeq.
+	MIMEJSONSeq = "application/json-seq"
+)
+
+// jsonSeqRecordSeparator precedes each record of a JSON text sequence.
+const jsonSeqRecordSeparator = 0x1e
+
+// StreamFraming selects how ForwardResponseStream separates the records of a
+// response stream encoded by a JSON marshaler. Other marshalers, and streams
+// of google.api.HttpBody messages, always use the delimiter of the marshaler.
+type StreamFraming int
+
+const (
+	// StreamFramingDelimited ends each record with the Delimiter of the
+	// marshaler, "\n" by default, and sends the content type of the
+	// marshaler. This is the default.
+	StreamFramingDelimited StreamFraming = iota
+	// StreamFramingNDJSON sends each record on its own line, with the
+	// content type "application/x-ndjson".
+	StreamFramingNDJSON
+	// StreamFramingJSONSeq sends the records as a JSON text sequence
+	// (RFC 7464): each record is preceded by the record separator 0x1E and
+	// ends with "\n". The content type is "application/json-seq".
+	StreamFramingJSONSeq
+)
+
+// StreamEnvelope selects how ForwardResponseStream wraps the records of a
+// response stream. Marshalers implementing StreamMarshaler define their own
+// envelope.
+type StreamEnvelope int
+
+const (
+	// StreamEnvelopeResult sends each message as {"result": message}, and the
+	// error ending a stream as {"error": status}. This is the default.
+	StreamEnvelopeResult StreamEnvelope = iota
+	// StreamEnvelopeNone sends the messages as they are. The error ending a
+	// stream is sent as a final google.rpc.Status record encoded as a
+	// google.protobuf.Any, which carries
+	// "@type": "type.googleapis.com/google.rpc.Status" in JSON.
+	StreamEnvelopeNone
+)
+
+// WithStreamFraming returns a ServeMuxOption which sets how the records of
+// response streams encoded by JSON marshalers are separated.
+func WithStreamFraming(framing StreamFraming) ServeMuxOption {
+	return func(mux *ServeMux) {
+		mux.streamFraming = framing
+	}
+}
+
+// WithStreamEnvelope returns a ServeMuxOption which sets how the records of
+// response streams are wrapped.
+func WithStreamEnvelope(envelope StreamEnvelope) ServeMuxOption {
+	return func(mux *ServeMux) {
+		mux.streamEnvelope = envelope
+	}
+}
+
+// WithStreamErrorTrailers returns a ServeMuxOption which makes response
+// streams end with the Grpc-Status and Grpc-Message HTTP trailers, rather
+// than with an error record. Grpc-Status is "0" when a stream ends without
+// error, and Grpc-Message holds the percent-encoded message of the error.
+//
+// Clients must read the trailers, which HTTP/1.1 sends after the last chunk
+// of the body, to tell a complete stream from a failed one.
+func WithStreamErrorTrailers() ServeMuxOption {
+	return func(mux *ServeMux) {
+		mux.streamErrorTrailers = true
+	}
+}
+
+// streamFormat is the encoding of the records of a response stream.
+type streamFormat struct {
+	marshaler Marshaler
+	// streamMarshaler is set when the marshaler frames the records itself.
+	streamMarshaler StreamMarshaler
+	delimiter       []byte
+	framing         StreamFraming
+	envelope        StreamEnvelope
+	trailers        bool
+}
+
+func newStreamFormat(mux *ServeMux, marshaler Marshaler) *streamFormat {
+	f := &streamFormat{
+		marshaler: marshaler,
+		envelope:  mux.streamEnvelope,
+		trailers:  mux.streamErrorTrailers,
+	}
+	if sm, ok := asStreamMarshaler(marshaler); ok {
+		f.streamMarshaler = sm
+		return f
+	}
+	if d, ok := marshaler.(Delimited); ok {
+		f.delimiter = d.Delimiter()
+	} else {
+		f.delimiter = []byte("\n")
+	}
+	if isJSONContentType(marshaler.ContentType(nil)) {
+		f.framing = mux.streamFraming
+	}
+	return f
+}
+
+// isJSONContentType reports whether contentType is application/json or a
+// +json structured syntax.
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// contentType returns the Content-Type of a stream whose first record holds v.
+func (f *streamFormat) contentType(v interface{}) string {
+	switch f.framing {
+	case StreamFramingNDJSON:
+		return MIMENDJSON
+	case StreamFramingJSONSeq:
+		return MIMEJSONSeq
+	}
+	if sct, ok := f.marshaler.(StreamContentType); ok {
+		return sct.StreamContentType(v)
+	}
+	return f.marshaler.ContentType(v)
+}
+
+// marshalResult encodes the record of a message of the stream.
+func (f *streamFormat) marshalResult(result interface{}) ([]byte, error) {
+	switch {
+	case f.streamMarshaler != nil:
+		return f.streamMarshaler.MarshalStreamResult(result)
+	case f.envelope == StreamEnvelopeNone:
+		return f.marshaler.Marshal(result)
+	}
+	return f.marshaler.Marshal(map[string]interface{}{"result": result})
+}
+
+// marshalError encodes the record of the error ending the stream.
+func (f *streamFormat) marshalError(st *status.Status) ([]byte, error) {
+	switch {
+	case f.streamMarshaler != nil:
+		return f.streamMarshaler.MarshalStreamError(st)
+	case f.envelope == StreamEnvelopeNone:
+		record, err := anypb.New(st.Proto())
+		if err != nil {
+			return nil, err
+		}
+		return f.marshaler.Marshal(record)
+	}
+	return f.marshaler.Marshal(errorChunk(st))
+}
+
+// writeRecord writes an encoded record of the stream to w, framed as set by
+// WithStreamFraming.
+func (f *streamFormat) writeRecord(w io.Writer, buf []byte) error {
+	if f.streamMarshaler != nil {
+		_, err := w.Write(buf)
+		return err
+	}
+	if f.framing == StreamFramingDelimited {
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+		_, err := w.Write(f.delimiter)
+		return err
+	}
+
+	// Both framings end records with a newline, so a record must fit on one line.
+	record := getBuffer()
+	defer putBuffer(record)
+	b := (*record)[:0]
+	if f.framing == StreamFramingJSONSeq {
+		b = append(b, jsonSeqRecordSeparator)
+	}
+	if bytes.IndexByte(buf, '\n') >= 0 {
+		compacted := bytes.NewBuffer(b)
+		if err := json.Compact(compacted, buf); err != nil {
+			return err
+		}
+		b = compacted.Bytes()
+	} else {
+		b = append(b, buf...)
+	}
+	b = append(b, '\n')
+	*record = b
+	_, err := w.Write(b)
+	return err
+}
+
+// setStatusTrailers sets the Grpc-Status and Grpc-Message trailers of w to st.
+func setStatusTrailers(w http.ResponseWriter, st *status.Status) {
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(int(st.Code())))
+	if st.Code() != codes.OK && st.Message() != "" {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", encodeGrpcMessage(st.Message()))
+	}
+}
+
+// encodeGrpcMessage percent-encodes msg as gRPC does for the grpc-message
+// header: bytes outside printable ASCII, and '%', are escaped.
+func encodeGrpcMessage(msg string) string {
+	var sb strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c >= ' ' && c <= '~' && c != '%' {
+			sb.WriteByte(c)
+		} else {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,177 @@
+package runtime_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	pb "github.com/grpc-ecosystem/grpc-gateway/v2/runtime/internal/examplepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// streamOf returns a recv function yielding msgs, then err, or io.EOF if err
+// is nil.
+func streamOf(msgs []proto.Message, err error) func() (proto.Message, error) {
+	var count int
+	return func() (proto.Message, error) {
+		if count == len(msgs) {
+			if err == nil {
+				return nil, io.EOF
+			}
+			return nil, err
+		}
+		count++
+		return msgs[count-1], nil
+	}
+}
+
+func TestForwardResponseStreamFormat(t *testing.T) {
+	msgs := []proto.Message{&pb.SimpleMessage{Id: "One"}, &pb.SimpleMessage{Id: "Two"}}
+	errStatus := status.New(codes.OutOfRange, "out of range")
+	for _, spec := range []struct {
+		name            string
+		opts            []runtime.ServeMuxOption
+		marshaler       runtime.Marshaler
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "default",
+			wantContentType: "application/json",
+			wantBody: `{"result":{"id":"One"}}` + "\n" +
+				`{"result":{"id":"Two"}}` + "\n" +
+				`{"error":{"code":11,"message":"out of range"}}` + "\n",
+		},
+		{
+			name:            "ndjson",
+			opts:            []runtime.ServeMuxOption{runtime.WithStreamFraming(runtime.StreamFramingNDJSON)},
+			marshaler:       &runtime.JSONPb{MarshalOptions: protojson.MarshalOptions{Multiline: true}},
+			wantContentType: "application/x-ndjson",
+			wantBody: `{"result":{"id":"One"}}` + "\n" +
+				`{"result":{"id":"Two"}}` + "\n" +
+				`{"error":{"code":11,"message":"out of range"}}` + "\n",
+		},
+		{
+			name:            "json-seq",
+			opts:            []runtime.ServeMuxOption{runtime.WithStreamFraming(runtime.StreamFramingJSONSeq)},
+			wantContentType: "application/json-seq",
+			wantBody: "\x1e" + `{"result":{"id":"One"}}` + "\n" +
+				"\x1e" + `{"result":{"id":"Two"}}` + "\n" +
+				"\x1e" + `{"error":{"code":11,"message":"out of range"}}` + "\n",
+		},
+		{
+			name:            "no envelope",
+			opts:            []runtime.ServeMuxOption{runtime.WithStreamEnvelope(runtime.StreamEnvelopeNone)},
+			wantContentType: "application/json",
+			wantBody: `{"id":"One"}` + "\n" +
+				`{"id":"Two"}` + "\n" +
+				`{"@type":"type.googleapis.com/google.rpc.Status","code":11,"message":"out of range"}` + "\n",
+		},
+		{
+			name:            "framing ignored by non-JSON marshalers",
+			opts:            []runtime.ServeMuxOption{runtime.WithStreamFraming(runtime.StreamFramingJSONSeq)},
+			marshaler:       &runtime.CBORMarshaler{},
+			wantContentType: "application/cbor-seq",
+			wantBody: "\xa1\x66result\xa1\x62id\x63One" +
+				"\xa1\x66result\xa1\x62id\x63Two" +
+				"\xa1\x65error\xa2\x64code\x0b\x67message\x6cout of range",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			marshaler := spec.marshaler
+			if marshaler == nil {
+				marshaler = &runtime.JSONPb{}
+			}
+			ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+			req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+			resp := httptest.NewRecorder()
+
+			runtime.ForwardResponseStream(ctx, runtime.NewServeMux(spec.opts...), marshaler, resp, req, streamOf(msgs, errStatus.Err()))
+
+			w := resp.Result()
+			if got := w.Header.Get("Content-Type"); got != spec.wantContentType {
+				t.Errorf("Content-Type = %q; want %q", got, spec.wantContentType)
+			}
+			body, err := io.ReadAll(w.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body with %v", err)
+			}
+			if got := compactJSONRecords(t, body, spec.wantContentType); got != spec.wantBody {
+				t.Errorf("ForwardResponseStream() body = %q; want %q", got, spec.wantBody)
+			}
+		})
+	}
+}
+
+// compactJSONRecords compacts the records of a JSON stream, as protojson
+// randomly adds spaces to its output. Other streams are returned as they are.
+func compactJSONRecords(t *testing.T, body []byte, contentType string) string {
+	if strings.Contains(contentType, "cbor") {
+		return string(body)
+	}
+	var sb strings.Builder
+	for _, record := range strings.SplitAfter(string(body), "\n") {
+		if record == "" {
+			continue
+		}
+		prefix := strings.TrimSuffix(record, strings.TrimLeft(record, "\x1e"))
+		sb.WriteString(prefix)
+		sb.WriteString(compactJSON(t, []byte(strings.TrimPrefix(record, prefix))))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func TestForwardResponseStreamErrorTrailers(t *testing.T) {
+	msgs := []proto.Message{&pb.SimpleMessage{Id: "One"}}
+	for _, spec := range []struct {
+		name        string
+		err         error
+		wantStatus  string
+		wantMessage string
+	}{
+		{
+			name:       "success",
+			wantStatus: "0",
+		},
+		{
+			name:        "error",
+			err:         status.Error(codes.Unavailable, "backend 100% down\n"),
+			wantStatus:  "14",
+			wantMessage: "backend 100%25 down%0A",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			mux := runtime.NewServeMux(
+				runtime.WithStreamEnvelope(runtime.StreamEnvelopeNone),
+				runtime.WithStreamErrorTrailers(),
+			)
+			ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{})
+			req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+			resp := httptest.NewRecorder()
+
+			runtime.ForwardResponseStream(ctx, mux, &runtime.JSONPb{}, resp, req, streamOf(msgs, spec.err))
+
+			w := resp.Result()
+			body, err := io.ReadAll(w.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response body with %v", err)
+			}
+			if got, want := string(body), `{"id":"One"}`+"\n"; got != want {
+				t.Errorf("ForwardResponseStream() body = %q; want %q", got, want)
+			}
+			if got := w.Trailer.Get("Grpc-Status"); got != spec.wantStatus {
+				t.Errorf("Grpc-Status trailer = %q; want %q", got, spec.wantStatus)
+			}
+			if got := w.Trailer.Get("Grpc-Message"); got != spec.wantMessage {
+				t.Errorf("Grpc-Message trailer = %q; want %q", got, spec.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const requestTimeout = "Request-Timeout"
+const xRequestTimeout = "X-Request-Timeout"
+
+// TimeoutPolicy bounds the deadline of the gRPC call made for an HTTP request.
+// A zero value for any field leaves that bound unset.
+type TimeoutPolicy struct {
+	// Default is the timeout used when the client did not ask for one.
+	// If unset, DefaultContextTimeout is used.
+	Default time.Duration
+	// Max caps the timeout requested by the client. Requests without a
+	// timeout, and without a Default, are capped as well.
+	Max time.Duration
+	// Min raises timeouts requested by the client which are shorter than Min.
+	Min time.Duration
+}
+
+// merge returns p with every unset field taken from fallback.
+func (p TimeoutPolicy) merge(fallback TimeoutPolicy) TimeoutPolicy {
+	if p.Default == 0 {
+		p.Default = fallback.Default
+	}
+	if p.Max == 0 {
+		p.Max = fallback.Max
+	}
+	if p.Min == 0 {
+		p.Min = fallback.Min
+	}
+	return p
+}
+
+// apply returns the timeout to use given the one requested by the client.
+// A timeout of zero means the call has no deadline.
+func (p TimeoutPolicy) apply(requested time.Duration, set bool) time.Duration {
+	timeout := requested
+	if !set {
+		timeout = p.Default
+		if timeout == 0 {
+			timeout = DefaultContextTimeout
+		}
+	} else if p.Min > 0 && timeout < p.Min {
+		timeout = p.Min
+	}
+	if p.Max > 0 && (timeout == 0 || timeout > p.Max) {
+		timeout = p.Max
+	}
+	return timeout
+}
+
+type requestTimeoutKey struct{}
+
+func withRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, timeout)
+}
+
+// RequestTimeout returns the timeout the gateway applied to the gRPC call
+// made for the request, if one was applied.
+func RequestTimeout(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(requestTimeoutKey{}).(time.Duration)
+	return timeout, ok
+}
+
+// timeoutPolicy returns the policy applying to the RPC method in ctx, and
+// whether any policy was configured on the mux at all.
+func (s *ServeMux) timeoutPolicy(ctx context.Context) (TimeoutPolicy, bool) {
+	if s.defaultTimeoutPolicy == nil && s.routeTimeoutPolicies == nil {
+		return TimeoutPolicy{}, false
+	}
+	var policy TimeoutPolicy
+	if s.defaultTimeoutPolicy != nil {
+		policy = *s.defaultTimeoutPolicy
+	}
+	if method, ok := RPCMethod(ctx); ok {
+		if route, ok := s.routeTimeoutPolicies[method]; ok {
+			policy = route.merge(policy)
+		}
+	}
+	return policy, true
+}
+
+// requestTimeout determines the timeout of the gRPC call made for req.
+//
+// Without a timeout policy only the Grpc-Timeout header is honored, falling
+// back to DefaultContextTimeout. With a policy, the Request-Timeout and
+// X-Request-Timeout headers are honored as well, and the result is bounded by
+// the policy.
+func (s *ServeMux) requestTimeout(ctx context.Context, req *http.Request) (time.Duration, error) {
+	policy, ok := s.timeoutPolicy(ctx)
+
+	var requested time.Duration
+	var set bool
+	if tm := req.Header.Get(metadataGrpcTimeout); tm != "" {
+		var err error
+		requested, err = timeoutDecode(tm)
+		if err != nil {
+			return 0, status.Errorf(codes.InvalidArgument, "invalid grpc-timeout: %s", tm)
+		}
+		set = true
+	} else if ok {
+		for _, key := range []string{requestTimeout, xRequestTimeout} {
+			tm := req.Header.Get(key)
+			if tm == "" {
+				continue
+			}
+			var err error
+			requested, err = parseRequestTimeout(tm)
+			if err != nil {
+				return 0, status.Errorf(codes.InvalidArgument, "invalid %s: %s", strings.ToLower(key), tm)
+			}
+			set = true
+			break
+		}
+	}
+
+	if !ok {
+		if !set {
+			return DefaultContextTimeout, nil
+		}
+		return requested, nil
+	}
+	return policy.apply(requested, set), nil
+}
+
+// parseRequestTimeout parses the value of a Request-Timeout or
+// X-Request-Timeout header, which is either a number of seconds ("2.5") or a
+// Go duration ("2500ms").
+func parseRequestTimeout(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if secs, err := strconv.ParseFloat(s, 64); err == nil {
+		if secs <= 0 || secs > float64(1<<63-1)/float64(time.Second) {
+			return 0, fmt.Errorf("timeout out of range: %q", s)
+		}
+		return time.Duration(secs * float64(time.Second)), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("timeout out of range: %q", s)
+	}
+	return d, nil
+}
+
+// deadlineExceededStatus rewrites the message of a DeadlineExceeded status to
+// state the timeout applied by the gateway, keeping its details.
+func deadlineExceededStatus(ctx context.Context, s *status.Status) *status.Status {
+	timeout, ok := RequestTimeout(ctx)
+	if !ok {
+		return s
+	}
+	pb := s.Proto()
+	pb.Message = fmt.Sprintf("request did not complete within the %s deadline: %s", timeout, pb.GetMessage())
+	return status.FromProto(pb)
+}
@@ -0,0 +1,382 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/internal/validate"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// RequestValidator validates the request messages the gateway populated
+// from HTTP requests, before they are sent to the gRPC servers. The error it
+// returns is handled as the error of the RPC.
+type RequestValidator func(ctx context.Context, req proto.Message) error
+
+// WithRequestValidator returns a ServeMuxOption which validates the request
+// messages with "validator", such as DefaultRequestValidator.
+//
+// The requests are only validated by the handlers generated with the
+// `validate_requests` option of protoc-gen-grpc-gateway.
+func WithRequestValidator(validator RequestValidator) ServeMuxOption {
+	return func(mux *ServeMux) {
+		mux.requestValidator = validator
+	}
+}
+
+type requestValidatorKey struct{}
+
+func withRequestValidator(ctx context.Context, validator RequestValidator) context.Context {
+	return context.WithValue(ctx, requestValidatorKey{}, validator)
+}
+
+// ValidateRequest validates "req" with the RequestValidator of the ServeMux
+// which annotated "ctx", if any. It is called by the generated handlers.
+func ValidateRequest(ctx context.Context, req proto.Message) error {
+	validator, ok := ctx.Value(requestValidatorKey{}).(RequestValidator)
+	if !ok || validator == nil {
+		return nil
+	}
+	return validator(ctx, req)
+}
+
+// DefaultRequestValidator is a RequestValidator enforcing the REQUIRED
+// google.api.field_behavior of fields, and the buf.validate (protovalidate)
+// rules on the length, pattern, affixes and values of strings, the length of
+// bytes, the bounds of numbers, the values of enums and the number and
+// uniqueness of items. Other buf.validate rules, such as CEL expressions, are
+// left to the gRPC servers.
+//
+// The REQUIRED fields of the messages of update requests, which have a
+// google.protobuf.FieldMask field next to the message being updated as in
+// https://google.aip.dev/134, are only enforced if the mask covers them.
+//
+// It fails with an InvalidArgument status carrying a
+// google.rpc.BadRequest detail listing the violations.
+func DefaultRequestValidator(ctx context.Context, req proto.Message) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	validateMessage(req.ProtoReflect(), "", nil, &violations)
+	if len(violations) == 0 {
+		return nil
+	}
+	msg := fmt.Sprintf("invalid request: %s: %s", violations[0].GetField(), violations[0].GetDescription())
+	if len(violations) > 1 {
+		msg += fmt.Sprintf(" (and %d more violations)", len(violations)-1)
+	}
+	st, err := status.New(codes.InvalidArgument, msg).WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	return st.Err()
+}
+
+// fieldConstraints are the constraints of a field DefaultRequestValidator
+// enforces.
+type fieldConstraints struct {
+	required bool
+	rules    *validate.Rules
+	pattern  *regexp.Regexp
+	items    *regexp.Regexp
+}
+
+var fieldConstraintsCache sync.Map // protoreflect.FieldDescriptor -> *fieldConstraints
+
+func constraintsOf(fd protoreflect.FieldDescriptor) *fieldConstraints {
+	if c, ok := fieldConstraintsCache.Load(fd); ok {
+		return c.(*fieldConstraints)
+	}
+	c := &fieldConstraints{}
+	if opts, ok := fd.Options().(*descriptorpb.FieldOptions); ok && opts != nil {
+		behaviors, _ := proto.GetExtension(opts, annotations.E_FieldBehavior).([]annotations.FieldBehavior)
+		c.required = slices.Contains(behaviors, annotations.FieldBehavior_REQUIRED)
+		c.rules = validate.FieldRules(opts)
+	}
+	if c.rules != nil {
+		c.required = c.required || c.rules.Required
+		c.pattern = compilePattern(c.rules.Pattern)
+		if c.rules.Items != nil {
+			c.items = compilePattern(c.rules.Items.Pattern)
+		}
+	}
+	actual, _ := fieldConstraintsCache.LoadOrStore(fd, c)
+	return actual.(*fieldConstraints)
+}
+
+func compilePattern(pattern string) *regexp.Regexp {
+	if pattern == "" {
+		return nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
+const fieldMaskFullName = "google.protobuf.FieldMask"
+
+// updateMask is the field mask of an update request, and the path of the
+// message being validated relative to the message it applies to.
+type updateMask struct {
+	paths  []string
+	prefix string
+}
+
+// requestUpdateMask returns the field mask of the update request "m", or nil
+// if it has none.
+func requestUpdateMask(m protoreflect.Message) *updateMask {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.Message() == nil || fd.Message().FullName() != fieldMaskFullName || fd.IsList() || !m.Has(fd) {
+			continue
+		}
+		mask := m.Get(fd).Message()
+		paths := mask.Get(mask.Descriptor().Fields().ByName("paths")).List()
+		if paths.Len() == 0 {
+			continue
+		}
+		u := &updateMask{}
+		for j := 0; j < paths.Len(); j++ {
+			u.paths = append(u.paths, paths.Get(j).String())
+		}
+		return u
+	}
+	return nil
+}
+
+// field returns the mask of the message field "name".
+func (u *updateMask) field(name protoreflect.Name) *updateMask {
+	if u == nil {
+		return nil
+	}
+	return &updateMask{paths: u.paths, prefix: u.prefix + string(name) + "."}
+}
+
+// covers reports whether the mask covers the field "name".
+func (u *updateMask) covers(name protoreflect.Name) bool {
+	if u == nil {
+		return true
+	}
+	path := u.prefix + string(name)
+	for _, p := range u.paths {
+		if p == "*" || p == path || strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// validateMessage appends the violations of "m", found at "path" of the
+// request, to "violations". The REQUIRED fields of "m" are only enforced if
+// "mask" covers them.
+func validateMessage(m protoreflect.Message, path string, mask *updateMask, violations *[]*errdetails.BadRequest_FieldViolation) {
+	var requestMask *updateMask
+	if path == "" {
+		requestMask = requestUpdateMask(m)
+	}
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		fieldPath := string(fd.Name())
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+		fieldMask := mask.field(fd.Name())
+		if requestMask != nil && fd.Message() != nil && fd.Message().FullName() != fieldMaskFullName {
+			fieldMask = requestMask
+		}
+		violate := func(format string, args ...any) {
+			*violations = append(*violations, &errdetails.BadRequest_FieldViolation{
+				Field:       fieldPath,
+				Description: fmt.Sprintf(format, args...),
+			})
+		}
+		c := constraintsOf(fd)
+		if !m.Has(fd) {
+			if c.required && mask.covers(fd.Name()) {
+				violate("value is required")
+				continue
+			}
+			// Fields with presence are only validated when set, and the
+			// others even when empty.
+			if fd.HasPresence() {
+				continue
+			}
+		}
+		v := m.Get(fd)
+		if c.rules != nil && c.rules.IgnoreIfZeroValue && (!m.Has(fd) || !fd.IsList() && isZeroValue(fd, v)) {
+			continue
+		}
+		switch {
+		case fd.IsList():
+			list := v.List()
+			if r := c.rules; r != nil {
+				n := uint64(list.Len())
+				if r.MinItems != nil && n < *r.MinItems {
+					violate("value must contain at least %d item(s)", *r.MinItems)
+				}
+				if r.MaxItems != nil && n > *r.MaxItems {
+					violate("value must contain no more than %d item(s)", *r.MaxItems)
+				}
+				if r.UniqueItems && !uniqueItems(list) {
+					violate("repeated value must contain unique items")
+				}
+			}
+			for j := 0; j < list.Len(); j++ {
+				itemPath := fmt.Sprintf("%s[%d]", fieldPath, j)
+				if fd.Message() != nil {
+					validateMessage(list.Get(j).Message(), itemPath, fieldMask, violations)
+					continue
+				}
+				if c.rules != nil && c.rules.Items != nil {
+					if c.rules.Items.IgnoreIfZeroValue && isZeroValue(fd, list.Get(j)) {
+						continue
+					}
+					validateValue(fd, list.Get(j), c.rules.Items, c.items, itemPath, violations)
+				}
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() == nil {
+				continue
+			}
+			v.Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+				validateMessage(value.Message(), fmt.Sprintf("%s[%q]", fieldPath, key.String()), fieldMask, violations)
+				return true
+			})
+		case fd.Message() != nil:
+			validateMessage(v.Message(), fieldPath, fieldMask, violations)
+		case c.rules != nil:
+			validateValue(fd, v, c.rules, c.pattern, fieldPath, violations)
+		}
+	}
+}
+
+// validateValue validates the scalar value "v" of the field "fd" with the
+// rules "r".
+func validateValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, r *validate.Rules, pattern *regexp.Regexp, path string, violations *[]*errdetails.BadRequest_FieldViolation) {
+	violate := func(format string, args ...any) {
+		*violations = append(*violations, &errdetails.BadRequest_FieldViolation{
+			Field:       path,
+			Description: fmt.Sprintf(format, args...),
+		})
+	}
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		s := v.String()
+		n := uint64(utf8.RuneCountInString(s))
+		if r.MinLen != nil && n < *r.MinLen {
+			violate("value length must be at least %d characters", *r.MinLen)
+		}
+		if r.MaxLen != nil && n > *r.MaxLen {
+			violate("value length must be at most %d characters", *r.MaxLen)
+		}
+		if pattern != nil && !pattern.MatchString(s) {
+			violate("value does not match regex pattern %q", r.Pattern)
+		}
+		if r.Prefix != "" && !strings.HasPrefix(s, r.Prefix) {
+			violate("value does not have prefix %q", r.Prefix)
+		}
+		if r.Suffix != "" && !strings.HasSuffix(s, r.Suffix) {
+			violate("value does not have suffix %q", r.Suffix)
+		}
+		if r.Contains != "" && !strings.Contains(s, r.Contains) {
+			violate("value does not contain substring %q", r.Contains)
+		}
+		if len(r.In) > 0 && !slices.Contains(r.In, s) {
+			violate("value must be in list %v", r.In)
+		}
+	case protoreflect.BytesKind:
+		n := uint64(len(v.Bytes()))
+		if r.MinLen != nil && n < *r.MinLen {
+			violate("value length must be at least %d bytes", *r.MinLen)
+		}
+		if r.MaxLen != nil && n > *r.MaxLen {
+			violate("value length must be at most %d bytes", *r.MaxLen)
+		}
+	case protoreflect.EnumKind:
+		if r.DefinedOnly && fd.Enum().Values().ByNumber(v.Enum()) == nil {
+			violate("value must be one of the defined enum values")
+		}
+	case protoreflect.BoolKind, protoreflect.MessageKind, protoreflect.GroupKind:
+	default:
+		var x float64
+		switch fd.Kind() {
+		case protoreflect.FloatKind, protoreflect.DoubleKind:
+			x = v.Float()
+		case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			x = float64(v.Uint())
+		default:
+			x = float64(v.Int())
+		}
+		if r.GT != nil && !(x > *r.GT) {
+			violate("value must be greater than %v", *r.GT)
+		}
+		if r.GTE != nil && !(x >= *r.GTE) {
+			violate("value must be greater than or equal to %v", *r.GTE)
+		}
+		if r.LT != nil && !(x < *r.LT) {
+			violate("value must be less than %v", *r.LT)
+		}
+		if r.LTE != nil && !(x <= *r.LTE) {
+			violate("value must be less than or equal to %v", *r.LTE)
+		}
+	}
+}
+
+// isZeroValue reports whether the scalar value "v" of the field "fd" is the
+// zero value of its kind. Messages are never zero once set.
+func isZeroValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return false
+	case protoreflect.StringKind:
+		return v.String() == ""
+	case protoreflect.BytesKind:
+		return len(v.Bytes()) == 0
+	case protoreflect.BoolKind:
+		return !v.Bool()
+	case protoreflect.EnumKind:
+		return v.Enum() == 0
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float() == 0
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return v.Uint() == 0
+	default:
+		return v.Int() == 0
+	}
+}
+
+func uniqueItems(list protoreflect.List) bool {
+	for i := 0; i < list.Len(); i++ {
+		for j := 0; j < i; j++ {
+			if equalValues(list.Get(i), list.Get(j)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func equalValues(a, b protoreflect.Value) bool {
+	switch x := a.Interface().(type) {
+	case []byte:
+		return bytes.Equal(x, b.Bytes())
+	case protoreflect.Message:
+		return proto.Equal(x.Interface(), b.Message().Interface())
+	default:
+		return a.Interface() == b.Interface()
+	}
+}
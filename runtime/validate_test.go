@@ -0,0 +1,297 @@
+package runtime_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// bufValidate returns the encoding of a buf.validate.field extension with
+// the rules of "kind", the number of its field in buf.validate.FieldRules,
+// encoded in "rules".
+func bufValidate(kind protowire.Number, rules []byte) []byte {
+	var field []byte
+	field = protowire.AppendTag(field, kind, protowire.BytesType)
+	field = protowire.AppendBytes(field, rules)
+	var b []byte
+	b = protowire.AppendTag(b, 1159, protowire.BytesType)
+	return protowire.AppendBytes(b, field)
+}
+
+func validatedField(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label, required bool, rules []byte) *descriptorpb.FieldDescriptorProto {
+	opts := &descriptorpb.FieldOptions{}
+	if required {
+		proto.SetExtension(opts, annotations.E_FieldBehavior, []annotations.FieldBehavior{annotations.FieldBehavior_REQUIRED})
+	}
+	opts.ProtoReflect().SetUnknown(rules)
+	return &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(number),
+		Type:     typ.Enum(),
+		Label:    label.Enum(),
+		JsonName: proto.String(name),
+		Options:  opts,
+	}
+}
+
+// newValidatedMessage returns a message with the fields:
+//
+//	string name = 1 [(google.api.field_behavior) = REQUIRED];
+//	string title = 2 [(buf.validate.field).string = {min_len: 2, pattern: "^[A-Z]"}];
+//	int32 pages = 3 [(buf.validate.field).int32 = {gt: 0, lte: 1000}];
+//	repeated string tags = 4 [(buf.validate.field).repeated = {max_items: 2, unique: true}];
+//	string isbn = 5 [(buf.validate.field) = {string: {len: 13}, ignore: IGNORE_IF_ZERO_VALUE}];
+func newValidatedMessage(t *testing.T) protoreflect.Message {
+	t.Helper()
+	return dynamicpb.NewMessage(validatedFile(t).Messages().ByName("Book"))
+}
+
+// newUpdateRequest returns a message with the fields:
+//
+//	Book book = 1;
+//	google.protobuf.FieldMask update_mask = 2;
+func newUpdateRequest(t *testing.T) protoreflect.Message {
+	t.Helper()
+	return dynamicpb.NewMessage(validatedFile(t).Messages().ByName("UpdateBookRequest"))
+}
+
+func validatedFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+	var title []byte
+	title = protowire.AppendTag(title, 2, protowire.VarintType)
+	title = protowire.AppendVarint(title, 2)
+	title = protowire.AppendTag(title, 6, protowire.BytesType)
+	title = protowire.AppendString(title, "^[A-Z]")
+	var pages []byte
+	pages = protowire.AppendTag(pages, 4, protowire.VarintType)
+	pages = protowire.AppendVarint(pages, 0)
+	pages = protowire.AppendTag(pages, 3, protowire.VarintType)
+	pages = protowire.AppendVarint(pages, 1000)
+	var tags []byte
+	tags = protowire.AppendTag(tags, 2, protowire.VarintType)
+	tags = protowire.AppendVarint(tags, 2)
+	tags = protowire.AppendTag(tags, 3, protowire.VarintType)
+	tags = protowire.AppendVarint(tags, 1)
+	var isbn []byte
+	isbn = protowire.AppendTag(isbn, 14, protowire.BytesType)
+	isbn = protowire.AppendBytes(isbn, protowire.AppendVarint(protowire.AppendTag(nil, 19, protowire.VarintType), 13))
+	isbn = protowire.AppendTag(isbn, 27, protowire.VarintType)
+	isbn = protowire.AppendVarint(isbn, 1) // IGNORE_IF_ZERO_VALUE
+	isbnRules := protowire.AppendTag(nil, 1159, protowire.BytesType)
+	isbnRules = protowire.AppendBytes(isbnRules, isbn)
+
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	str := descriptorpb.FieldDescriptorProto_TYPE_STRING
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("validate_test.proto"),
+		Package:    proto.String("runtime.test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/api/field_behavior.proto", "google/protobuf/field_mask.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Book"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					validatedField("name", 1, str, optional, true, nil),
+					validatedField("title", 2, str, optional, false, bufValidate(14, title)),
+					validatedField("pages", 3, descriptorpb.FieldDescriptorProto_TYPE_INT32, optional, false, bufValidate(3, pages)),
+					validatedField("tags", 4, str, descriptorpb.FieldDescriptorProto_LABEL_REPEATED, false, bufValidate(18, tags)),
+					validatedField("isbn", 5, str, optional, false, isbnRules),
+				},
+			},
+			{
+				Name: proto.String("UpdateBookRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{
+						Name:     proto.String("book"),
+						Number:   proto.Int32(1),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						Label:    optional.Enum(),
+						TypeName: proto.String(".runtime.test.Book"),
+					},
+					{
+						Name:     proto.String("update_mask"),
+						Number:   proto.Int32(2),
+						Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+						Label:    optional.Enum(),
+						TypeName: proto.String(".google.protobuf.FieldMask"),
+					},
+				},
+			},
+		},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatalf("protodesc.NewFile() failed with %v", err)
+	}
+	return fd
+}
+
+// fieldViolations returns the field violations of the status "err".
+func fieldViolations(t *testing.T, err error) []*errdetails.BadRequest_FieldViolation {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		t.Fatalf("runtime.DefaultRequestValidator() = %v; want an InvalidArgument status", err)
+	}
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range st.Details() {
+		if br, ok := detail.(*errdetails.BadRequest); ok {
+			violations = br.GetFieldViolations()
+		}
+	}
+	return violations
+}
+
+func TestDefaultRequestValidator(t *testing.T) {
+	for _, spec := range []struct {
+		name   string
+		fields map[string]any
+		want   []*errdetails.BadRequest_FieldViolation
+	}{
+		{
+			name:   "valid",
+			fields: map[string]any{"name": "books/1", "title": "Go", "pages": int32(200), "tags": []string{"a", "b"}},
+		},
+		{
+			name:   "missing required field",
+			fields: map[string]any{"title": "Go", "pages": int32(1)},
+			want: []*errdetails.BadRequest_FieldViolation{
+				{Field: "name", Description: "value is required"},
+			},
+		},
+		{
+			name:   "invalid values",
+			fields: map[string]any{"name": "books/1", "title": "g", "pages": int32(0), "tags": []string{"a", "a", "b"}, "isbn": "123"},
+			want: []*errdetails.BadRequest_FieldViolation{
+				{Field: "title", Description: "value length must be at least 2 characters"},
+				{Field: "title", Description: `value does not match regex pattern "^[A-Z]"`},
+				{Field: "pages", Description: "value must be greater than 0"},
+				{Field: "tags", Description: "value must contain no more than 2 item(s)"},
+				{Field: "tags", Description: "repeated value must contain unique items"},
+				{Field: "isbn", Description: "value length must be at least 13 characters"},
+			},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			msg := newValidatedMessage(t)
+			fields := msg.Descriptor().Fields()
+			for name, v := range spec.fields {
+				fd := fields.ByName(protoreflect.Name(name))
+				switch v := v.(type) {
+				case []string:
+					list := msg.Mutable(fd).List()
+					for _, s := range v {
+						list.Append(protoreflect.ValueOfString(s))
+					}
+				default:
+					msg.Set(fd, protoreflect.ValueOf(v))
+				}
+			}
+
+			err := runtime.DefaultRequestValidator(context.Background(), msg.Interface())
+			if spec.want == nil {
+				if err != nil {
+					t.Errorf("runtime.DefaultRequestValidator() failed with %v; want success", err)
+				}
+				return
+			}
+			got := fieldViolations(t, err)
+			if diff := cmp.Diff(spec.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("field violations differ (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultRequestValidatorUpdateMask(t *testing.T) {
+	for _, spec := range []struct {
+		name  string
+		paths []string
+		want  []*errdetails.BadRequest_FieldViolation
+	}{
+		{
+			name: "no mask",
+			want: []*errdetails.BadRequest_FieldViolation{
+				{Field: "book.name", Description: "value is required"},
+			},
+		},
+		{
+			name:  "masked fields",
+			paths: []string{"title"},
+		},
+		{
+			name:  "masked required field",
+			paths: []string{"name", "title"},
+			want: []*errdetails.BadRequest_FieldViolation{
+				{Field: "book.name", Description: "value is required"},
+			},
+		},
+		{
+			name:  "wildcard",
+			paths: []string{"*"},
+			want: []*errdetails.BadRequest_FieldViolation{
+				{Field: "book.name", Description: "value is required"},
+			},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			req := newUpdateRequest(t)
+			fields := req.Descriptor().Fields()
+			book := req.Mutable(fields.ByName("book")).Message()
+			book.Set(book.Descriptor().Fields().ByName("title"), protoreflect.ValueOfString("Go"))
+			book.Set(book.Descriptor().Fields().ByName("pages"), protoreflect.ValueOfInt32(1))
+			if spec.paths != nil {
+				req.Set(fields.ByName("update_mask"), protoreflect.ValueOfMessage((&fieldmaskpb.FieldMask{Paths: spec.paths}).ProtoReflect()))
+			}
+
+			err := runtime.DefaultRequestValidator(context.Background(), req.Interface())
+			if spec.want == nil {
+				if err != nil {
+					t.Errorf("runtime.DefaultRequestValidator() failed with %v; want success", err)
+				}
+				return
+			}
+			if diff := cmp.Diff(spec.want, fieldViolations(t, err), protocmp.Transform()); diff != "" {
+				t.Errorf("field violations differ (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	msg := newValidatedMessage(t).Interface()
+	r := httptest.NewRequest(http.MethodPost, "/v1/books", nil)
+
+	ctx, err := runtime.AnnotateContext(context.Background(), runtime.NewServeMux(), r, "/runtime.test.Library/CreateBook")
+	if err != nil {
+		t.Fatalf("runtime.AnnotateContext() failed with %v", err)
+	}
+	if err := runtime.ValidateRequest(ctx, msg); err != nil {
+		t.Errorf("runtime.ValidateRequest() failed with %v; want no validation without a validator", err)
+	}
+
+	mux := runtime.NewServeMux(runtime.WithRequestValidator(runtime.DefaultRequestValidator))
+	ctx, err = runtime.AnnotateContext(context.Background(), mux, r, "/runtime.test.Library/CreateBook")
+	if err != nil {
+		t.Fatalf("runtime.AnnotateContext() failed with %v", err)
+	}
+	if err := runtime.ValidateRequest(ctx, msg); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("runtime.ValidateRequest() = %v; want an InvalidArgument status", err)
+	}
+}